@@ -27,6 +27,7 @@ type settings struct {
 	prune                     bool
 	target                    string
 	secretPath                string
+	caBundle                  string
 	resultFileImageDigest     string
 	resultFileSourceTimestamp string
 	showListing               bool
@@ -45,6 +46,7 @@ func init() {
 	pflag.StringVar(&flagValues.resultFileSourceTimestamp, "result-file-source-timestamp", "", "A file to write the source timestamp")
 
 	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains access credentials (optional)")
+	pflag.StringVar(&flagValues.caBundle, "ca-bundle", "", "A file with PEM encoded certificate authorities to trust in addition to the system ones, enables the TLS verification of the registry (optional)")
 	pflag.BoolVar(&flagValues.prune, "prune", false, "Delete bundle image from registry after it was pulled")
 	pflag.BoolVar(&flagValues.showListing, "show-listing", false, "Print file listing of files unpacked from the bundle")
 }
@@ -80,12 +82,15 @@ func Do(ctx context.Context) error {
 		}
 	}
 
-	ref, err := image.ParseReference(flagValues.image, true)
+	// the registry is only verified if its certificate authorities are known
+	insecure := flagValues.caBundle == ""
+
+	ref, err := image.ParseReference(flagValues.image, insecure)
 	if err != nil {
 		return err
 	}
 
-	options, auth, err := image.GetOptions(ctx, ref, true, flagValues.secretPath, "Shipwright Build")
+	options, auth, err := image.GetOptions(ctx, ref, insecure, flagValues.caBundle, flagValues.secretPath, "Shipwright Build")
 	if err != nil {
 		return err
 	}
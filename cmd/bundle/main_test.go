@@ -168,7 +168,7 @@ var _ = Describe("Bundle Loader", func() {
 		var dockerConfigFile string
 
 		var copyImage = func(src, dst name.Reference) {
-			options, _, err := image.GetOptions(context.TODO(), src, true, dockerConfigFile, "test-agent")
+			options, _, err := image.GetOptions(context.TODO(), src, true, "", dockerConfigFile, "test-agent")
 			Expect(err).ToNot(HaveOccurred())
 
 			srcDesc, err := remote.Get(src, options...)
@@ -177,7 +177,7 @@ var _ = Describe("Bundle Loader", func() {
 			srcImage, err := srcDesc.Image()
 			Expect(err).ToNot(HaveOccurred())
 
-			options, _, err = image.GetOptions(context.TODO(), dst, true, dockerConfigFile, "test-agent")
+			options, _, err = image.GetOptions(context.TODO(), dst, true, "", dockerConfigFile, "test-agent")
 			Expect(err).ToNot(HaveOccurred())
 
 			err = remote.Write(dst, srcImage, options...)
@@ -215,7 +215,7 @@ var _ = Describe("Bundle Loader", func() {
 				ref, err := name.ParseReference(testImage)
 				Expect(err).ToNot(HaveOccurred())
 
-				options, auth, err := image.GetOptions(context.TODO(), ref, true, dockerConfigFile, "test-agent")
+				options, auth, err := image.GetOptions(context.TODO(), ref, true, "", dockerConfigFile, "test-agent")
 				Expect(err).ToNot(HaveOccurred())
 
 				// Delete test image (best effort)
@@ -249,7 +249,7 @@ var _ = Describe("Bundle Loader", func() {
 				ref, err := name.ParseReference(testImage)
 				Expect(err).ToNot(HaveOccurred())
 
-				options, _, err := image.GetOptions(context.TODO(), ref, true, dockerConfigFile, "test-agent")
+				options, _, err := image.GetOptions(context.TODO(), ref, true, "", dockerConfigFile, "test-agent")
 				Expect(err).ToNot(HaveOccurred())
 
 				_, err = remote.Head(ref, options...)
@@ -6,15 +6,23 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/pflag"
 
@@ -28,6 +36,14 @@ const (
 	typeUndef credentialType = iota
 	typePrivateKey
 	typeUsernamePassword
+	typeGitHubApp
+)
+
+// The results of the step are reported through the termination message of the Pod, which is
+// limited to 4 KB for all results, the results with unbounded values are therefore truncated
+const (
+	maxCommitMessageLength = 256
+	maxTagsLength          = 1024
 )
 
 var (
@@ -68,6 +84,25 @@ type settings struct {
 	resultFileErrorReason     string
 	verbose                   bool
 	showListing               bool
+	sparseCheckout            []string
+	signatureVerificationPath string
+	resultFileSigner          string
+	pullRequestRef            string
+	pullRequestMerge          bool
+	resultFilePRHeadSha       string
+	resultFilePRBaseSha       string
+	resultFilePRMergeSha      string
+	skipLFS                   bool
+	lfsInclude                []string
+	lfsExclude                []string
+	lfsSkipSmudge             bool
+	resultFileLFSObjects      string
+	resultFileLFSSize         string
+	caBundle                  string
+	resultFileCommitMessage   string
+	resultFileCommitTimestamp string
+	resultFileTags            string
+	resultFileDescribe        string
 }
 
 var flagValues settings
@@ -92,7 +127,12 @@ func init() {
 	pflag.StringVar(&flagValues.resultFileCommitAuthor, "result-file-commit-author", "", "A file to write the commit author to.")
 	pflag.StringVar(&flagValues.resultFileSourceTimestamp, "result-file-source-timestamp", "", "A file to write the source timestamp to.")
 	pflag.StringVar(&flagValues.resultFileBranchName, "result-file-branch-name", "", "A file to write the branch name to.")
-	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains a secret. Either username and password for basic authentication. Or a SSH private key and optionally a known hosts file. Optional.")
+	pflag.StringVar(&flagValues.resultFileCommitMessage, "result-file-commit-message", "", "A file to write the subject of the commit message to, it is truncated to 256 bytes.")
+	pflag.StringVar(&flagValues.resultFileCommitTimestamp, "result-file-commit-timestamp", "", "A file to write the committer timestamp of the commit to.")
+	pflag.StringVar(&flagValues.resultFileTags, "result-file-tags", "", "A file to write the tags that point to the commit to, one per line, the list is truncated to 1024 bytes. The tags are listed with an additional request to the remote repository.")
+	pflag.StringVar(&flagValues.resultFileDescribe, "result-file-describe", "", "A file to write the output of git describe --tags to, the file is empty if no tag is reachable from the commit. Only tags of commits in the history of the clone are fetched, with a depth of 1 these are the tags of the commit itself.")
+	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains a secret. Either username and password for basic authentication. Or a SSH private key and optionally a known hosts file. Or the app ID, installation ID and private key of a GitHub App. Optional.")
+	pflag.StringVar(&flagValues.caBundle, "ca-bundle", "", "A file with PEM encoded certificate authorities to trust in addition to the system ones. Optional.")
 
 	// Flags with paths for writing error related information
 	pflag.StringVar(&flagValues.resultFileErrorMessage, "result-file-error-message", "", "A file to write the error message to.")
@@ -103,6 +143,29 @@ func init() {
 	// for (in the context of Shipwright build).
 	pflag.UintVar(&flagValues.depth, "depth", 1, "Create a shallow clone based on the given depth")
 
+	// Optional flag to only check out some directories of the repository, which
+	// is useful for large repositories where the build only needs a subdirectory.
+	pflag.StringArrayVar(&flagValues.sparseCheckout, "sparse-checkout", nil, "A directory of the repository to check out, can be specified multiple times. Optional, defaults to the whole repository.")
+
+	// Optional flags to verify the signature of the checked out commit or tag
+	pflag.StringVar(&flagValues.signatureVerificationPath, "signature-verification-path", "", "A directory that contains the trusted keys to verify the signature of the commit or tag. Either GPG public keys in a file gpg-public-keys, or a SSH allowed signers file allowed_signers. Optional.")
+	pflag.StringVar(&flagValues.resultFileSigner, "result-file-signer", "", "A file to write the signer of the commit or tag to.")
+
+	// Optional flags to build a pull request instead of the revision, the revision is then the base branch
+	pflag.StringVar(&flagValues.pullRequestRef, "pull-request-ref", "", "The ref of the head of the pull request to check out, for example refs/pull/1/head. Optional.")
+	pflag.BoolVar(&flagValues.pullRequestMerge, "pull-request-merge", false, "Merge the head of the pull request into the revision instead of checking it out")
+	pflag.StringVar(&flagValues.resultFilePRHeadSha, "result-file-pull-request-head-sha", "", "A file to write the commit sha of the head of the pull request to.")
+	pflag.StringVar(&flagValues.resultFilePRBaseSha, "result-file-pull-request-base-sha", "", "A file to write the commit sha of the base branch of the pull request to.")
+	pflag.StringVar(&flagValues.resultFilePRMergeSha, "result-file-pull-request-merge-sha", "", "A file to write the commit sha of the merge of the pull request to.")
+
+	// Optional flags to control which Git Large File Storage (LFS) files are downloaded
+	pflag.BoolVar(&flagValues.skipLFS, "skip-lfs", false, "Do not download Git LFS files, the LFS pointer files are checked out instead")
+	pflag.StringArrayVar(&flagValues.lfsInclude, "lfs-include", nil, "A pattern of Git LFS files to download, can be specified multiple times. Optional, defaults to all files.")
+	pflag.StringArrayVar(&flagValues.lfsExclude, "lfs-exclude", nil, "A pattern of Git LFS files to not download, can be specified multiple times. Optional.")
+	pflag.BoolVar(&flagValues.lfsSkipSmudge, "lfs-skip-smudge", false, "Download the Git LFS files in a single batch after the checkout instead of during the checkout")
+	pflag.StringVar(&flagValues.resultFileLFSObjects, "result-file-lfs-objects", "", "A file to write the number of downloaded Git LFS objects to.")
+	pflag.StringVar(&flagValues.resultFileLFSSize, "result-file-lfs-size", "", "A file to write the total size in bytes of the downloaded Git LFS objects to.")
+
 	// Mostly internal flag
 	pflag.BoolVar(&flagValues.skipValidation, "skip-validation", false, "skip pre-requisite validation")
 	pflag.BoolVar(&flagValues.gitURLRewrite, "git-url-rewrite", false, "set Git config to use url-insteadOf setting based on Git repository URL")
@@ -118,7 +181,13 @@ func main() {
 			exitcode = err.Code
 		}
 
-		if writeErr := writeErrorResults(shpgit.NewErrorResultFromMessage(err.Error())); writeErr != nil {
+		errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+		var exitError *ExitError
+		if errors.As(err, &exitError) && exitError.Reason != shpgit.Unknown {
+			errorResult = &shpgit.ErrorResult{Message: exitError.Message, Reason: exitError.Reason}
+		}
+
+		if writeErr := writeErrorResults(errorResult); writeErr != nil {
 			log.Printf("Could not write error results: %s", writeErr.Error())
 		}
 
@@ -181,6 +250,45 @@ func runGitClone(ctx context.Context) error {
 		return err
 	}
 
+	if flagValues.signatureVerificationPath != "" {
+		signer, err := verifySignature(ctx)
+		if err != nil {
+			return err
+		}
+
+		if flagValues.resultFileSigner != "" {
+			// #nosec G306 the file must be readable by build steps that potentially run as a different user
+			if err := os.WriteFile(flagValues.resultFileSigner, []byte(signer), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	if flagValues.resultFileLFSObjects != "" || flagValues.resultFileLFSSize != "" {
+		objects, size, err := lfsObjects()
+		if err != nil {
+			return err
+		}
+
+		if objects > 0 {
+			log.Printf("Downloaded %d Git LFS objects with a total size of %d bytes\n", objects, size)
+		}
+
+		if flagValues.resultFileLFSObjects != "" {
+			// #nosec G306 the file must be readable by build steps that potentially run as a different user
+			if err := os.WriteFile(flagValues.resultFileLFSObjects, []byte(strconv.FormatInt(objects, 10)), 0644); err != nil {
+				return err
+			}
+		}
+
+		if flagValues.resultFileLFSSize != "" {
+			// #nosec G306 the file must be readable by build steps that potentially run as a different user
+			if err := os.WriteFile(flagValues.resultFileLFSSize, []byte(strconv.FormatInt(size, 10)), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
 	if flagValues.showListing {
 		// ignore any errors when walking through the file system, the listing is only for informational purposes
 		_ = util.ListFiles(log.Writer(), flagValues.target)
@@ -210,6 +318,58 @@ func runGitClone(ctx context.Context) error {
 		}
 	}
 
+	if flagValues.resultFileCommitMessage != "" {
+		output, err := git(ctx, "-C", flagValues.target, "log", "-1", "--pretty=format:%s")
+		if err != nil {
+			return err
+		}
+
+		// #nosec G306 the file must be readable by build steps that potentially run as a different user
+		if err = os.WriteFile(flagValues.resultFileCommitMessage, []byte(truncate(output, maxCommitMessageLength)), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileCommitTimestamp != "" {
+		output, err := git(ctx, "-C", flagValues.target, "show", "--no-patch", "--format=%ct")
+		if err != nil {
+			return err
+		}
+
+		// #nosec G306 the file must be readable by build steps that potentially run as a different user
+		if err = os.WriteFile(flagValues.resultFileCommitTimestamp, []byte(output), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileTags != "" {
+		output, err := git(ctx, "-C", flagValues.target, "tag", "--points-at", "HEAD")
+		if err != nil {
+			return err
+		}
+
+		// #nosec G306 the file must be readable by build steps that potentially run as a different user
+		if err = os.WriteFile(flagValues.resultFileTags, []byte(truncateLines(output, maxTagsLength)), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileDescribe != "" {
+		// git describe fails if no tag is reachable from the commit, for example in
+		// a shallow clone, the result is then written empty, because a task that
+		// references a result that was not written fails
+		output, err := git(ctx, "-C", flagValues.target, "describe", "--tags")
+		if err != nil {
+			log.Printf("Unable to describe the commit with a tag: %v\n", err)
+			output = ""
+		}
+
+		// #nosec G306 the file must be readable by build steps that potentially run as a different user
+		if err = os.WriteFile(flagValues.resultFileDescribe, []byte(output), 0644); err != nil {
+			return err
+		}
+	}
+
 	if flagValues.resultFileSourceTimestamp != "" {
 		output, err := git(ctx, "-C", flagValues.target, "show", "--no-patch", "--format=%ct")
 		if err != nil {
@@ -222,7 +382,9 @@ func runGitClone(ctx context.Context) error {
 		}
 	}
 
-	if strings.TrimSpace(flagValues.revision) == "" && strings.TrimSpace(flagValues.resultFileBranchName) != "" {
+	// the head of a pull request is checked out detached, there is only a branch if it was merged
+	hasBranch := flagValues.pullRequestRef == "" || flagValues.pullRequestMerge
+	if hasBranch && strings.TrimSpace(flagValues.revision) == "" && strings.TrimSpace(flagValues.resultFileBranchName) != "" {
 		output, err := git(ctx, "-C", flagValues.target, "rev-parse", "--abbrev-ref", "HEAD")
 		if err != nil {
 			return err
@@ -280,6 +442,12 @@ func clone(ctx context.Context) error {
 		"--quiet",
 	}
 
+	// merging a pull request requires the history of both branches to find their merge base
+	depth := flagValues.depth
+	if flagValues.pullRequestRef != "" && flagValues.pullRequestMerge {
+		depth = 0
+	}
+
 	if useNoTagsFlag {
 		cloneArgs = append(cloneArgs, "--no-tags")
 	}
@@ -290,8 +458,8 @@ func clone(ctx context.Context) error {
 		// we can pass the commit SHA directly to `git clone --revision` and can honor the depth.
 		cloneArgs = append(cloneArgs, "--revision", flagValues.revision)
 
-		if flagValues.depth > 0 {
-			cloneArgs = append(cloneArgs, "--depth", fmt.Sprintf("%d", flagValues.depth))
+		if depth > 0 {
+			cloneArgs = append(cloneArgs, "--depth", fmt.Sprintf("%d", depth))
 		}
 
 	case commitShaRegEx.MatchString(flagValues.revision):
@@ -306,11 +474,16 @@ func clone(ctx context.Context) error {
 			cloneArgs = append(cloneArgs, "--branch", flagValues.revision)
 		}
 
-		if flagValues.depth > 0 {
-			cloneArgs = append(cloneArgs, "--depth", fmt.Sprintf("%d", flagValues.depth))
+		if depth > 0 {
+			cloneArgs = append(cloneArgs, "--depth", fmt.Sprintf("%d", depth))
 		}
 	}
 
+	if len(flagValues.sparseCheckout) > 0 {
+		// only download the file contents of the directories that are checked out
+		cloneArgs = append(cloneArgs, "--filter=blob:none", "--sparse")
+	}
+
 	var addtlGitArgs []string
 	if flagValues.secretPath != "" {
 		credType, err := checkCredentials()
@@ -396,20 +569,29 @@ func clone(ctx context.Context) error {
 				}
 			}
 
-		case typeUsernamePassword:
+		case typeUsernamePassword, typeGitHubApp:
 			repoURL, err := url.Parse(flagValues.url)
 			if err != nil {
 				return err
 			}
 
-			username, err := os.ReadFile(filepath.Join(flagValues.secretPath, "username"))
-			if err != nil {
-				return err
-			}
+			var username, password []byte
+			if credType == typeGitHubApp {
+				// the short-lived installation token of the GitHub App is used as the password
+				token, err := gitHubAppInstallationToken(ctx)
+				if err != nil {
+					return err
+				}
 
-			password, err := os.ReadFile(filepath.Join(flagValues.secretPath, "password"))
-			if err != nil {
-				return err
+				username, password = []byte(shpgit.GitHubAppTokenUsername), []byte(token)
+			} else {
+				if username, err = os.ReadFile(filepath.Join(flagValues.secretPath, "username")); err != nil {
+					return err
+				}
+
+				if password, err = os.ReadFile(filepath.Join(flagValues.secretPath, "password")); err != nil {
+					return err
+				}
 			}
 
 			repoURL.User = url.UserPassword(string(username), string(password))
@@ -433,18 +615,70 @@ func clone(ctx context.Context) error {
 		}
 	}
 
+	if flagValues.caBundle != "" {
+		caInfoFile, err := trustedCAInfoFile()
+		if err != nil {
+			return err
+		}
+		defer os.Remove(caInfoFile)
+
+		// the environment variable is used instead of the -c option of the clone, which
+		// only takes effect after the initial fetch of the clone
+		if err := os.Setenv("GIT_SSL_CAINFO", caInfoFile); err != nil {
+			return err
+		}
+		defer os.Unsetenv("GIT_SSL_CAINFO")
+	}
+
+	// the LFS configuration applies to the clone and all later commands that check out files
+	addtlGitArgs = append(addtlGitArgs, lfsConfigArgs()...)
+	if flagValues.skipLFS || flagValues.lfsSkipSmudge {
+		if err := os.Setenv("GIT_LFS_SKIP_SMUDGE", "1"); err != nil {
+			return err
+		}
+		defer os.Unsetenv("GIT_LFS_SKIP_SMUDGE")
+	}
+
 	cloneArgs = append(cloneArgs, addtlGitArgs...)
 	cloneArgs = append(cloneArgs, "--", flagValues.url, flagValues.target)
 	if _, err := git(ctx, cloneArgs...); err != nil {
 		return err
 	}
 
+	// the file contents of the sparse checkout are downloaded on demand, these
+	// commands therefore need the same credentials as the clone
+	if len(flagValues.sparseCheckout) > 0 {
+		sparseCheckoutArgs := []string{"-C", flagValues.target}
+		sparseCheckoutArgs = append(sparseCheckoutArgs, addtlGitArgs...)
+		sparseCheckoutArgs = append(sparseCheckoutArgs, "sparse-checkout", "set", "--cone", "--")
+		sparseCheckoutArgs = append(sparseCheckoutArgs, flagValues.sparseCheckout...)
+		if _, err := git(ctx, sparseCheckoutArgs...); err != nil {
+			return err
+		}
+	}
+
 	if commitSha != "" {
-		if _, err := git(ctx, "-C", flagValues.target, "checkout", commitSha); err != nil {
+		checkoutArgs := []string{"-C", flagValues.target}
+		checkoutArgs = append(checkoutArgs, addtlGitArgs...)
+		checkoutArgs = append(checkoutArgs, "checkout", commitSha)
+		if _, err := git(ctx, checkoutArgs...); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.pullRequestRef != "" {
+		if err := checkoutPullRequest(ctx, addtlGitArgs, depth); err != nil {
 			return err
 		}
 	}
 
+	// the clone does not fetch tags, fetch the ones that are needed for the results
+	if flagValues.resultFileTags != "" || flagValues.resultFileDescribe != "" {
+		if err := fetchTags(ctx, addtlGitArgs); err != nil {
+			log.Printf("Warning: failed to fetch the tags of the commit: %v\n", err)
+		}
+	}
+
 	submoduleArgs := []string{"-C", flagValues.target}
 	submoduleArgs = append(submoduleArgs, addtlGitArgs...)
 	submoduleArgs = append(submoduleArgs, "submodule", "update", "--init", "--recursive")
@@ -456,6 +690,19 @@ func clone(ctx context.Context) error {
 		return err
 	}
 
+	if !flagValues.skipLFS && flagValues.lfsSkipSmudge {
+		if err := os.Unsetenv("GIT_LFS_SKIP_SMUDGE"); err != nil {
+			return err
+		}
+
+		lfsPullArgs := []string{"-C", flagValues.target}
+		lfsPullArgs = append(lfsPullArgs, addtlGitArgs...)
+		lfsPullArgs = append(lfsPullArgs, "lfs", "pull")
+		if _, err := git(ctx, lfsPullArgs...); err != nil {
+			return err
+		}
+	}
+
 	revision := flagValues.revision
 	if revision == "" {
 		// user requested to clone the default branch, determine the branch name
@@ -467,6 +714,10 @@ func clone(ctx context.Context) error {
 		revision = strings.TrimRight(refParse, "\n")
 	}
 
+	if flagValues.pullRequestRef != "" {
+		revision = fmt.Sprintf("%s, %s", flagValues.pullRequestRef, revision)
+	}
+
 	log.Printf("Successfully loaded %s (%s) into %s\n",
 		displayURL,
 		revision,
@@ -476,6 +727,370 @@ func clone(ctx context.Context) error {
 	return nil
 }
 
+// systemCAFiles are the locations of the certificate authorities of the system on the common Linux distributions
+var systemCAFiles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// trustedCAInfoFile writes a temporary file with the certificate authorities of the system and
+// the ones of the CA bundle, since the GIT_SSL_CAINFO setting of Git replaces the system ones
+func trustedCAInfoFile() (string, error) {
+	caBundle, err := readCABundle()
+	if err != nil {
+		return "", err
+	}
+
+	var data []byte
+	for _, systemCAFile := range systemCAFiles {
+		// #nosec G304 the locations are well-defined by the code
+		if systemCAs, err := os.ReadFile(systemCAFile); err == nil {
+			data = append(systemCAs, '\n')
+			break
+		}
+	}
+	data = append(data, caBundle...)
+
+	caInfoFile, err := os.CreateTemp(os.TempDir(), "ca-info")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := caInfoFile.Write(data); err != nil {
+		_ = caInfoFile.Close()
+		return "", err
+	}
+
+	return caInfoFile.Name(), caInfoFile.Close()
+}
+
+// trustedCertPool returns the certificate authorities of the system and the ones of the CA bundle
+// for the HTTP requests of the Git step itself
+func trustedCertPool() (*x509.CertPool, error) {
+	caBundle, err := readCABundle()
+	if err != nil {
+		return nil, err
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		certPool = x509.NewCertPool()
+	}
+	certPool.AppendCertsFromPEM(caBundle)
+
+	return certPool, nil
+}
+
+// readCABundle reads the PEM encoded certificate authorities of the CA bundle
+func readCABundle() ([]byte, error) {
+	caBundle, err := os.ReadFile(flagValues.caBundle)
+	if err != nil {
+		return nil, &ExitError{Code: 120, Message: fmt.Sprintf("failed to read the CA bundle: %s", err.Error()), Cause: err}
+	}
+
+	return caBundle, nil
+}
+
+// lfsConfigArgs returns the Git configuration for the patterns of the Git LFS files to download
+func lfsConfigArgs() []string {
+	var args []string
+	if len(flagValues.lfsInclude) > 0 {
+		args = append(args, "-c", fmt.Sprintf("lfs.fetchinclude=%s", strings.Join(flagValues.lfsInclude, ",")))
+	}
+
+	if len(flagValues.lfsExclude) > 0 {
+		args = append(args, "-c", fmt.Sprintf("lfs.fetchexclude=%s", strings.Join(flagValues.lfsExclude, ",")))
+	}
+
+	return args
+}
+
+// lfsObjects returns the number and the total size in bytes of the Git LFS objects that were downloaded
+func lfsObjects() (int64, int64, error) {
+	var objects, size int64
+	err := filepath.WalkDir(filepath.Join(flagValues.target, ".git", "lfs", "objects"), func(_ string, d fs.DirEntry, err error) error {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil
+
+		case err != nil:
+			return err
+
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			objects++
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return objects, size, err
+}
+
+// fetchTags fetches the tags of the remote repository that point to a commit in the history
+// of the checked out commit, so that the tags of the commit and its description can be
+// determined without downloading the commits of all other tags
+func fetchTags(ctx context.Context, addtlGitArgs []string) error {
+	lsRemoteArgs := []string{"-C", flagValues.target}
+	lsRemoteArgs = append(lsRemoteArgs, addtlGitArgs...)
+	lsRemoteArgs = append(lsRemoteArgs, "ls-remote", "--tags", "origin")
+	output, err := git(ctx, lsRemoteArgs...)
+	if err != nil {
+		return err
+	}
+
+	// the commit of every tag, for annotated tags the one of the peeled ref
+	tagCommits := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		sha, ref, found := strings.Cut(strings.TrimSpace(line), "\t")
+		if !found || !strings.HasPrefix(ref, "refs/tags/") {
+			continue
+		}
+
+		tagCommits[strings.TrimSuffix(ref, "^{}")] = sha
+	}
+
+	if len(tagCommits) == 0 {
+		return nil
+	}
+
+	output, err = git(ctx, "-C", flagValues.target, "rev-list", "HEAD")
+	if err != nil {
+		return err
+	}
+
+	commits := map[string]struct{}{}
+	for _, commit := range strings.Split(output, "\n") {
+		commits[commit] = struct{}{}
+	}
+
+	var refspecs []string
+	for ref, commit := range tagCommits {
+		if _, found := commits[commit]; found {
+			refspecs = append(refspecs, fmt.Sprintf("+%s:%s", ref, ref))
+		}
+	}
+
+	if len(refspecs) == 0 {
+		return nil
+	}
+
+	sort.Strings(refspecs)
+
+	fetchArgs := []string{"-C", flagValues.target}
+	fetchArgs = append(fetchArgs, addtlGitArgs...)
+	fetchArgs = append(fetchArgs, "fetch", "--quiet", "--no-tags", "origin")
+	fetchArgs = append(fetchArgs, refspecs...)
+	_, err = git(ctx, fetchArgs...)
+	return err
+}
+
+// checkoutPullRequest fetches the head of the pull request, and either checks it out, or merges
+// it into the checked out base branch
+func checkoutPullRequest(ctx context.Context, addtlGitArgs []string, depth uint) error {
+	baseSha, err := git(ctx, "-C", flagValues.target, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return err
+	}
+
+	fetchArgs := []string{"-C", flagValues.target}
+	fetchArgs = append(fetchArgs, addtlGitArgs...)
+	fetchArgs = append(fetchArgs, "fetch", "--quiet", "--no-tags")
+	if depth > 0 {
+		fetchArgs = append(fetchArgs, "--depth", fmt.Sprintf("%d", depth))
+	}
+	fetchArgs = append(fetchArgs, "--", "origin", flagValues.pullRequestRef)
+	if _, err := git(ctx, fetchArgs...); err != nil {
+		return err
+	}
+
+	headSha, err := git(ctx, "-C", flagValues.target, "rev-parse", "--verify", "FETCH_HEAD")
+	if err != nil {
+		return err
+	}
+
+	if !flagValues.pullRequestMerge {
+		checkoutArgs := []string{"-C", flagValues.target}
+		checkoutArgs = append(checkoutArgs, addtlGitArgs...)
+		checkoutArgs = append(checkoutArgs, "checkout", "--quiet", "--detach", headSha)
+		if _, err := git(ctx, checkoutArgs...); err != nil {
+			return err
+		}
+	} else {
+		mergeSha, err := merge(ctx, addtlGitArgs, baseSha, headSha)
+		if err != nil {
+			return err
+		}
+
+		if flagValues.resultFilePRMergeSha != "" {
+			// #nosec G306 the file must be readable by build steps that potentially run as a different user
+			if err := os.WriteFile(flagValues.resultFilePRMergeSha, []byte(mergeSha), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	if flagValues.resultFilePRHeadSha != "" {
+		// #nosec G306 the file must be readable by build steps that potentially run as a different user
+		if err := os.WriteFile(flagValues.resultFilePRHeadSha, []byte(headSha), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFilePRBaseSha != "" {
+		// #nosec G306 the file must be readable by build steps that potentially run as a different user
+		if err := os.WriteFile(flagValues.resultFilePRBaseSha, []byte(baseSha), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// merge merges the head of the pull request into the checked out base branch and returns the
+// commit sha of the merge. The merge commit uses the most recent committer date of both commits,
+// so that merging the same commits always results in the same commit sha.
+func merge(ctx context.Context, addtlGitArgs []string, baseSha string, headSha string) (string, error) {
+	var mergeDate int64
+	for _, sha := range []string{baseSha, headSha} {
+		output, err := git(ctx, "-C", flagValues.target, "show", "--no-patch", "--format=%ct", sha)
+		if err != nil {
+			return "", err
+		}
+
+		commitDate, err := strconv.ParseInt(output, 10, 64)
+		if err != nil {
+			return "", err
+		}
+
+		mergeDate = max(mergeDate, commitDate)
+	}
+
+	for _, envVar := range []string{"GIT_AUTHOR_DATE", "GIT_COMMITTER_DATE"} {
+		if err := os.Setenv(envVar, fmt.Sprintf("%d +0000", mergeDate)); err != nil {
+			return "", err
+		}
+		defer os.Unsetenv(envVar)
+	}
+
+	mergeArgs := []string{"-C", flagValues.target}
+	mergeArgs = append(mergeArgs, addtlGitArgs...)
+	mergeArgs = append(mergeArgs,
+		"-c", "user.name=Shipwright",
+		"-c", "user.email=shipwright@localhost",
+		"merge", "--quiet", "--no-ff", "--no-edit",
+		"-m", fmt.Sprintf("Merge %s", flagValues.pullRequestRef),
+		headSha,
+	)
+	if _, mergeErr := git(ctx, mergeArgs...); mergeErr != nil {
+		conflicts, err := git(ctx, "-C", flagValues.target, "diff", "--name-only", "--diff-filter=U")
+		if err != nil || conflicts == "" {
+			return "", mergeErr
+		}
+
+		// leave a clean working tree behind, the error result contains the conflicting files
+		_, _ = git(ctx, "-C", flagValues.target, "merge", "--abort")
+
+		return "", &ExitError{
+			Code:    140,
+			Message: fmt.Sprintf("%s Conflicting files: %s", shpgit.MergeConflict.ToMessage(), strings.Join(strings.Fields(conflicts), ", ")),
+			Cause:   mergeErr,
+			Reason:  shpgit.MergeConflict,
+		}
+	}
+
+	return git(ctx, "-C", flagValues.target, "rev-parse", "--verify", "HEAD")
+}
+
+// verifySignature verifies the signature of the checked out tag, in case the revision is an
+// annotated tag, or of the head of a merged pull request, or otherwise of the checked out
+// commit, with the trusted keys, and returns the identity of the signer
+func verifySignature(ctx context.Context) (string, error) {
+	var configArgs []string
+
+	allowedSignersFile := filepath.Join(flagValues.signatureVerificationPath, "allowed_signers")
+	if hasFile(allowedSignersFile) {
+		configArgs = append(configArgs, "-c", fmt.Sprintf("gpg.ssh.allowedSignersFile=%s", allowedSignersFile))
+	}
+
+	// always use an empty GPG home directory, so that only the trusted keys are known
+	gnupgHome, err := os.MkdirTemp(os.TempDir(), "gnupg")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(gnupgHome)
+
+	if err := os.Setenv("GNUPGHOME", gnupgHome); err != nil {
+		return "", err
+	}
+	defer os.Unsetenv("GNUPGHOME")
+
+	gpgPublicKeysFile := filepath.Join(flagValues.signatureVerificationPath, "gpg-public-keys")
+	if hasFile(gpgPublicKeysFile) {
+		// #nosec G204 arguments are well-defined by the code
+		if out, err := exec.CommandContext(ctx, "gpg", "--batch", "--quiet", "--import", gpgPublicKeysFile).CombinedOutput(); err != nil {
+			return "", &ExitError{Code: 130, Message: fmt.Sprintf("failed to import the GPG public keys: %s", strings.TrimSpace(string(out))), Cause: err}
+		}
+	} else if len(configArgs) == 0 {
+		return "", &ExitError{Code: 130, Message: "the signature verification secret must contain GPG public keys in gpg-public-keys, or a SSH allowed signers file in allowed_signers"}
+	}
+
+	verifyArgs := []string{"-C", flagValues.target}
+	verifyArgs = append(verifyArgs, configArgs...)
+	switch objectType, err := git(ctx, "-C", flagValues.target, "cat-file", "-t", fmt.Sprintf("refs/tags/%s", flagValues.revision)); {
+	case flagValues.pullRequestRef != "" && flagValues.pullRequestMerge:
+		// the merge commit is created by the Git step, verify the head of the pull request instead
+		verifyArgs = append(verifyArgs, "verify-commit", "--raw", "HEAD^2")
+	case flagValues.pullRequestRef == "" && flagValues.revision != "" && err == nil && objectType == "tag":
+		verifyArgs = append(verifyArgs, "verify-tag", "--raw", fmt.Sprintf("refs/tags/%s", flagValues.revision))
+	default:
+		verifyArgs = append(verifyArgs, "verify-commit", "--raw", "HEAD")
+	}
+
+	output, err := git(ctx, verifyArgs...)
+	if err != nil {
+		if output != "" {
+			log.Print(output)
+		}
+		return "", &ExitError{
+			Code:    131,
+			Message: shpgit.SignatureUntrusted.ToMessage(),
+			Cause:   err,
+			Reason:  shpgit.SignatureUntrusted,
+		}
+	}
+
+	signer := parseSigner(output)
+	log.Printf("Successfully verified the signature of %s\n", signer)
+
+	return signer, nil
+}
+
+var (
+	gpgGoodSignatureRegEx = regexp.MustCompile(`(?m)^\[GNUPG:\] GOODSIG \S+ (.+)$`)
+	sshGoodSignatureRegEx = regexp.MustCompile(`(?m)^Good "git" signature for (.+) with \S+ key`)
+)
+
+// parseSigner returns the identity of the signer from the raw output of the signature verification,
+// which is the user ID of a GPG key, or the principal of a SSH key
+func parseSigner(output string) string {
+	for _, regEx := range []*regexp.Regexp{gpgGoodSignatureRegEx, sshGoodSignatureRegEx} {
+		if match := regEx.FindStringSubmatch(output); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	}
+
+	return ""
+}
+
 func git(ctx context.Context, args ...string) (string, error) {
 	fullArgs := []string{
 		"-c",
@@ -554,6 +1169,33 @@ func checkCredentials() (credentialType, error) {
 		}
 	}
 
+	// Checking whether mounted secret contains the credentials of a GitHub App
+	// in which case there need to be the files of the app ID, installation ID and private key
+	hasAppID := hasFile(flagValues.secretPath, shpgit.GitHubAppIDKey)
+	hasInstallationID := hasFile(flagValues.secretPath, shpgit.GitHubAppInstallationIDKey)
+	hasAppPrivateKey := hasFile(flagValues.secretPath, shpgit.GitHubAppPrivateKeyKey)
+	switch {
+	case !hasAppID && !hasInstallationID && !hasAppPrivateKey:
+		// not a GitHub App secret
+
+	case !hasAppID || !hasInstallationID || !hasAppPrivateKey:
+		return typeUndef, &ExitError{
+			Code:    110,
+			Message: shpgit.AuthGitHubAppIncomplete.ToMessage(),
+			Reason:  shpgit.AuthGitHubAppIncomplete,
+		}
+
+	case strings.HasPrefix(flagValues.url, "https://"):
+		return typeGitHubApp, nil
+
+	default:
+		return typeUndef, &ExitError{
+			Code:    110,
+			Message: shpgit.AuthUnexpectedHTTP.ToMessage(),
+			Reason:  shpgit.AuthUnexpectedHTTP,
+		}
+	}
+
 	// Checking whether mounted secret is of type `kubernetes.io/basic-auth`
 	// in which case there need to be the files username and password
 	hasUsername := hasFile(flagValues.secretPath, "username")
@@ -579,11 +1221,52 @@ func checkCredentials() (credentialType, error) {
 
 	return typeUndef, &ExitError{
 		Code:    110,
-		Message: "Unsupported type of credentials provided, either SSH private key or username/password is supported",
+		Message: "Unsupported type of credentials provided, either SSH private key, username/password, or GitHub App is supported",
 		Reason:  shpgit.Unknown,
 	}
 }
 
+// gitHubAppInstallationToken creates an installation token of the GitHub App of the secret, the
+// token endpoint is the one of the GitHub API URL in the secret, or the one of github.com
+func gitHubAppInstallationToken(ctx context.Context) (string, error) {
+	data := map[string][]byte{}
+	for _, key := range []string{shpgit.GitHubAppIDKey, shpgit.GitHubAppInstallationIDKey, shpgit.GitHubAppPrivateKeyKey, shpgit.GitHubAppAPIURLKey} {
+		value, err := os.ReadFile(filepath.Join(flagValues.secretPath, key))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		data[key] = value
+	}
+
+	apiURL := strings.TrimSpace(string(data[shpgit.GitHubAppAPIURLKey]))
+	if apiURL == "" {
+		apiURL = shpgit.DefaultGitHubAPIURL
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if flagValues.caBundle != "" {
+		rootCAs, err := trustedCertPool()
+		if err != nil {
+			return "", err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	token, err := shpgit.GitHubAppInstallationToken(ctx, &http.Client{Transport: transport, Timeout: time.Minute}, apiURL,
+		string(data[shpgit.GitHubAppIDKey]), string(data[shpgit.GitHubAppInstallationIDKey]), data[shpgit.GitHubAppPrivateKeyKey])
+	if err != nil {
+		log.Printf("Unable to create the installation token of the GitHub App using %s: %v\n", apiURL, err)
+		return "", &ExitError{
+			Code:    110,
+			Message: shpgit.AuthGitHubAppTokenFailed.ToMessage(),
+			Cause:   err,
+			Reason:  shpgit.AuthGitHubAppTokenFailed,
+		}
+	}
+
+	return token, nil
+}
+
 func writeErrorResults(failure *shpgit.ErrorResult) (err error) {
 	if flagValues.resultFileErrorReason == "" || flagValues.resultFileErrorMessage == "" {
 		return nil
@@ -623,3 +1306,29 @@ func cleanURL() string {
 	// in any case, as a fallback, return it as-is
 	return flagValues.url
 }
+
+// truncate returns the value with at most limit bytes, cut at the boundary of a UTF-8 character
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+
+	for limit > 0 && !utf8.RuneStart(value[limit]) {
+		limit--
+	}
+
+	return value[:limit]
+}
+
+// truncateLines returns the lines of the value that fit into limit bytes
+func truncateLines(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+
+	if index := strings.LastIndex(value[:limit+1], "\n"); index >= 0 {
+		return value[:index]
+	}
+
+	return ""
+}
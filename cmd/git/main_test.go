@@ -7,13 +7,21 @@ package main_test
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -206,6 +214,40 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("cloning repositories with a sparse checkout", func() {
+		const exampleRepo = "https://github.com/shipwright-io/sample-go"
+
+		It("should only check out the requested directories and the top-level files", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", exampleRepo,
+					"--target", target,
+					"--sparse-checkout", "docker-build",
+				))).ToNot(HaveOccurred())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "docker-build", "Dockerfile")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "source-build")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should store the commit-sha of a sparse checkout of a specified commit-sha (short)", func() {
+			withTempFile("commit-sha", func(filename string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", exampleRepo,
+						"--target", target,
+						"--revision", "0e05834",
+						"--sparse-checkout", "docker-build",
+						"--result-file-commit-sha", filename,
+					))).ToNot(HaveOccurred())
+
+					Expect(filecontent(filename)).To(Equal("0e0583421a5e4bf562ffe33f3651e16ba0c78591"))
+				})
+			})
+		})
+	})
+
 	Context("cloning private repositories using SSH keys", func() {
 		const exampleRepo = "git@github.com:shipwright-io/sample-nodejs-private.git"
 
@@ -516,6 +558,67 @@ var _ = Describe("Git Resource", func() {
 						Expect(http.DetectContentType(data)).To(Equal("image/png"))
 					})
 				})
+
+				It("should store the number and size of the downloaded LFS objects", func() {
+					withTempFile("lfs-objects", func(objectsFile string) {
+						withTempFile("lfs-size", func(sizeFile string) {
+							withTempDir(func(target string) {
+								Expect(run(withArgs(
+									"--url", exampleRepo,
+									"--target", target,
+									"--result-file-lfs-objects", objectsFile,
+									"--result-file-lfs-size", sizeFile,
+								))).To(Succeed())
+
+								Expect(strconv.Atoi(filecontent(objectsFile))).To(BeNumerically(">", 0))
+								Expect(strconv.Atoi(filecontent(sizeFile))).To(BeNumerically(">", 0))
+							})
+						})
+					})
+				})
+
+				It("should check out the LFS pointer files when LFS is skipped", func() {
+					withTempFile("lfs-objects", func(objectsFile string) {
+						withTempDir(func(target string) {
+							Expect(run(withArgs(
+								"--url", exampleRepo,
+								"--target", target,
+								"--skip-lfs",
+								"--result-file-lfs-objects", objectsFile,
+							))).To(Succeed())
+
+							Expect(filecontent(filepath.Join(target, "assets", "shipwright-logo-lightbg-512.png"))).To(HavePrefix("version https://git-lfs.github.com/spec/v1"))
+							Expect(filecontent(objectsFile)).To(Equal("0"))
+						})
+					})
+				})
+
+				It("should not download excluded LFS files", func() {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", exampleRepo,
+							"--target", target,
+							"--lfs-exclude", "assets/*",
+						))).To(Succeed())
+
+						Expect(filecontent(filepath.Join(target, "assets", "shipwright-logo-lightbg-512.png"))).To(HavePrefix("version https://git-lfs.github.com/spec/v1"))
+					})
+				})
+
+				It("should download the LFS files after the checkout when smudging is skipped", func() {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", exampleRepo,
+							"--target", target,
+							"--lfs-skip-smudge",
+						))).To(Succeed())
+
+						// #nosec: G304 fine in tests
+						data, err := os.ReadFile(filepath.Join(target, "assets", "shipwright-logo-lightbg-512.png"))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(http.DetectContentType(data)).To(Equal("image/png"))
+					})
+				})
 			})
 		})
 
@@ -671,6 +774,638 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("verifying signatures", func() {
+		var repo, keys string
+		var unsignedCommit string
+
+		// git runs a Git command in the local repository, signing is done with the SSH key
+		gitCmd := func(args ...string) string {
+			fullArgs := []string{
+				"-C", repo,
+				"-c", "user.name=Dev",
+				"-c", "user.email=dev@example.com",
+				"-c", "gpg.format=ssh",
+				"-c", "user.signingkey=" + filepath.Join(keys, "key"),
+			}
+			// #nosec G204 fine in tests
+			out, err := exec.Command("git", append(fullArgs, args...)...).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		sshKeygen := func(name string, comment string) string {
+			keyFile := filepath.Join(keys, name)
+			// #nosec G204 fine in tests
+			out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", comment, "-f", keyFile).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return filecontent(keyFile + ".pub")
+		}
+
+		BeforeEach(func() {
+			if _, err := exec.LookPath("ssh-keygen"); err != nil {
+				Skip("ssh-keygen is not available")
+			}
+
+			repo = GinkgoT().TempDir()
+			keys = GinkgoT().TempDir()
+
+			// the trusted key, and a second key that is not trusted
+			file(filepath.Join(keys, "allowed_signers"), 0644, []byte("dev@example.com "+sshKeygen("key", "dev@example.com")))
+			file(filepath.Join(keys, "untrusted_signers"), 0644, []byte("dev@example.com "+sshKeygen("other-key", "other@example.com")))
+
+			// an unsigned commit with a signed tag, followed by a signed commit
+			gitCmd("init", "--quiet", "--initial-branch", "main")
+			file(filepath.Join(repo, "README.md"), 0644, []byte("# sample"))
+			gitCmd("add", "README.md")
+			gitCmd("commit", "--quiet", "--message", "unsigned")
+			unsignedCommit = gitCmd("rev-parse", "HEAD")
+			gitCmd("tag", "--sign", "--message", "v1.0.0", "v1.0.0")
+			file(filepath.Join(repo, "README.md"), 0644, []byte("# signed sample"))
+			gitCmd("commit", "--quiet", "--all", "--gpg-sign", "--message", "signed")
+		})
+
+		It("should verify a signed commit and store the signer", func() {
+			withTempFile("signer", func(filename string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", "file://"+repo,
+						"--target", target,
+						"--signature-verification-path", keys,
+						"--result-file-signer", filename,
+					))).To(Succeed())
+
+					Expect(filecontent(filename)).To(Equal("dev@example.com"))
+				})
+			})
+		})
+
+		It("should verify a signed tag", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--revision", "v1.0.0",
+					"--signature-verification-path", keys,
+				))).To(Succeed())
+			})
+		})
+
+		It("should fail for an unsigned commit", func() {
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--revision", unsignedCommit,
+					"--signature-verification-path", keys,
+				))
+
+				var exitError *ExitError
+				Expect(errors.As(err, &exitError)).To(BeTrue())
+				Expect(exitError.Reason).To(Equal(shpgit.SignatureUntrusted))
+			})
+		})
+
+		It("should fail for a commit that is signed with an untrusted key", func() {
+			Expect(os.Rename(filepath.Join(keys, "untrusted_signers"), filepath.Join(keys, "allowed_signers"))).To(Succeed())
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--signature-verification-path", keys,
+				))
+
+				var exitError *ExitError
+				Expect(errors.As(err, &exitError)).To(BeTrue())
+				Expect(exitError.Reason).To(Equal(shpgit.SignatureUntrusted))
+				Expect(exitError.Message).To(Equal(shpgit.SignatureUntrusted.ToMessage()))
+			})
+		})
+
+		It("should fail if there are no trusted keys", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--signature-verification-path", GinkgoT().TempDir(),
+				))).To(MatchError(ContainSubstring("must contain GPG public keys")))
+			})
+		})
+	})
+
+	Context("cloning private repositories using a GitHub App", func() {
+		const installationToken = "ghs_installation-token"
+
+		var server *httptest.Server
+		var secret, caBundle string
+
+		BeforeEach(func() {
+			// a bare repository with one commit that is served by git http-backend
+			root := GinkgoT().TempDir()
+			work := GinkgoT().TempDir()
+			for _, args := range [][]string{
+				{"-C", work, "init", "--quiet", "--initial-branch", "main"},
+				{"-C", work, "-c", "user.name=Dev", "-c", "user.email=dev@example.com", "commit", "--quiet", "--allow-empty", "--message", "initial"},
+				{"clone", "--quiet", "--bare", work, filepath.Join(root, "app.git")},
+			} {
+				// #nosec G204 fine in tests
+				out, err := exec.Command("git", args...).CombinedOutput()
+				Expect(err).ToNot(HaveOccurred(), string(out))
+			}
+
+			execPath, err := exec.Command("git", "--exec-path").Output()
+			Expect(err).ToNot(HaveOccurred())
+
+			gitHTTPBackend := &cgi.Handler{
+				Path: filepath.Join(strings.TrimSpace(string(execPath)), "git-http-backend"),
+				Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v3/app/installations/67890/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = fmt.Fprintf(w, `{"token":%q}`, installationToken)
+			})
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				if username, password, ok := r.BasicAuth(); !ok || username != "x-access-token" || password != installationToken {
+					w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				gitHTTPBackend.ServeHTTP(w, r)
+			})
+
+			server = httptest.NewTLSServer(mux)
+			DeferCleanup(server.Close)
+
+			caBundle = filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt")
+			file(caBundle, 0644, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+			privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+
+			// Mock the filesystem state of a secret volume mount with the credentials of a GitHub App
+			secret = GinkgoT().TempDir()
+			file(filepath.Join(secret, "github-app-id"), 0400, []byte("12345"))
+			file(filepath.Join(secret, "github-app-private-key"), 0400, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}))
+			file(filepath.Join(secret, "github-app-api-url"), 0400, []byte(server.URL+"/api/v3"))
+		})
+
+		It("should Git clone a private repository using an installation token of the GitHub App", func() {
+			file(filepath.Join(secret, "github-app-installation-id"), 0400, []byte("67890"))
+
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", server.URL+"/app.git",
+					"--secret-path", secret,
+					"--ca-bundle", caBundle,
+					"--target", target,
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, ".git")).To(BeADirectory())
+			})
+		})
+
+		It("should fail in case the installation token cannot be created", func() {
+			file(filepath.Join(secret, "github-app-installation-id"), 0400, []byte("11111"))
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", server.URL+"/app.git",
+					"--secret-path", secret,
+					"--ca-bundle", caBundle,
+					"--target", target,
+				))
+
+				var exitError *ExitError
+				Expect(errors.As(err, &exitError)).To(BeTrue())
+				Expect(exitError.Code).To(Equal(110))
+				Expect(exitError.Reason).To(Equal(shpgit.AuthGitHubAppTokenFailed))
+				Expect(exitError.Cause).To(MatchError(ContainSubstring("401 Unauthorized")))
+			})
+		})
+
+		It("should fail in case the installation ID is missing", func() {
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", server.URL+"/app.git",
+					"--secret-path", secret,
+					"--target", target,
+				))
+
+				var exitError *ExitError
+				Expect(errors.As(err, &exitError)).To(BeTrue())
+				Expect(exitError.Code).To(Equal(110))
+				Expect(exitError.Reason).To(Equal(shpgit.AuthGitHubAppIncomplete))
+			})
+		})
+	})
+
+	Context("using a CA bundle", func() {
+		var repo string
+
+		BeforeEach(func() {
+			repo = GinkgoT().TempDir()
+			for _, args := range [][]string{
+				{"init", "--quiet", "--initial-branch", "main"},
+				{"-c", "user.name=Dev", "-c", "user.email=dev@example.com", "commit", "--quiet", "--allow-empty", "--message", "initial"},
+			} {
+				// #nosec G204 fine in tests
+				out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+				Expect(err).ToNot(HaveOccurred(), string(out))
+			}
+		})
+
+		It("should clone with the CA bundle", func() {
+			caBundle := filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt")
+			file(caBundle, 0644, []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"))
+
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--ca-bundle", caBundle,
+				))).To(Succeed())
+			})
+		})
+
+		It("should clone over HTTPS from a server with a certificate of the CA bundle", func() {
+			root := GinkgoT().TempDir()
+			// #nosec G204 fine in tests
+			out, err := exec.Command("git", "clone", "--quiet", "--bare", repo, filepath.Join(root, "repo.git")).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+
+			execPath, err := exec.Command("git", "--exec-path").Output()
+			Expect(err).ToNot(HaveOccurred())
+
+			server := httptest.NewTLSServer(&cgi.Handler{
+				Path: filepath.Join(strings.TrimSpace(string(execPath)), "git-http-backend"),
+				Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+			})
+			defer server.Close()
+
+			caBundle := filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt")
+			file(caBundle, 0644, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", server.URL+"/repo.git",
+					"--target", target,
+					"--ca-bundle", caBundle,
+				))).To(Succeed())
+			})
+		})
+
+		It("should fail if the CA bundle does not exist", func() {
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--ca-bundle", filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt"),
+				))
+
+				var exitError *ExitError
+				Expect(errors.As(err, &exitError)).To(BeTrue())
+				Expect(exitError.Code).To(Equal(120))
+				Expect(exitError.Message).To(HavePrefix("failed to read the CA bundle"))
+			})
+		})
+	})
+
+	Context("classifying errors", func() {
+		var root string
+
+		BeforeEach(func() {
+			root = GinkgoT().TempDir()
+			work := GinkgoT().TempDir()
+			for _, args := range [][]string{
+				{"-C", work, "init", "--quiet", "--initial-branch", "main"},
+				{"-C", work, "-c", "user.name=Dev", "-c", "user.email=dev@example.com", "commit", "--quiet", "--allow-empty", "--message", "initial"},
+				{"clone", "--quiet", "--bare", work, filepath.Join(root, "repo.git")},
+				{"-C", filepath.Join(root, "repo.git"), "update-server-info"},
+			} {
+				// #nosec G204 fine in tests
+				out, err := exec.Command("git", args...).CombinedOutput()
+				Expect(err).ToNot(HaveOccurred(), string(out))
+			}
+		})
+
+		It("should classify an untrusted certificate of the server", func() {
+			server := httptest.NewTLSServer(http.FileServer(http.Dir(root)))
+			defer server.Close()
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", server.URL+"/repo.git",
+					"--target", target,
+				))
+				Expect(err).To(HaveOccurred())
+				Expect(shpgit.NewErrorResultFromMessage(err.Error()).Reason).To(Equal(shpgit.TLSVerificationFailed))
+			})
+		})
+
+		It("should classify a shallow clone from a server that does not support it", func() {
+			// a file server only provides the dumb HTTP protocol
+			server := httptest.NewServer(http.FileServer(http.Dir(root)))
+			defer server.Close()
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", server.URL+"/repo.git",
+					"--target", target,
+					"--depth", "1",
+				))
+				Expect(err).To(HaveOccurred())
+				Expect(shpgit.NewErrorResultFromMessage(err.Error()).Reason).To(Equal(shpgit.ShallowCloneNotSupported))
+			})
+		})
+
+		It("should classify an unavailable server", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", server.URL+"/repo.git",
+					"--target", target,
+				))
+				Expect(err).To(HaveOccurred())
+				Expect(shpgit.NewErrorResultFromMessage(err.Error()).Reason).To(Equal(shpgit.RemoteUnavailable))
+			})
+		})
+	})
+
+	Context("building pull requests", func() {
+		var repo string
+		var baseCommit, headCommit string
+
+		// git runs a Git command in the local repository
+		gitCmd := func(args ...string) string {
+			fullArgs := []string{
+				"-C", repo,
+				"-c", "user.name=Dev",
+				"-c", "user.email=dev@example.com",
+			}
+			// #nosec G204 fine in tests
+			out, err := exec.Command("git", append(fullArgs, args...)...).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		BeforeEach(func() {
+			repo = GinkgoT().TempDir()
+
+			gitCmd("init", "--quiet", "--initial-branch", "main")
+			file(filepath.Join(repo, "README.md"), 0644, []byte("# sample"))
+			gitCmd("add", "README.md")
+			gitCmd("commit", "--quiet", "--message", "initial")
+
+			// pull request 1 adds a file, pull request 2 conflicts with the main branch
+			gitCmd("checkout", "--quiet", "-b", "feature")
+			file(filepath.Join(repo, "feature.txt"), 0644, []byte("feature"))
+			gitCmd("add", "feature.txt")
+			gitCmd("commit", "--quiet", "--message", "add feature")
+			headCommit = gitCmd("rev-parse", "HEAD")
+			gitCmd("update-ref", "refs/pull/1/head", headCommit)
+
+			gitCmd("checkout", "--quiet", "-b", "conflict", "main")
+			file(filepath.Join(repo, "README.md"), 0644, []byte("# conflicting sample"))
+			gitCmd("commit", "--quiet", "--all", "--message", "change readme")
+			gitCmd("update-ref", "refs/pull/2/head", "HEAD")
+
+			gitCmd("checkout", "--quiet", "main")
+			file(filepath.Join(repo, "README.md"), 0644, []byte("# updated sample"))
+			gitCmd("commit", "--quiet", "--all", "--message", "update readme")
+			baseCommit = gitCmd("rev-parse", "HEAD")
+		})
+
+		It("should check out the head of the pull request", func() {
+			withTempFile("head-sha", func(headShaFile string) {
+				withTempFile("base-sha", func(baseShaFile string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", "file://"+repo,
+							"--target", target,
+							"--pull-request-ref", "refs/pull/1/head",
+							"--result-file-pull-request-head-sha", headShaFile,
+							"--result-file-pull-request-base-sha", baseShaFile,
+						))).To(Succeed())
+
+						Expect(filecontent(headShaFile)).To(Equal(headCommit))
+						Expect(filecontent(baseShaFile)).To(Equal(baseCommit))
+						Expect(filecontent(filepath.Join(target, "feature.txt"))).To(Equal("feature"))
+						Expect(filecontent(filepath.Join(target, "README.md"))).To(Equal("# sample"))
+					})
+				})
+			})
+		})
+
+		It("should merge the pull request into the base branch", func() {
+			withTempFile("merge-sha", func(mergeShaFile string) {
+				withTempFile("commit-sha", func(commitShaFile string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", "file://"+repo,
+							"--target", target,
+							"--revision", "main",
+							"--pull-request-ref", "refs/pull/1/head",
+							"--pull-request-merge",
+							"--result-file-pull-request-merge-sha", mergeShaFile,
+							"--result-file-commit-sha", commitShaFile,
+						))).To(Succeed())
+
+						Expect(filecontent(mergeShaFile)).To(Equal(filecontent(commitShaFile)))
+						Expect(filecontent(filepath.Join(target, "feature.txt"))).To(Equal("feature"))
+						Expect(filecontent(filepath.Join(target, "README.md"))).To(Equal("# updated sample"))
+					})
+				})
+			})
+		})
+
+		It("should create the same merge commit for the same pull request", func() {
+			withTempFile("merge-sha", func(mergeShaFile string) {
+				var mergeShas []string
+				for range 2 {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", "file://"+repo,
+							"--target", target,
+							"--pull-request-ref", "refs/pull/1/head",
+							"--pull-request-merge",
+							"--result-file-pull-request-merge-sha", mergeShaFile,
+						))).To(Succeed())
+
+						mergeShas = append(mergeShas, filecontent(mergeShaFile))
+					})
+				}
+
+				Expect(mergeShas[0]).To(Equal(mergeShas[1]))
+			})
+		})
+
+		It("should fail for a pull request with merge conflicts", func() {
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--pull-request-ref", "refs/pull/2/head",
+					"--pull-request-merge",
+				))
+
+				var exitError *ExitError
+				Expect(errors.As(err, &exitError)).To(BeTrue())
+				Expect(exitError.Reason).To(Equal(shpgit.MergeConflict))
+				Expect(exitError.Message).To(HaveSuffix("Conflicting files: README.md"))
+			})
+		})
+
+		It("should fail for a pull request that does not exist", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--pull-request-ref", "refs/pull/3/head",
+				))).To(MatchError(ContainSubstring("refs/pull/3/head")))
+			})
+		})
+	})
+
+	Context("reporting the commit message, timestamp and tags", func() {
+		var repo string
+
+		// git runs a Git command in the local repository
+		gitCmd := func(args ...string) string {
+			fullArgs := []string{
+				"-C", repo,
+				"-c", "user.name=Dev",
+				"-c", "user.email=dev@example.com",
+			}
+			// #nosec G204 fine in tests
+			out, err := exec.Command("git", append(fullArgs, args...)...).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		BeforeEach(func() {
+			repo = GinkgoT().TempDir()
+
+			gitCmd("init", "--quiet", "--initial-branch", "main")
+			gitCmd("commit", "--quiet", "--allow-empty", "--message", "initial")
+			gitCmd("tag", "--annotate", "v1.0.0", "--message", "release 1.0.0")
+
+			// a tag on another branch must not be fetched
+			gitCmd("checkout", "--quiet", "-b", "feature")
+			gitCmd("commit", "--quiet", "--allow-empty", "--message", "feature")
+			gitCmd("tag", "feature-tag")
+
+			gitCmd("checkout", "--quiet", "main")
+			gitCmd("commit", "--quiet", "--allow-empty", "--message", "fix the build", "--message", "with some details")
+			gitCmd("branch", "fix")
+			gitCmd("commit", "--quiet", "--allow-empty", "--message", "prepare release 1.1.0")
+			gitCmd("tag", "v1.1.0")
+			gitCmd("tag", "latest")
+		})
+
+		It("should store the commit message, the committer timestamp and the tags of the commit", func() {
+			withTempFile("commit-message", func(commitMessageFile string) {
+				withTempFile("commit-timestamp", func(commitTimestampFile string) {
+					withTempFile("tags", func(tagsFile string) {
+						withTempFile("describe", func(describeFile string) {
+							withTempDir(func(target string) {
+								Expect(run(withArgs(
+									"--url", "file://"+repo,
+									"--target", target,
+									"--result-file-commit-message", commitMessageFile,
+									"--result-file-commit-timestamp", commitTimestampFile,
+									"--result-file-tags", tagsFile,
+									"--result-file-describe", describeFile,
+								))).To(Succeed())
+
+								Expect(filecontent(commitMessageFile)).To(Equal("prepare release 1.1.0"))
+								Expect(filecontent(commitTimestampFile)).To(Equal(gitCmd("show", "--no-patch", "--format=%ct", "main")))
+								Expect(strings.Split(filecontent(tagsFile), "\n")).To(ConsistOf("latest", "v1.1.0"))
+								Expect(filecontent(describeFile)).To(Equal("latest"))
+							})
+						})
+					})
+				})
+			})
+		})
+
+		It("should truncate the commit message and the tags of the commit", func() {
+			gitCmd("commit", "--quiet", "--allow-empty", "--message", strings.Repeat("ü", 200))
+			for i := range 100 {
+				gitCmd("tag", fmt.Sprintf("release-candidate-%03d", i))
+			}
+
+			withTempFile("commit-message", func(commitMessageFile string) {
+				withTempFile("tags", func(tagsFile string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", "file://"+repo,
+							"--target", target,
+							"--result-file-commit-message", commitMessageFile,
+							"--result-file-tags", tagsFile,
+						))).To(Succeed())
+
+						Expect(filecontent(commitMessageFile)).To(Equal(strings.Repeat("ü", 128)))
+
+						tags := strings.Split(filecontent(tagsFile), "\n")
+						Expect(len(filecontent(tagsFile))).To(BeNumerically("<=", 1024))
+						Expect(tags).To(HaveLen(46))
+						Expect(tags[45]).To(Equal("release-candidate-045"))
+					})
+				})
+			})
+		})
+
+		It("should describe the commit with the most recent tag in its history", func() {
+			withTempFile("tags", func(tagsFile string) {
+				withTempFile("describe", func(describeFile string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", "file://"+repo,
+							"--target", target,
+							"--revision", gitCmd("rev-parse", "fix"),
+							"--result-file-tags", tagsFile,
+							"--result-file-describe", describeFile,
+						))).To(Succeed())
+
+						Expect(filecontent(tagsFile)).To(BeEmpty())
+						Expect(filecontent(describeFile)).To(MatchRegexp(`^v1\.0\.0-1-g[0-9a-f]+$`))
+
+						output, err := exec.Command("git", "-C", target, "tag").CombinedOutput()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(output)).ToNot(ContainSubstring("feature-tag"))
+					})
+				})
+			})
+		})
+
+		It("should write an empty description of the commit of a shallow clone without a tag", func() {
+			withTempFile("describe", func(describeFile string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", "file://"+repo,
+						"--target", target,
+						"--revision", "fix",
+						"--result-file-describe", describeFile,
+					))).To(Succeed())
+
+					Expect(filecontent(describeFile)).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Context("Using show listing flag", func() {
 		const exampleRepo = "https://github.com/shipwright-io/sample-go"
 
@@ -0,0 +1,222 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/shipwright-io/build/pkg/archive"
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+type settings struct {
+	help                      bool
+	url                       string
+	sha256                    string
+	stripComponents           int
+	target                    string
+	secretPath                string
+	caBundle                  string
+	resultFileArchiveDigest   string
+	resultFileSourceTimestamp string
+}
+
+var flagValues settings
+
+func init() {
+	// Explicitly define the help flag so that --help can be invoked and returns status code 0
+	pflag.BoolVar(&flagValues.help, "help", false, "Print the help")
+
+	// Main flags of the HTTP archive step
+	pflag.StringVar(&flagValues.url, "url", "", "The URL of the archive (mandatory)")
+	pflag.StringVar(&flagValues.sha256, "sha256", "", "The expected hex encoded SHA-256 checksum of the archive")
+	pflag.IntVar(&flagValues.stripComponents, "strip-components", 0, "The number of leading path elements to remove from the file names in the archive")
+	pflag.StringVar(&flagValues.target, "target", "/workspace/source", "The target directory to place the code")
+	pflag.StringVar(&flagValues.resultFileArchiveDigest, "result-file-archive-digest", "", "A file to write the archive digest")
+	pflag.StringVar(&flagValues.resultFileSourceTimestamp, "result-file-source-timestamp", "", "A file to write the source timestamp")
+
+	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains a secret. Either username and password for basic authentication, or a token for bearer authentication. Optional.")
+	pflag.StringVar(&flagValues.caBundle, "ca-bundle", "", "A file with PEM encoded certificate authorities to trust in addition to the system ones (optional)")
+}
+
+func main() {
+	if err := Do(context.Background()); err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// Do is the main entry point of the HTTP archive command
+func Do(ctx context.Context) error {
+	flagValues = settings{}
+	pflag.Parse()
+
+	if flagValues.help {
+		pflag.Usage()
+		return nil
+	}
+
+	if flagValues.url == "" {
+		return fmt.Errorf("mandatory flag --url is not set")
+	}
+
+	archiveURL, err := url.Parse(flagValues.url)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "http-archive")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	log.Printf("Downloading %q", archiveURL.Redacted())
+	digest, err := download(ctx, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if flagValues.sha256 != "" && !strings.EqualFold(flagValues.sha256, digest) {
+		return fmt.Errorf("the SHA-256 checksum %s of the archive does not match the expected checksum %s", digest, flagValues.sha256)
+	}
+
+	extractDetails, err := archive.Extract(file.Name(), flagValues.target, flagValues.stripComponents)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Archive content was extracted to %s\n", flagValues.target)
+
+	if flagValues.resultFileArchiveDigest != "" {
+		// #nosec G306 the file must be readable by build steps that potentially run as a different user
+		if err = os.WriteFile(flagValues.resultFileArchiveDigest, []byte("sha256:"+digest), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileSourceTimestamp != "" {
+		if extractDetails.MostRecentFileTimestamp != nil {
+			// #nosec G306 the file must be readable by build steps that potentially run as a different user
+			if err = os.WriteFile(flagValues.resultFileSourceTimestamp, []byte(strconv.FormatInt(extractDetails.MostRecentFileTimestamp.Unix(), 10)), 0644); err != nil {
+				return err
+			}
+
+		} else {
+			log.Printf("Unable to determine source timestamp of content in %s\n", flagValues.target)
+		}
+	}
+
+	return nil
+}
+
+// download writes the archive to the writer and returns its hex encoded SHA-256 checksum
+func download(ctx context.Context, w io.Writer) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, flagValues.url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if flagValues.secretPath != "" {
+		if err := setAuthorization(request); err != nil {
+			return "", err
+		}
+	}
+
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return "", err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download the archive, the server responded with %s", response.Status)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, hash), response.Body); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// newHTTPClient returns the client for the download, which trusts the certificate authorities
+// of the CA bundle in addition to the system ones
+func newHTTPClient() (*http.Client, error) {
+	if flagValues.caBundle == "" {
+		return http.DefaultClient, nil
+	}
+
+	rootCAs, err := image.LoadCertPool(flagValues.caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// setAuthorization sets the credentials of the secret on the request, either the username and
+// password for basic authentication, or the token for bearer authentication
+func setAuthorization(request *http.Request) error {
+	if hasFile(flagValues.secretPath, "username") && hasFile(flagValues.secretPath, "password") {
+		username, err := os.ReadFile(filepath.Join(flagValues.secretPath, "username"))
+		if err != nil {
+			return err
+		}
+
+		password, err := os.ReadFile(filepath.Join(flagValues.secretPath, "password"))
+		if err != nil {
+			return err
+		}
+
+		request.SetBasicAuth(string(username), string(password))
+		return nil
+	}
+
+	if hasFile(flagValues.secretPath, "token") {
+		token, err := os.ReadFile(filepath.Join(flagValues.secretPath, "token"))
+		if err != nil {
+			return err
+		}
+
+		request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+		return nil
+	}
+
+	return fmt.Errorf("unsupported type of credentials provided, either username and password, or a token is supported")
+}
+
+func hasFile(dir string, name string) bool {
+	info, err := os.Stat(filepath.Join(dir, name))
+	return err == nil && !info.IsDir()
+}
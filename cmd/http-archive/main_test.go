@@ -0,0 +1,210 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/shipwright-io/build/cmd/http-archive"
+)
+
+var _ = Describe("HTTP Archive", func() {
+	modTime := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	run := func(args ...string) error {
+		log.SetOutput(GinkgoWriter)
+
+		// discard stderr output
+		var tmp = os.Stderr
+		os.Stderr = nil
+		defer func() { os.Stderr = tmp }()
+
+		os.Args = append([]string{"tool"}, args...)
+		return Do(context.Background())
+	}
+
+	withTempDir := func(f func(target string)) {
+		path, err := os.MkdirTemp(os.TempDir(), "http-archive")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(path)
+
+		f(path)
+	}
+
+	filecontent := func(path string) string {
+		// #nosec G304 ok in tests
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	// sourceArchive returns a gzip compressed tar archive with a README.md file in a top-level directory
+	sourceArchive := func() []byte {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		Expect(tw.WriteHeader(&tar.Header{Name: "project-1.0/README.md", Typeflag: tar.TypeReg, Mode: 0644, Size: 6, ModTime: modTime})).To(Succeed())
+		_, err := tw.Write([]byte("readme"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tw.Close()).To(Succeed())
+		Expect(gw.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	checksum := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	withServer := func(handler http.HandlerFunc, f func(url string)) {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		f(server.URL + "/project-1.0.tar.gz")
+	}
+
+	serve := func(data []byte) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(data)
+		}
+	}
+
+	Context("validations and error cases", func() {
+		It("should succeed in case the help flag is used", func() {
+			Expect(run("--help")).To(Succeed())
+		})
+
+		It("should fail in case the URL is not set", func() {
+			Expect(run("--target", "/workspace/source")).To(MatchError("mandatory flag --url is not set"))
+		})
+
+		It("should fail in case the server does not respond with OK", func() {
+			withServer(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}, func(url string) {
+				withTempDir(func(target string) {
+					Expect(run("--url", url, "--target", target)).To(MatchError(ContainSubstring("the server responded with 404 Not Found")))
+				})
+			})
+		})
+
+		It("should fail in case the checksum does not match", func() {
+			data := sourceArchive()
+			withServer(serve(data), func(url string) {
+				withTempDir(func(target string) {
+					expected := checksum([]byte("something else"))
+					Expect(run("--url", url, "--target", target, "--sha256", expected)).To(MatchError(
+						"the SHA-256 checksum " + checksum(data) + " of the archive does not match the expected checksum " + expected,
+					))
+					Expect(filepath.Join(target, "README.md")).ToNot(BeAnExistingFile())
+				})
+			})
+		})
+	})
+
+	Context("downloading an archive", func() {
+		It("should extract the archive and write the result files", func() {
+			data := sourceArchive()
+			withServer(serve(data), func(url string) {
+				withTempDir(func(target string) {
+					resultDigest := filepath.Join(target, "..", filepath.Base(target)+"-digest")
+					resultTimestamp := filepath.Join(target, "..", filepath.Base(target)+"-timestamp")
+					defer os.Remove(resultDigest)
+					defer os.Remove(resultTimestamp)
+
+					Expect(run(
+						"--url", url,
+						"--target", target,
+						"--sha256", checksum(data),
+						"--strip-components", "1",
+						"--result-file-archive-digest", resultDigest,
+						"--result-file-source-timestamp", resultTimestamp,
+					)).To(Succeed())
+
+					Expect(filecontent(filepath.Join(target, "README.md"))).To(Equal("readme"))
+					Expect(filecontent(resultDigest)).To(Equal("sha256:" + checksum(data)))
+					Expect(filecontent(resultTimestamp)).To(Equal(strconv.FormatInt(modTime.Unix(), 10)))
+				})
+			})
+		})
+
+		It("should use the credentials of the secret", func() {
+			data := sourceArchive()
+			withServer(func(w http.ResponseWriter, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				if !ok || username != "user" || password != "secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write(data)
+			}, func(url string) {
+				withTempDir(func(secretPath string) {
+					Expect(os.WriteFile(filepath.Join(secretPath, "username"), []byte("user"), 0600)).To(Succeed())
+					Expect(os.WriteFile(filepath.Join(secretPath, "password"), []byte("secret"), 0600)).To(Succeed())
+
+					withTempDir(func(target string) {
+						Expect(run("--url", url, "--target", target, "--secret-path", secretPath)).To(Succeed())
+						Expect(filecontent(filepath.Join(target, "project-1.0", "README.md"))).To(Equal("readme"))
+					})
+				})
+			})
+		})
+
+		It("should trust the certificate authorities of the CA bundle", func() {
+			data := sourceArchive()
+			server := httptest.NewTLSServer(serve(data))
+			defer server.Close()
+
+			withTempDir(func(caDir string) {
+				caBundle := filepath.Join(caDir, "ca-bundle.crt")
+				Expect(os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)).To(Succeed())
+
+				withTempDir(func(target string) {
+					Expect(run("--url", server.URL+"/project-1.0.tar.gz", "--target", target)).To(MatchError(ContainSubstring("certificate")))
+				})
+
+				withTempDir(func(target string) {
+					Expect(run("--url", server.URL+"/project-1.0.tar.gz", "--target", target, "--ca-bundle", caBundle)).To(Succeed())
+					Expect(filecontent(filepath.Join(target, "project-1.0", "README.md"))).To(Equal("readme"))
+				})
+			})
+		})
+
+		It("should send a token of the secret as bearer token", func() {
+			data := sourceArchive()
+			withServer(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer my-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write(data)
+			}, func(url string) {
+				withTempDir(func(secretPath string) {
+					Expect(os.WriteFile(filepath.Join(secretPath, "token"), []byte("my-token\n"), 0600)).To(Succeed())
+
+					withTempDir(func(target string) {
+						Expect(run("--url", url, "--target", target, "--secret-path", secretPath)).To(Succeed())
+					})
+				})
+			})
+		})
+	})
+})
@@ -14,10 +14,12 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	containerreg "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/spf13/pflag"
 
@@ -42,15 +44,26 @@ type settings struct {
 	help bool
 	push string
 	annotation,
-	label []string
+	label,
+	platformDirectory,
+	additionalTag []string
 	insecure bool
 	image,
 	imageTimestamp,
 	imageTimestampFile,
+	buildRunName,
+	buildTimestamp,
+	gitCommitSha,
+	gitCommitShaFile,
+	gitDescribe,
+	gitDescribeFile,
 	resultFileImageDigest,
 	resultFileImageSize,
 	resultFileImageVulnerabilities,
-	secretPath string
+	resultFileImagePlatforms,
+	resultFileImageTags,
+	secretPath,
+	caBundle string
 	vulnerabilitySettings   resources.VulnerablilityScanParams
 	vulnerabilityCountLimit int
 }
@@ -66,8 +79,10 @@ func initializeFlag() {
 	pflag.StringVar(&flagValues.image, "image", "", "The name of image in container registry")
 	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains access credentials (optional)")
 	pflag.BoolVar(&flagValues.insecure, "insecure", false, "Flag indicating the the container registry is insecure")
+	pflag.StringVar(&flagValues.caBundle, "ca-bundle", "", "A file with PEM encoded certificate authorities to trust in addition to the system ones (optional)")
 
 	pflag.StringVar(&flagValues.push, "push", "", "Push the image contained in this directory")
+	pflag.StringArrayVar(&flagValues.platformDirectory, "platform-directory", nil, "Platform and directory of an image to add to a multi-platform image index, in the format os/arch=directory")
 
 	pflag.StringArrayVar(&flagValues.annotation, "annotation", nil, "New annotations to add")
 	pflag.StringArrayVar(&flagValues.label, "label", nil, "New labels to add")
@@ -75,9 +90,19 @@ func initializeFlag() {
 	pflag.StringVar(&flagValues.imageTimestamp, "image-timestamp", "", "number to use as Unix timestamp to set image creation timestamp")
 	pflag.StringVar(&flagValues.imageTimestampFile, "image-timestamp-file", "", "path to a file containing a unix timestamp to set as the image timestamp")
 
+	pflag.StringArrayVar(&flagValues.additionalTag, "additional-tag", nil, "Additional tag to push the image with, can contain the same placeholders as the image")
+	pflag.StringVar(&flagValues.buildRunName, "buildrun-name", "", "The name of the BuildRun for the $(buildrun.name) placeholder")
+	pflag.StringVar(&flagValues.buildTimestamp, "build-timestamp", "", "Unix timestamp of the BuildRun creation for the $(date) placeholder")
+	pflag.StringVar(&flagValues.gitCommitSha, "git-commit-sha", "", "The commit sha of the Git source for the $(source.git.commitSha) placeholder")
+	pflag.StringVar(&flagValues.gitCommitShaFile, "git-commit-sha-file", "", "path to a file containing the commit sha of the Git source for the $(source.git.commitSha) placeholder")
+	pflag.StringVar(&flagValues.gitDescribe, "git-describe", "", "The git describe version of the Git source for the $(source.git.describe) placeholder")
+	pflag.StringVar(&flagValues.gitDescribeFile, "git-describe-file", "", "path to a file containing the git describe version of the Git source for the $(source.git.describe) placeholder")
+
 	pflag.StringVar(&flagValues.resultFileImageDigest, "result-file-image-digest", "", "A file to write the image digest to")
 	pflag.StringVar(&flagValues.resultFileImageSize, "result-file-image-size", "", "A file to write the image size to")
 	pflag.StringVar(&flagValues.resultFileImageVulnerabilities, "result-file-image-vulnerabilities", "", "A file to write the image vulnerabilities to")
+	pflag.StringVar(&flagValues.resultFileImagePlatforms, "result-file-image-platforms", "", "A file to write the manifest digest of each platform of a multi-platform image to")
+	pflag.StringVar(&flagValues.resultFileImageTags, "result-file-image-tags", "", "A file to write the tags that the image was pushed with to")
 	pflag.Var(&flagValues.vulnerabilitySettings, "vuln-settings", "Vulnerability settings json string. One can enable the scan by setting {\"enabled\":true} to this option")
 	pflag.IntVar(&flagValues.vulnerabilityCountLimit, "vuln-count-limit", 50, "vulnerability count limit for the output of vulnerability scan")
 }
@@ -127,6 +152,33 @@ func Execute(ctx context.Context) error {
 		flagValues.imageTimestamp = string(data)
 	}
 
+	// validate that images are either pushed from one directory, or assembled from platform directories
+	if flagValues.push != "" && len(flagValues.platformDirectory) > 0 {
+		pflag.Usage()
+		return fmt.Errorf("push and platform directory flag is used, they are mutually exclusive, only use one")
+	}
+
+	// translate the files of the Git source results into their values, the describe result is empty
+	// when no tag is reachable, a file that does not exist is treated the same way
+	for _, result := range []struct {
+		value *string
+		file  string
+	}{
+		{&flagValues.gitCommitSha, flagValues.gitCommitShaFile},
+		{&flagValues.gitDescribe, flagValues.gitDescribeFile},
+	} {
+		if result.file == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(result.file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read the Git source result from %s: %w", result.file, err)
+		}
+
+		*result.value = strings.TrimSpace(string(data))
+	}
+
 	return runImageProcessing(ctx)
 }
 
@@ -135,7 +187,15 @@ func runImageProcessing(ctx context.Context) error {
 	if flagValues.image == "" {
 		return &ExitError{Code: 100, Message: "the 'image' argument must not be empty"}
 	}
-	imageName, err := image.ParseReference(flagValues.image, flagValues.insecure)
+	placeholderValues, err := tagPlaceholderValues()
+	if err != nil {
+		return err
+	}
+	imageReference, err := image.ResolveTagPlaceholders(flagValues.image, placeholderValues)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the tag of the image: %w", err)
+	}
+	imageName, err := image.ParseReference(imageReference, flagValues.insecure)
 	if err != nil {
 		return fmt.Errorf("failed to parse image name: %w", err)
 	}
@@ -153,7 +213,7 @@ func runImageProcessing(ctx context.Context) error {
 	}
 
 	// prepare the registry options
-	options, auth, err := image.GetOptions(ctx, imageName, flagValues.insecure, flagValues.secretPath, "Shipwright Build")
+	options, auth, err := image.GetOptions(ctx, imageName, flagValues.insecure, flagValues.caBundle, flagValues.secretPath, "Shipwright Build")
 	if err != nil {
 		return err
 	}
@@ -162,12 +222,16 @@ func runImageProcessing(ctx context.Context) error {
 	var img containerreg.Image
 	var imageIndex containerreg.ImageIndex
 	var isImageFromTar bool
-	if flagValues.push == "" {
-		log.Printf("Loading the image from the registry %q\n", imageName.String())
-		img, imageIndex, err = image.LoadImageOrImageIndexFromRegistry(imageName, options)
-	} else {
+	switch {
+	case len(flagValues.platformDirectory) > 0:
+		log.Printf("Assembling the image index from %d platform directories\n", len(flagValues.platformDirectory))
+		imageIndex, err = loadImageIndexFromPlatformDirectories(flagValues.platformDirectory)
+	case flagValues.push != "":
 		log.Printf("Loading the image from the directory %q\n", flagValues.push)
 		img, imageIndex, isImageFromTar, err = image.LoadImageOrImageIndexFromDirectory(flagValues.push)
+	default:
+		log.Printf("Loading the image from the registry %q\n", imageName.String())
+		img, imageIndex, err = image.LoadImageOrImageIndexFromRegistry(imageName, options)
 	}
 	if err != nil {
 		log.Printf("Failed to load the image: %v\n", err)
@@ -193,7 +257,25 @@ func runImageProcessing(ctx context.Context) error {
 	// check for image vulnerabilities if vulnerability scanning is enabled.
 	var vulns []buildapi.Vulnerability
 
-	if flagValues.vulnerabilitySettings.Enabled {
+	if flagValues.vulnerabilitySettings.Enabled && len(flagValues.platformDirectory) > 0 {
+		for _, platformDirectory := range flagValues.platformDirectory {
+			_, directory, _ := strings.Cut(platformDirectory, "=")
+			imageString, err := imageInDirectory(directory)
+			if err != nil {
+				return err
+			}
+
+			platformVulns, err := image.RunVulnerabilityScan(ctx, imageString, flagValues.vulnerabilitySettings.VulnerabilityScanOptions, auth, flagValues.insecure, true, flagValues.vulnerabilityCountLimit)
+			if err != nil {
+				return err
+			}
+			vulns = append(vulns, platformVulns...)
+		}
+
+		if err := writeVulnerabilities(vulns); err != nil {
+			return err
+		}
+	} else if flagValues.vulnerabilitySettings.Enabled {
 		var imageString string
 		var imageInDir bool
 		if flagValues.push != "" {
@@ -217,22 +299,13 @@ func runImageProcessing(ctx context.Context) error {
 			return err
 		}
 
-		// log all the vulnerabilities
-		if len(vulns) > 0 {
-			log.Println("vulnerabilities found in the output image :")
-			for _, vuln := range vulns {
-				log.Printf("ID: %s, Severity: %s\n", vuln.ID, vuln.Severity)
-			}
-		}
-		vulnOuput := serializeVulnerabilities(vulns)
-		// #nosec G306 the file must be readable by build steps that potentially run as a different user
-		if err := os.WriteFile(flagValues.resultFileImageVulnerabilities, vulnOuput, 0640); err != nil {
+		if err := writeVulnerabilities(vulns); err != nil {
 			return err
 		}
 	}
 
 	// Don't push the image if fail is set to true for shipwright managed push
-	if flagValues.push != "" {
+	if flagValues.push != "" || len(flagValues.platformDirectory) > 0 {
 		if flagValues.vulnerabilitySettings.FailOnFinding && len(vulns) > 0 {
 			log.Println("vulnerabilities have been found in the output image, exiting with code 22")
 			return &ExitError{Code: 22, Message: "vulnerabilities found, exiting with code 22", Cause: errors.New("vulnerabilities found in the image")}
@@ -262,6 +335,44 @@ func runImageProcessing(ctx context.Context) error {
 
 	log.Printf("Image %s@%s pushed\n", imageName.String(), digest)
 
+	var tags []string
+	if tag, ok := imageName.(name.Tag); ok {
+		tags = append(tags, tag.TagStr())
+	}
+
+	// push the image with the additional tags
+	for _, additionalTag := range flagValues.additionalTag {
+		tag, err := image.ResolveTagPlaceholders(additionalTag, placeholderValues)
+		if err != nil {
+			log.Printf("Skipping the additional tag %q: %v\n", additionalTag, err)
+			continue
+		}
+
+		if slices.Contains(tags, tag) {
+			continue
+		}
+
+		tagName, err := image.ParseReference(fmt.Sprintf("%s:%s", imageName.Context().Name(), tag), flagValues.insecure)
+		if err != nil {
+			return fmt.Errorf("failed to parse the additional tag %q: %w", tag, err)
+		}
+
+		log.Printf("Pushing the image with the additional tag %q\n", tag)
+		if _, _, err := image.PushImageOrImageIndex(tagName, img, imageIndex, options); err != nil {
+			log.Printf("Failed to push the image with the additional tag %q: %v\n", tag, err)
+			return err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	// Writing image tags to file
+	if len(tags) > 0 && flagValues.resultFileImageTags != "" {
+		if err := os.WriteFile(flagValues.resultFileImageTags, []byte(strings.Join(tags, ",")), 0400); err != nil {
+			return err
+		}
+	}
+
 	// Writing image digest to file
 	if digest != "" && flagValues.resultFileImageDigest != "" {
 		if err := os.WriteFile(flagValues.resultFileImageDigest, []byte(digest), 0400); err != nil {
@@ -276,9 +387,103 @@ func runImageProcessing(ctx context.Context) error {
 		}
 	}
 
+	// Writing the manifest digest of each platform to file
+	if imageIndex != nil && len(flagValues.platformDirectory) > 0 && flagValues.resultFileImagePlatforms != "" {
+		platformDigests, err := image.PlatformDigests(imageIndex)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(flagValues.resultFileImagePlatforms, []byte(strings.Join(platformDigests, ",")), 0400); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// tagPlaceholderValues returns the values of the placeholders in the tags of the image
+func tagPlaceholderValues() (map[string]string, error) {
+	values := map[string]string{
+		buildapi.OutputImageTagGitCommitSha: flagValues.gitCommitSha,
+		buildapi.OutputImageTagGitDescribe:  flagValues.gitDescribe,
+		buildapi.OutputImageTagBuildRunName: flagValues.buildRunName,
+	}
+
+	if flagValues.buildTimestamp != "" {
+		sec, err := strconv.ParseInt(flagValues.buildTimestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse build timestamp value %q as a number: %w", flagValues.buildTimestamp, err)
+		}
+
+		values[buildapi.OutputImageTagDate] = time.Unix(sec, 0).UTC().Format("20060102-150405")
+	}
+
+	return values, nil
+}
+
+// loadImageIndexFromPlatformDirectories loads the image of every platform directory and
+// assembles them into one image index, the values are in the format os/arch=directory
+func loadImageIndexFromPlatformDirectories(platformDirectories []string) (containerreg.ImageIndex, error) {
+	var platformImages []image.PlatformImage
+	for _, platformDirectory := range platformDirectories {
+		platform, directory, found := strings.Cut(platformDirectory, "=")
+		if !found || directory == "" {
+			return nil, fmt.Errorf("invalid platform directory %q, must be in the format os/arch=directory", platformDirectory)
+		}
+
+		platformOS, platformArch, err := image.ParsePlatform(platform)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("Loading the image for platform %s from the directory %q\n", platform, directory)
+		img, imageIndex, _, err := image.LoadImageOrImageIndexFromDirectory(directory)
+		if err != nil {
+			return nil, err
+		}
+		if imageIndex != nil {
+			return nil, fmt.Errorf("the directory %q of platform %s contains an image index, expected a single image", directory, platform)
+		}
+
+		platformImages = append(platformImages, image.PlatformImage{OS: platformOS, Arch: platformArch, Image: img})
+	}
+
+	return image.NewImageIndex(platformImages)
+}
+
+// imageInDirectory returns the path to scan for an image that is stored in a directory,
+// which is the tar file in case the directory contains an image tarball
+func imageInDirectory(directory string) (string, error) {
+	if _, err := os.Stat(filepath.Join(directory, "index.json")); err == nil {
+		return directory, nil
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 {
+		return filepath.Join(directory, entries[0].Name()), nil
+	}
+
+	return directory, nil
+}
+
+// writeVulnerabilities logs the vulnerabilities and writes them to the result file
+func writeVulnerabilities(vulns []buildapi.Vulnerability) error {
+	if len(vulns) > 0 {
+		log.Println("vulnerabilities found in the output image :")
+		for _, vuln := range vulns {
+			log.Printf("ID: %s, Severity: %s\n", vuln.ID, vuln.Severity)
+		}
+	}
+
+	vulnOuput := serializeVulnerabilities(vulns)
+	// #nosec G306 the file must be readable by build steps that potentially run as a different user
+	return os.WriteFile(flagValues.resultFileImageVulnerabilities, vulnOuput, 0640)
+}
+
 // splitKeyVals splits key value pairs which is in form hello=world
 func splitKeyVals(kvPairs []string) (map[string]string, error) {
 	m := map[string]string{}
@@ -21,6 +21,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/registry"
 	containerreg "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -372,6 +373,60 @@ var _ = Describe("Image Processing Resource", Ordered, func() {
 		})
 	})
 
+	Context("assembling a multi-platform image", func() {
+		It("should fail if both --push and --platform-directory are used", func() {
+			Expect(run(
+				"--image", "some-registry/some-namespace/some-image",
+				"--push", "/workspace/output-image",
+				"--platform-directory", "linux/amd64=/workspace/output-image/linux-amd64",
+			)).To(HaveOccurred())
+		})
+
+		It("should push an image index with the image of every platform", func() {
+			withTempRegistry(func(endpoint string) {
+				withTempDir(func(dir string) {
+					withTempFile("image-platforms", func(filename string) {
+						tag, err := name.NewTag(fmt.Sprintf("%s/%s:%s", endpoint, "temp-image", rand.String(5)))
+						Expect(err).ToNot(HaveOccurred())
+
+						amd64Image, err := random.Image(1024, 1)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(crane.SaveOCI(amd64Image, path.Join(dir, "linux-amd64"))).To(Succeed())
+
+						arm64Image, err := random.Image(2048, 1)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(crane.SaveOCI(arm64Image, path.Join(dir, "linux-arm64"))).To(Succeed())
+
+						Expect(run(
+							"--insecure",
+							"--image", tag.String(),
+							"--platform-directory", "linux/amd64="+path.Join(dir, "linux-amd64"),
+							"--platform-directory", "linux/arm64="+path.Join(dir, "linux-arm64"),
+							"--result-file-image-platforms", filename,
+						)).ToNot(HaveOccurred())
+
+						ref, err := name.ParseReference(tag.String())
+						Expect(err).ToNot(HaveOccurred())
+
+						imageIndex, err := remote.Index(ref)
+						Expect(err).ToNot(HaveOccurred())
+
+						indexManifest, err := imageIndex.IndexManifest()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(indexManifest.Manifests).To(HaveLen(2))
+
+						amd64Digest, err := amd64Image.Digest()
+						Expect(err).ToNot(HaveOccurred())
+						arm64Digest, err := arm64Image.Digest()
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(filecontent(filename)).To(Equal(fmt.Sprintf("linux/amd64=%s,linux/arm64=%s", amd64Digest, arm64Digest)))
+					})
+				})
+			})
+		})
+	})
+
 	Context("store result after image mutation", func() {
 		It("should store image digest into file specified in --result-file-image-digest flags", func() {
 			withTestImage(func(tag name.Tag) {
@@ -405,6 +460,67 @@ var _ = Describe("Image Processing Resource", Ordered, func() {
 		})
 	})
 
+	Context("pushing the image with tags", func() {
+		It("should resolve the placeholders and push the image with the additional tags", func() {
+			withTestImageAsDirectory(func(path string, tag name.Tag) {
+				withTempFile("describe", func(describeFile string) {
+					withTempFile("image-tags", func(tagsFile string) {
+						Expect(os.WriteFile(describeFile, []byte("v1.1.0-3-g0e05834\n"), 0644)).To(Succeed())
+
+						Expect(run(
+							"--insecure",
+							"--push", path,
+							"--image", tag.Context().Name()+":$(buildrun.name)",
+							"--buildrun-name", "sample-go-abcde",
+							"--build-timestamp", "1691650396",
+							"--git-describe-file", describeFile,
+							"--additional-tag", "$(source.git.describe)",
+							"--additional-tag", "build-$(date)",
+							"--additional-tag", "latest",
+							"--result-file-image-tags", tagsFile,
+						)).To(Succeed())
+
+						Expect(filecontent(tagsFile)).To(Equal("sample-go-abcde,v1.1.0-3-g0e05834,build-20230810-065316,latest"))
+
+						tags, err := crane.ListTags(tag.Context().Name())
+						Expect(err).ToNot(HaveOccurred())
+						Expect(tags).To(ConsistOf("sample-go-abcde", "v1.1.0-3-g0e05834", "build-20230810-065316", "latest"))
+
+						digest := getImageDigest(tag.Context().Tag("sample-go-abcde"))
+						Expect(getImageDigest(tag.Context().Tag("latest"))).To(Equal(digest))
+					})
+				})
+			})
+		})
+
+		It("should skip an additional tag whose placeholder has no value", func() {
+			withTestImageAsDirectory(func(path string, tag name.Tag) {
+				withTempFile("image-tags", func(tagsFile string) {
+					Expect(run(
+						"--insecure",
+						"--push", path,
+						"--image", tag.String(),
+						"--git-describe-file", "/does/not/exist",
+						"--additional-tag", "$(source.git.describe)",
+						"--result-file-image-tags", tagsFile,
+					)).To(Succeed())
+
+					Expect(filecontent(tagsFile)).To(Equal(tag.TagStr()))
+				})
+			})
+		})
+
+		It("should fail if a placeholder of the image has no value", func() {
+			withTestImageAsDirectory(func(path string, tag name.Tag) {
+				Expect(run(
+					"--insecure",
+					"--push", path,
+					"--image", tag.Context().Name()+":$(source.git.commitSha)",
+				)).To(MatchError(ContainSubstring("there is no value for the placeholder $(source.git.commitSha)")))
+			})
+		})
+	})
+
 	Context("Vulnerability Scanning", func() {
 		directory := path.Join("..", "..", "test", "data", "images", "vuln-image-in-oci")
 
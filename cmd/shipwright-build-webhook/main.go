@@ -15,18 +15,29 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/signals"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
+	"github.com/shipwright-io/build/pkg/apis"
 	"github.com/shipwright-io/build/pkg/ctxlog"
 	"github.com/shipwright-io/build/pkg/webhook/conversion"
 	"github.com/shipwright-io/build/pkg/webhook/tlsconfig"
+	"github.com/shipwright-io/build/pkg/webhook/trigger"
+	"github.com/shipwright-io/build/pkg/webhook/validation"
 	"github.com/shipwright-io/build/version"
 )
 
 var (
-	versionGiven    = flag.String("version", "devel", "Version of Shipwright webhook running")
-	tlsMinVersion   = pflag.String("tls-min-version", "", "Minimum TLS version for the webhook HTTPS server (VersionTLS10, VersionTLS11, VersionTLS12, VersionTLS13). Defaults to VersionTLS12.")
-	tlsCipherSuites = pflag.String("tls-cipher-suites", "", "Comma-separated list of TLS 1.2 cipher suites (Go cipher suite names). Only applies when the minimum TLS version is below TLS 1.3. Defaults to Go runtime selection.")
+	versionGiven     = flag.String("version", "devel", "Version of Shipwright webhook running")
+	tlsMinVersion    = pflag.String("tls-min-version", "", "Minimum TLS version for the webhook HTTPS server (VersionTLS10, VersionTLS11, VersionTLS12, VersionTLS13). Defaults to VersionTLS12.")
+	tlsCipherSuites  = pflag.String("tls-cipher-suites", "", "Comma-separated list of TLS 1.2 cipher suites (Go cipher suite names). Only applies when the minimum TLS version is below TLS 1.3. Defaults to Go runtime selection.")
+	triggers         = pflag.Bool("triggers", false, "Serve the webhook endpoints of Git services on port 8080 instead of the conversion and admission webhooks.")
+	triggerRateLimit = pflag.Float64("trigger-rate-limit", 10, "Maximum number of requests per second to the webhook endpoints of Git services.")
+	triggerRateBurst = pflag.Int("trigger-rate-burst", 20, "Maximum number of requests to the webhook endpoints of Git services that are served at once over the rate limit.")
 )
 
 func printVersion(ctx context.Context) {
@@ -60,6 +71,32 @@ func Execute() error {
 	version.SetVersion(*versionGiven)
 	printVersion(ctx)
 
+	var server *http.Server
+	var err error
+	if *triggers {
+		server, err = newTriggerServer(ctx)
+	} else {
+		server, err = newWebhookServer(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	stopCh := signals.SetupSignalHandler()
+	sig := <-stopCh
+
+	l.Info("Shutting down server.", "signal", sig)
+	ctxlog.Info(ctx, "shutting down webhook server,", "signal:", sig)
+	if err := server.Shutdown(context.Background()); err != nil {
+		l.Error(err, "Failed to gracefully shutdown the server.")
+		return err
+	}
+	return nil
+
+}
+
+// newWebhookServer returns the HTTPS server of the conversion and admission webhooks, which the Kubernetes API server calls
+func newWebhookServer(ctx context.Context) (*http.Server, error) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", health)
 	ctxlog.Info(ctx, "adding handlefunc() /health")
@@ -68,10 +105,14 @@ func Execute() error {
 	mux.HandleFunc("/convert", conversion.CRDConvertHandler(ctx))
 	ctxlog.Info(ctx, "adding handlefunc() /convert")
 
+	// validate endpoint handles AdmissionReview API object serialized to JSON
+	mux.HandleFunc("/validate", validation.AdmissionHandler(ctx))
+	ctxlog.Info(ctx, "adding handlefunc() /validate")
+
 	serverTLSConfig, warning, err := tlsconfig.BuildServerTLSConfig(*tlsMinVersion, *tlsCipherSuites)
 	if err != nil {
 		ctxlog.Error(ctx, err, "invalid TLS configuration")
-		return err
+		return nil, err
 	}
 	if warning != "" {
 		ctxlog.Info(ctx, warning)
@@ -101,17 +142,75 @@ func Execute() error {
 		}
 	}()
 
-	stopCh := signals.SetupSignalHandler()
-	sig := <-stopCh
+	return server, nil
+}
 
-	l.Info("Shutting down server.", "signal", sig)
-	ctxlog.Info(ctx, "shutting down webhook server,", "signal:", sig)
-	if err := server.Shutdown(context.Background()); err != nil {
-		l.Error(err, "Failed to gracefully shutdown the server.")
-		return err
+// newTriggerServer returns the server of the webhook endpoints of Git services. It is exposed to the Git services,
+// for example through an Ingress that terminates TLS, and therefore runs separately from the admission webhooks
+// with its own service account that can look up Builds and create BuildRuns.
+func newTriggerServer(ctx context.Context) (*http.Server, error) {
+	c, err := newClient()
+	if err != nil {
+		ctxlog.Error(ctx, err, "failed to create a Kubernetes client")
+		return nil, err
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(*triggerRateLimit), *triggerRateBurst)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", health)
+	ctxlog.Info(ctx, "adding handlefunc() /health")
+
+	mux.HandleFunc("/triggers/github", trigger.RateLimited(limiter, trigger.GitHubHandler(ctx, c)))
+	ctxlog.Info(ctx, "adding handlefunc() /triggers/github")
+
+	mux.HandleFunc("/triggers/gitlab", trigger.RateLimited(limiter, trigger.GitLabHandler(ctx, c)))
+	ctxlog.Info(ctx, "adding handlefunc() /triggers/gitlab")
+
+	mux.HandleFunc("/triggers/gitea", trigger.RateLimited(limiter, trigger.GiteaHandler(ctx, c)))
+	ctxlog.Info(ctx, "adding handlefunc() /triggers/gitea")
+
+	mux.HandleFunc("/triggers/bitbucket", trigger.RateLimited(limiter, trigger.BitbucketHandler(ctx, c)))
+	ctxlog.Info(ctx, "adding handlefunc() /triggers/bitbucket")
+
+	server := &http.Server{
+		Addr:              ":8080",
+		Handler:           mux,
+		ReadHeaderTimeout: 32 * time.Second,
+	}
+
+	go func() {
+		ctxlog.Info(ctx, "starting trigger server")
+		// blocking call, returns on error
+		if err := server.ListenAndServe(); err != nil {
+			ctxlog.Error(ctx, err, "trigger server failed to start")
+		}
+	}()
+
+	return server, nil
+}
+
+// newClient creates a client for the Shipwright Build and Kubernetes core objects
+func newClient() (client.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := k8sruntime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
 	}
-	return nil
 
+	return client.WithFieldOwner(c, "shipwright-build-webhook"), nil
 }
 
 func health(resp http.ResponseWriter, _ *http.Request) {
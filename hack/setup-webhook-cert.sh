@@ -87,6 +87,9 @@ kubectl patch crd buildstrategies.shipwright.io -p "{\"spec\":{\"conversion\":{\
 kubectl patch crd builds.shipwright.io -p "{\"spec\":{\"conversion\":{\"webhook\":{\"clientConfig\":{\"caBundle\":\"${CA}\"}}}}}"
 kubectl patch crd buildruns.shipwright.io -p "{\"spec\":{\"conversion\":{\"webhook\":{\"clientConfig\":{\"caBundle\":\"${CA}\"}}}}}"
 
+echo "[INFO] Patching caBundle into ValidatingWebhookConfiguration"
+kubectl patch validatingwebhookconfiguration validation.shipwright.io --type=json -p "[{\"op\":\"add\",\"path\":\"/webhooks/0/clientConfig/caBundle\",\"value\":\"${CA}\"}]"
+
 echo "[INFO] Restarting shipwright-build-webhook"
 kubectl -n shipwright-build rollout restart deployment shipwright-build-webhook
 kubectl -n shipwright-build rollout status deployment shipwright-build-webhook
\ No newline at end of file
@@ -373,7 +373,10 @@ func (p TriggerWhen) convertToAlpha(dest *buildapialpha.TriggerWhen) {
 	}
 	dest.GitHub.Branches = p.GetBranches(GitHubWebHookTrigger)
 
-	dest.Image = (*buildapialpha.WhenImage)(p.Image)
+	if p.Image != nil {
+		// the pull secret and insecure setting do not exist in v1alpha1
+		dest.Image = &buildapialpha.WhenImage{Names: p.Image.Names}
+	}
 	dest.ObjectRef = (*buildapialpha.WhenObjectRef)(p.ObjectRef)
 
 }
@@ -418,7 +421,9 @@ func convertToBetaTriggers(orig *buildapialpha.TriggerWhen) TriggerWhen {
 	}
 
 	dest.GitHub.Branches = orig.GetBranches(buildapialpha.GitHubWebHookTrigger)
-	dest.Image = (*WhenImage)(orig.Image)
+	if orig.Image != nil {
+		dest.Image = &WhenImage{Names: orig.Image.Names}
+	}
 	dest.ObjectRef = (*WhenObjectRef)(orig.ObjectRef)
 
 	return dest
@@ -26,6 +26,8 @@ const (
 	SetOwnerReferenceFailed BuildReason = "SetOwnerReferenceFailed"
 	// SpecSourceSecretRefNotFound indicates the referenced secret in source is missing
 	SpecSourceSecretRefNotFound BuildReason = "SpecSourceSecretRefNotFound"
+	// SpecSourceSecretNotValid indicates the referenced secret in source does not contain valid credentials
+	SpecSourceSecretNotValid BuildReason = "SpecSourceSecretNotValid"
 	// SpecOutputSecretRefNotFound indicates the referenced secret in output is missing
 	SpecOutputSecretRefNotFound BuildReason = "SpecOutputSecretRefNotFound"
 	// SpecBuilderSecretRefNotFound indicates the referenced secret in builder is missing
@@ -33,6 +35,11 @@ const (
 	SpecBuilderSecretRefNotFound BuildReason = "SpecBuilderSecretRefNotFound"
 	// MultipleSecretRefNotFound indicates that multiple secrets are missing
 	MultipleSecretRefNotFound BuildReason = "MultipleSecretRefNotFound"
+	// SpecTriggerSecretRefNotFound indicates the referenced secret in a trigger is missing
+	SpecTriggerSecretRefNotFound BuildReason = "SpecTriggerSecretRefNotFound"
+	// SpecTrustedCAConfigMapNotFound indicates the referenced ConfigMap with the trusted certificate authorities
+	// or its key is missing
+	SpecTrustedCAConfigMapNotFound BuildReason = "SpecTrustedCAConfigMapNotFound"
 	// SpecEnvNameCanNotBeBlank indicates that the name for an environment variable is blank
 	SpecEnvNameCanNotBeBlank BuildReason = "SpecEnvNameCanNotBeBlank"
 	// SpecEnvOnlyOneOfValueOrValueFromMustBeSpecified indicates that both value and valueFrom were specified
@@ -73,14 +80,28 @@ const (
 	TriggerInvalidType BuildReason = "TriggerInvalidType"
 	// TriggerInvalidGitHubWebHook indicates the trigger type GitHub is invalid
 	TriggerInvalidGitHubWebHook BuildReason = "TriggerInvalidGitHubWebHook"
+	// TriggerInvalidGitLabWebHook indicates the trigger type GitLab is invalid
+	TriggerInvalidGitLabWebHook BuildReason = "TriggerInvalidGitLabWebHook"
+	// TriggerInvalidGiteaWebHook indicates the trigger type Gitea is invalid
+	TriggerInvalidGiteaWebHook BuildReason = "TriggerInvalidGiteaWebHook"
+	// TriggerInvalidBitbucketWebHook indicates the trigger type Bitbucket is invalid
+	TriggerInvalidBitbucketWebHook BuildReason = "TriggerInvalidBitbucketWebHook"
 	// TriggerInvalidImage indicates the trigger type Image is invalid
 	TriggerInvalidImage BuildReason = "TriggerInvalidImage"
 	// TriggerInvalidPipeline indicates the trigger type Pipeline is invalid
 	TriggerInvalidPipeline BuildReason = "TriggerInvalidPipeline"
+	// TriggerInvalidSchedule indicates the trigger type Schedule is invalid
+	TriggerInvalidSchedule BuildReason = "TriggerInvalidSchedule"
+	// TriggerInvalidBuildRun indicates the trigger type BuildRun is invalid
+	TriggerInvalidBuildRun BuildReason = "TriggerInvalidBuildRun"
+	// TriggerSecretMissing indicates a Git webhook trigger is defined without a trigger secret
+	TriggerSecretMissing BuildReason = "TriggerSecretMissing"
 	// OutputTimestampNotSupported indicates that an unsupported output timestamp setting was used
 	OutputTimestampNotSupported BuildReason = "OutputTimestampNotSupported"
 	// OutputTimestampNotValid indicates that the output timestamp value is not valid
 	OutputTimestampNotValid BuildReason = "OutputTimestampNotValid"
+	// OutputTagNotValid indicates that a tag of the output image or one of its placeholders is not valid
+	OutputTagNotValid BuildReason = "OutputTagNotValid"
 	// NodeSelectorNotValid indicates that the nodeSelector value is not valid
 	NodeSelectorNotValid BuildReason = "NodeSelectorNotValid"
 	// TolerationNotValid indicates that the Toleration value is not valid
@@ -96,6 +117,12 @@ const (
 	InvalidPlatform BuildReason = "InvalidPlatform"
 	// ExecutorNotPipelineRun indicates multi-arch builds require PipelineRun executor mode
 	ExecutorNotPipelineRun BuildReason = "ExecutorNotPipelineRun"
+	// MultiPlatformStorageClassMissing indicates multi-arch builds require a storage class that provides
+	// ReadWriteMany volumes
+	MultiPlatformStorageClassMissing BuildReason = "MultiPlatformStorageClassMissing"
+	// MultiPlatformAffinityAssistantEnabled indicates multi-arch builds require the affinity assistant of
+	// Tekton to be disabled, because it schedules the tasks of all platforms on the node of the first one
+	MultiPlatformAffinityAssistantEnabled BuildReason = "MultiPlatformAffinityAssistantEnabled"
 	// NodePlatformNotFound indicates no schedulable node was found for a requested platform
 	NodePlatformNotFound BuildReason = "NodePlatformNotFound"
 	// AllValidationsSucceeded indicates a Build was successfully validated
@@ -149,6 +176,20 @@ const (
 	OutputImageBuildTimestamp = "BuildTimestamp"
 )
 
+const (
+	// OutputImageTagGitCommitSha is the placeholder for the commit sha of the Git source in a tag of the output image
+	OutputImageTagGitCommitSha = "$(source.git.commitSha)"
+
+	// OutputImageTagGitDescribe is the placeholder for the git describe version of the Git source in a tag of the output image
+	OutputImageTagGitDescribe = "$(source.git.describe)"
+
+	// OutputImageTagBuildRunName is the placeholder for the name of the BuildRun in a tag of the output image
+	OutputImageTagBuildRunName = "$(buildrun.name)"
+
+	// OutputImageTagDate is the placeholder for the creation date of the BuildRun in a tag of the output image
+	OutputImageTagDate = "$(date)"
+)
+
 // BuildSpec defines the desired state of Build
 type BuildSpec struct {
 	// Source refers to the location where the source code is,
@@ -158,6 +199,14 @@ type BuildSpec struct {
 	// +optional
 	Source *Source `json:"source"`
 
+	// AdditionalSources refers to the locations of further source code, for example
+	// shared build scripts, that is fetched into subdirectories of the source root
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	AdditionalSources []AdditionalSource `json:"additionalSources,omitempty"`
+
 	// Trigger defines the scenarios where a new build should be triggered.
 	//
 	// +optional
@@ -182,6 +231,12 @@ type BuildSpec struct {
 	// +kubebuilder:validation:Format=duration
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// Timeouts defines the maximum amount of time of the source, build and output phases
+	// of the BuildRuns of this Build
+	//
+	// +optional
+	Timeouts *PhaseTimeouts `json:"timeouts,omitempty"`
+
 	// Env contains additional environment variables that should be passed to the build container
 	//
 	// +optional
@@ -218,8 +273,49 @@ type BuildSpec struct {
 	// RuntimeClassName specifies the RuntimeClass to be used to run the Pod
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat BuildRuns of this Build that overlap, valid values are:
+	// - "Allow" (default), to run the BuildRuns in parallel
+	// - "Forbid", to queue a new BuildRun until the BuildRuns that were created before it finished
+	// - "Replace", to cancel the BuildRuns that were created before a new BuildRun and are not yet finished
+	//
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Retry defines how often the BuildRuns of this Build are attempted again when they
+	// fail for a transient reason
+	//
+	// +optional
+	Retry *Retry `json:"retry,omitempty"`
+
+	// TrustedCA references a ConfigMap with a bundle of certificate authorities, which the
+	// source and output steps trust in addition to the ones of the system, for example the
+	// certificate authority of an internal Git server or container registry
+	//
+	// +optional
+	TrustedCA *TrustedCA `json:"trustedCA,omitempty"`
+
+	// Proxy defines the HTTP proxy that the source and output steps use
+	//
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
 }
 
+// ConcurrencyPolicy describes how BuildRuns of the same Build are handled when they overlap
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows BuildRuns of the same Build to run in parallel
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+
+	// ForbidConcurrent queues a BuildRun until the earlier BuildRuns of the same Build finished
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+
+	// ReplaceConcurrent cancels the earlier BuildRuns of the same Build that did not finish yet
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
 // BuildVolume is a volume that will be mounted in build pod during build step
 type BuildVolume struct {
 	// Name of the Build Volume
@@ -298,9 +394,17 @@ type ImagePlatform struct {
 
 // Image refers to an container image with credentials
 type Image struct {
-	// Image is the reference of the image.
+	// Image is the reference of the image. Its tag can contain the placeholders
+	// $(source.git.commitSha), $(source.git.describe), $(buildrun.name) and $(date),
+	// which are resolved when the image is pushed.
 	Image string `json:"image"`
 
+	// AdditionalTags are further tags of the image that it is pushed with, they
+	// can contain the same placeholders as the tag of the image.
+	//
+	// +optional
+	AdditionalTags []string `json:"additionalTags,omitempty"`
+
 	// Insecure defines whether the registry is not secure
 	//
 	// +optional
@@ -362,6 +466,60 @@ type BuildStatus struct {
 	// The message of the registered Build, either an error or succeed message
 	// +optional
 	Message *string `json:"message,omitempty"`
+
+	// Triggers holds the state of the triggers of the Build
+	// +optional
+	Triggers []TriggerStatus `json:"triggers,omitempty"`
+}
+
+// TriggerStatus describes the state of a trigger of a Build
+type TriggerStatus struct {
+	// Name of the trigger, matching the name of an entry in spec.trigger.when
+	Name string `json:"name"`
+
+	// LastScheduleTime is the scheduled time of the last BuildRun that was created for a Schedule trigger
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Images are the last seen digests of the images of an Image trigger
+	// +optional
+	Images []TriggerImageStatus `json:"images,omitempty"`
+
+	// ImageGeneration is increased whenever a digest of the images of an Image trigger changes,
+	// so that a digest that changes back to an earlier value creates a new BuildRun
+	// +optional
+	ImageGeneration int64 `json:"imageGeneration,omitempty"`
+}
+
+// TriggerImageStatus describes the last seen digest of an image of an Image trigger
+type TriggerImageStatus struct {
+	// Name of the image, matching an entry in spec.trigger.when[].image.names
+	Name string `json:"name"`
+
+	// Digest of the image
+	Digest string `json:"digest"`
+}
+
+// GetTriggerStatus returns the status of the trigger with the given name, or nil if there is none
+func (bs *BuildStatus) GetTriggerStatus(name string) *TriggerStatus {
+	for i := range bs.Triggers {
+		if bs.Triggers[i].Name == name {
+			return &bs.Triggers[i]
+		}
+	}
+	return nil
+}
+
+// SetTriggerStatus adds or replaces the status of a trigger
+func (bs *BuildStatus) SetTriggerStatus(triggerStatus TriggerStatus) {
+	for i := range bs.Triggers {
+		if bs.Triggers[i].Name == triggerStatus.Name {
+			bs.Triggers[i] = triggerStatus
+			return
+		}
+	}
+
+	bs.Triggers = append(bs.Triggers, triggerStatus)
 }
 
 // +genclient
@@ -440,6 +598,10 @@ func (b Build) GetSourceCredentials() *string {
 		if b.Spec.Source.OCIArtifact != nil && b.Spec.Source.OCIArtifact.PullSecret != nil {
 			return b.Spec.Source.OCIArtifact.PullSecret
 		}
+	case HTTPType:
+		if b.Spec.Source.HTTPArchive != nil && b.Spec.Source.HTTPArchive.AuthSecret != nil {
+			return b.Spec.Source.HTTPArchive.AuthSecret
+		}
 	default:
 		if b.Spec.Source.Git != nil && b.Spec.Source.Git.CloneSecret != nil {
 			return b.Spec.Source.Git.CloneSecret
@@ -447,3 +609,31 @@ func (b Build) GetSourceCredentials() *string {
 	}
 	return nil
 }
+
+// GetSignatureVerificationSecrets returns the names of the secrets with the trusted keys of the
+// Git sources of a Build
+func (b Build) GetSignatureVerificationSecrets() []string {
+	var secrets []string
+	if b.Spec.Source != nil && b.Spec.Source.Type == GitType && b.Spec.Source.Git != nil && b.Spec.Source.Git.SignatureVerificationSecret != nil {
+		secrets = append(secrets, *b.Spec.Source.Git.SignatureVerificationSecret)
+	}
+
+	for _, source := range b.Spec.AdditionalSources {
+		if source.Type == GitType && source.Git != nil && source.Git.SignatureVerificationSecret != nil {
+			secrets = append(secrets, *source.Git.SignatureVerificationSecret)
+		}
+	}
+
+	return secrets
+}
+
+// GetAdditionalSourceCredentials returns the secret names of the additional sources of a Build
+func (b Build) GetAdditionalSourceCredentials() []string {
+	var secrets []string
+	for _, source := range b.Spec.AdditionalSources {
+		if secret := source.GetCredentials(); secret != nil {
+			secrets = append(secrets, *secret)
+		}
+	}
+	return secrets
+}
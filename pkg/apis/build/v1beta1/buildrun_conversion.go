@@ -115,8 +115,12 @@ func (src *BuildRun) ConvertTo(ctx context.Context, obj *unstructured.Unstructur
 		// Note: v1alpha contains a Name field under the SourceResult
 		// object, which we dont set here.
 		sourceStatus = append(sourceStatus, buildapialpha.SourceResult{
-			Name:      "default",
-			Git:       (*buildapialpha.GitSourceResult)(src.Status.Source.Git),
+			Name: "default",
+			Git: &buildapialpha.GitSourceResult{
+				CommitSha:    src.Status.Source.Git.CommitSha,
+				CommitAuthor: src.Status.Source.Git.CommitAuthor,
+				BranchName:   src.Status.Source.Git.BranchName,
+			},
 			Timestamp: src.Status.Source.Timestamp,
 		})
 	}
@@ -232,10 +236,17 @@ func (src *BuildRun) ConvertFrom(ctx context.Context, obj *unstructured.Unstruct
 	var sourceStatus *SourceResult
 	for _, s := range alphaBuildRun.Status.Sources {
 		sourceStatus = &SourceResult{
-			Git:         (*GitSourceResult)(s.Git),
 			OciArtifact: (*OciArtifactSourceResult)(s.Bundle),
 			Timestamp:   s.Timestamp,
 		}
+
+		if s.Git != nil {
+			sourceStatus.Git = &GitSourceResult{
+				CommitSha:    s.Git.CommitSha,
+				CommitAuthor: s.Git.CommitAuthor,
+				BranchName:   s.Git.BranchName,
+			}
+		}
 	}
 
 	conditions := []Condition{}
@@ -18,6 +18,30 @@ const (
 
 	// LabelBuildRunGeneration is a label key for BuildRuns to define the generation
 	LabelBuildRunGeneration = BuildRunDomain + "/generation"
+
+	// AnnotationNamespaceConcurrencyLimit is an annotation on a namespace that overrides the
+	// configured maximum number of concurrently executing BuildRuns in that namespace
+	AnnotationNamespaceConcurrencyLimit = BuildRunDomain + "/concurrency-limit"
+
+	// AnnotationBuildRunTrigger is an annotation on BuildRuns that were created by a trigger of
+	// their Build, it holds the name of the trigger
+	AnnotationBuildRunTrigger = BuildRunDomain + "/trigger"
+
+	// AnnotationBuildRunScheduledTime is an annotation on BuildRuns that were created by a Schedule
+	// trigger, it holds the scheduled time in RFC 3339 format
+	AnnotationBuildRunScheduledTime = BuildRunDomain + "/scheduled-time"
+
+	// AnnotationBuildRunTriggerImage is an annotation on BuildRuns that were created by an Image
+	// trigger, it holds the images with a changed digest in the format name@digest, separated by commas
+	AnnotationBuildRunTriggerImage = BuildRunDomain + "/trigger-image"
+
+	// AnnotationBuildRunTriggerObject is an annotation on BuildRuns that were created by a Pipeline or
+	// BuildRun trigger, it holds the completed object in the format kind/name
+	AnnotationBuildRunTriggerObject = BuildRunDomain + "/trigger-object"
+
+	// AnnotationBuildRunTriggerDelivery is an annotation on BuildRuns that were created by a Git
+	// webhook trigger, it holds the identifier of the webhook delivery
+	AnnotationBuildRunTriggerDelivery = BuildRunDomain + "/trigger-delivery"
 )
 
 // VulnerabilitySeverity is an enum for the possible values for severity of a vulnerability
@@ -79,6 +103,11 @@ type BuildRunSpec struct {
 	// +kubebuilder:validation:Format=duration
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// Timeouts overrides the phase timeouts of the Build
+	//
+	// +optional
+	Timeouts *PhaseTimeouts `json:"timeouts,omitempty"`
+
 	// Params is a list of key/value that could be used
 	// to set strategy parameters
 	//
@@ -138,6 +167,11 @@ type BuildRunSpec struct {
 	// RuntimeClassName specifies the RuntimeClass to be used to run the Pod
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// Retry overrides the retry policy of the Build
+	//
+	// +optional
+	Retry *Retry `json:"retry,omitempty"`
 }
 
 // BuildRunRequestedState defines the buildrun state the user can provide to override whatever is the current state.
@@ -162,6 +196,18 @@ const (
 
 	// BuildRunStateStepOutOfMemory indicates that a step failed because it went out of memory.
 	BuildRunStateStepOutOfMemory = "StepOutOfMemory"
+
+	// BuildRunStatePending indicates that the BuildRun waits for earlier BuildRuns of the same Build
+	// to finish because the Build forbids concurrent BuildRuns
+	BuildRunStatePending = "Pending"
+
+	// BuildRunStateQueued indicates that the BuildRun waits for a free slot because the maximum
+	// number of concurrently executing BuildRuns in its namespace is reached
+	BuildRunStateQueued = "Queued"
+
+	// BuildRunStateRetrying indicates that an attempt of the BuildRun failed and that the BuildRun
+	// waits for the backoff of its retry policy before the next attempt starts
+	BuildRunStateRetrying = "Retrying"
 )
 
 // SourceResult holds the results emitted from the different sources
@@ -179,6 +225,12 @@ type SourceResult struct {
 	// +optional
 	OciArtifact *OciArtifactSourceResult `json:"ociArtifact,omitempty"`
 
+	// HTTPArchive holds the results emitted from
+	// the source step of type HTTP
+	//
+	// +optional
+	HTTPArchive *HTTPArchiveSourceResult `json:"httpArchive,omitempty"`
+
 	// Timestamp holds the timestamp of the source, which
 	// depends on the actual source type and could range from
 	// being the commit timestamp or the fileystem timestamp
@@ -188,12 +240,26 @@ type SourceResult struct {
 	Timestamp *metav1.Time `json:"timestamp,omitempty"`
 }
 
+// AdditionalSourceResult holds the results emitted from the step of an additional source
+type AdditionalSourceResult struct {
+	// Name is the name of the additional source
+	Name string `json:"name"`
+
+	SourceResult `json:",inline"`
+}
+
 // OciArtifactSourceResult holds the results emitted from the bundle source
 type OciArtifactSourceResult struct {
 	// Digest hold the image digest result
 	Digest string `json:"digest,omitempty"`
 }
 
+// HTTPArchiveSourceResult holds the results emitted from the HTTP archive source
+type HTTPArchiveSourceResult struct {
+	// Digest holds the SHA-256 digest of the downloaded archive
+	Digest string `json:"digest,omitempty"`
+}
+
 // GitSourceResult holds the results emitted from the git source
 type GitSourceResult struct {
 	// CommitSha holds the commit sha of git source
@@ -202,11 +268,75 @@ type GitSourceResult struct {
 	// CommitAuthor holds the commit author of a git source
 	CommitAuthor string `json:"commitAuthor,omitempty"`
 
+	// CommitMessage holds the subject of the commit message of a git source
+	//
+	// +optional
+	CommitMessage string `json:"commitMessage,omitempty"`
+
+	// CommitTimestamp holds the committer timestamp of the commit of a git source
+	//
+	// +optional
+	CommitTimestamp *metav1.Time `json:"commitTimestamp,omitempty"`
+
+	// Tags holds the names of the tags that point to the commit of a git source
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Describe holds the version of the commit of a git source as described
+	// by `git describe --tags`, this will be set only when a tag is reachable
+	// from the commit
+	//
+	// +optional
+	Describe string `json:"describe,omitempty"`
+
 	// BranchName holds the default branch name of the git source
 	// this will be set only when revision is not specified in Build object
 	//
 	// +optional
 	BranchName string `json:"branchName,omitempty"`
+
+	// Signer holds the identity of the signer of the commit or tag, this
+	// will be set only when the signature verification is enabled
+	//
+	// +optional
+	Signer string `json:"signer,omitempty"`
+
+	// PullRequest holds the commits of the pull request, this will
+	// be set only when a pull request was built
+	//
+	// +optional
+	PullRequest *GitPullRequestResult `json:"pullRequest,omitempty"`
+
+	// LFS holds the details of the downloaded Git Large File Storage (LFS)
+	// files, this will be set only when LFS files were downloaded
+	//
+	// +optional
+	LFS *GitLFSResult `json:"lfs,omitempty"`
+}
+
+// GitLFSResult holds the details of the downloaded Git Large File Storage (LFS) files
+type GitLFSResult struct {
+	// Objects holds the number of downloaded LFS objects
+	Objects int64 `json:"objects,omitempty"`
+
+	// Size holds the total size in bytes of the downloaded LFS objects
+	Size int64 `json:"size,omitempty"`
+}
+
+// GitPullRequestResult holds the commits of a pull request that was built
+type GitPullRequestResult struct {
+	// HeadSha holds the commit sha of the head of the pull request
+	HeadSha string `json:"headSha,omitempty"`
+
+	// BaseSha holds the commit sha of the base branch
+	BaseSha string `json:"baseSha,omitempty"`
+
+	// MergeSha holds the commit sha of the merge of the pull request into the base
+	// branch, this will be set only when the pull request was merged
+	//
+	// +optional
+	MergeSha string `json:"mergeSha,omitempty"`
 }
 
 // Vulnerability defines a vulnerability by its ID and severity
@@ -227,10 +357,36 @@ type Output struct {
 	// +optional
 	Size int64 `json:"size,omitempty"`
 
+	// Tags holds the tags that the output image was pushed with
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
 	// Vulnerabilities holds the list of vulnerabilities detected in the image
 	//
 	// +optional
 	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+
+	// Platforms holds the image manifest digest of every platform in case
+	// a multi-platform image was built. In this case, the Digest field holds
+	// the digest of the image index.
+	//
+	// +optional
+	Platforms []OutputPlatform `json:"platforms,omitempty"`
+}
+
+// OutputPlatform holds the information about the image that the BuildRun built for one platform
+type OutputPlatform struct {
+	// OS is the operating system of the image platform
+	OS string `json:"os"`
+
+	// Arch is the CPU architecture of the image platform
+	Arch string `json:"arch"`
+
+	// Digest holds the digest of the image manifest of the platform
+	//
+	// +optional
+	Digest string `json:"digest,omitempty"`
 }
 
 // BuildRunStatus defines the observed state of BuildRun
@@ -240,6 +396,11 @@ type BuildRunStatus struct {
 	// +optional
 	Source *SourceResult `json:"source,omitempty"`
 
+	// AdditionalSources holds the results emitted from the steps of the additional sources
+	//
+	// +optional
+	AdditionalSources []AdditionalSourceResult `json:"additionalSources,omitempty"`
+
 	// Output holds the results emitted from step definition of an output
 	//
 	// +optional
@@ -274,6 +435,19 @@ type BuildRunStatus struct {
 	// FailureDetails contains error details that are collected and surfaced from TaskRun
 	// +optional
 	FailureDetails *FailureDetails `json:"failureDetails,omitempty"`
+
+	// Attempts holds the earlier attempts of a BuildRun that were retried because of
+	// the retry policy, the current attempt is described by the Executor
+	//
+	// +optional
+	Attempts []BuildRunAttempt `json:"attempts,omitempty"`
+
+	// StepResources holds the resources of the steps whose memory limit was increased by the
+	// memory escalation of the retry policy. Once the BuildRun succeeded, they hold the limits
+	// that are sufficient for the steps and can be used in the Build.
+	//
+	// +optional
+	StepResources []StepResourceOverride `json:"stepResources,omitempty"`
 }
 
 // Location describes the location where the failure happened
@@ -187,8 +187,58 @@ type Step struct {
 	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty" protobuf:"bytes,15,opt,name=securityContext"`
 }
 
+// BuildStrategyReason is a type used for populating the reason of the Ready condition of a build strategy
+type BuildStrategyReason string
+
+const (
+	// Ready specifies that the build strategy passed all validations and can be used by builds.
+	Ready Type = "Ready"
+
+	// BuildStrategySucceeded indicates that all validations of the build strategy succeeded
+	BuildStrategySucceeded BuildStrategyReason = "Succeeded"
+	// BuildStrategyUndefinedParameter indicates that a step references a parameter that is not defined in the build strategy
+	BuildStrategyUndefinedParameter BuildStrategyReason = "UndefinedParameter"
+	// BuildStrategyRestrictedParameter indicates that the build strategy defines a parameter with a system reserved name
+	BuildStrategyRestrictedParameter BuildStrategyReason = "RestrictedParametersInUse"
+	// BuildStrategyUndefinedVolume indicates that a step mounts a volume that is not defined in the build strategy
+	BuildStrategyUndefinedVolume BuildStrategyReason = "UndefinedVolume"
+	// BuildStrategyDuplicateStepName indicates that the build strategy contains multiple steps with the same name
+	BuildStrategyDuplicateStepName BuildStrategyReason = "DuplicateStepName"
+	// BuildStrategyArrayParameterInStringContext indicates that a step uses an array parameter where only a string is possible
+	BuildStrategyArrayParameterInStringContext BuildStrategyReason = "ArrayParameterInStringContext"
+
+	// AllStrategyValidationsSucceeded indicates a build strategy was successfully validated
+	AllStrategyValidationsSucceeded = "all validations succeeded"
+)
+
 // BuildStrategyStatus defines the observed state of BuildStrategy
 type BuildStrategyStatus struct {
+	// Conditions holds the latest available observations of the build strategy's current state.
+	//
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// GetCondition returns a condition based on a type from a list of Conditions
+func (bss *BuildStrategyStatus) GetCondition(t Type) *Condition {
+	for _, c := range bss.Conditions {
+		if c.Type == t {
+			return &c
+		}
+	}
+	return nil
+}
+
+// SetCondition updates a list of conditions with the provided condition
+func (bss *BuildStrategyStatus) SetCondition(condition *Condition) {
+	for i, c := range bss.Conditions {
+		if c.Type == condition.Type {
+			bss.Conditions[i] = *condition
+			return
+		}
+	}
+
+	bss.Conditions = append(bss.Conditions, *condition)
 }
 
 // BuildStrategyKind defines the type of BuildStrategy used by the build.
@@ -0,0 +1,49 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+// DefaultTrustedCAKey is the key of the ConfigMap with the bundle of trusted certificate
+// authorities, if the Build does not define one
+const DefaultTrustedCAKey = "ca-bundle.crt"
+
+// TrustedCA references a ConfigMap with a bundle of PEM encoded certificate authorities
+type TrustedCA struct {
+	// ConfigMap is the name of the ConfigMap in the namespace of the Build
+	ConfigMap string `json:"configMap"`
+
+	// Key is the key of the ConfigMap that contains the certificate authorities.
+	// If not defined, it defaults to ca-bundle.crt.
+	//
+	// +optional
+	Key *string `json:"key,omitempty"`
+}
+
+// GetKey returns the key of the ConfigMap that contains the certificate authorities
+func (t *TrustedCA) GetKey() string {
+	if t.Key != nil && *t.Key != "" {
+		return *t.Key
+	}
+
+	return DefaultTrustedCAKey
+}
+
+// Proxy describes the HTTP proxy that is used to access Git servers and container registries
+type Proxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests
+	//
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests
+	//
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma separated list of host names, domains and IP ranges
+	// that are accessed without the proxy
+	//
+	// +optional
+	NoProxy *string `json:"noProxy,omitempty"`
+}
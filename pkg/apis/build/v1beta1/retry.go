@@ -0,0 +1,76 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Retry defines how often a BuildRun is attempted again when it fails for one of the listed reasons
+type Retry struct {
+	// MaxAttempts is the maximum number of attempts of a BuildRun, including the first one
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int `json:"maxAttempts"`
+
+	// Backoff is the time to wait before the second attempt, it is doubled for every
+	// further attempt. Without a backoff, a failed attempt is retried immediately.
+	//
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// Reasons are the failure reasons that are retried. They are compared with the reason
+	// of the failure details and the reason of the Succeeded condition of the BuildRun,
+	// for example PodEvicted.
+	//
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// MemoryEscalation retries a BuildRun whose step went out of memory with an increased
+	// memory limit for that step.
+	//
+	// +optional
+	MemoryEscalation *MemoryEscalation `json:"memoryEscalation,omitempty"`
+}
+
+// MemoryEscalation defines how the memory limit of a step that went out of memory is increased
+// for the next attempt
+type MemoryEscalation struct {
+	// Factor is the factor by which the memory limit of the step is multiplied, for example "1.5".
+	// Defaults to "2".
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Factor string `json:"factor,omitempty"`
+
+	// MaxLimit is the memory limit that the escalation does not exceed
+	MaxLimit resource.Quantity `json:"maxLimit"`
+}
+
+// BuildRunAttempt describes a failed attempt of a BuildRun that was retried
+type BuildRunAttempt struct {
+	// Executor is the name and kind of the resource that executed the attempt
+	Executor BuildExecutor `json:"executor"`
+
+	// Reason is the reason of the Succeeded condition of the failed attempt
+	Reason string `json:"reason"`
+
+	// Message is the message of the Succeeded condition of the failed attempt
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// FailureDetails contains the error details of the failed attempt
+	//
+	// +optional
+	FailureDetails *FailureDetails `json:"failureDetails,omitempty"`
+
+	// CompletionTime is the time the attempt failed
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
@@ -4,7 +4,11 @@
 
 package v1beta1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // PruneOption defines the supported options for image pruning
 type PruneOption string
@@ -23,6 +27,10 @@ const GitType BuildSourceType = "Git"
 // OCIArtifactType represents a build whose source code is in a "scratch" container image, also known as an OCI artifact.
 const OCIArtifactType BuildSourceType = "OCI"
 
+// HTTPType represents a build whose source code is an archive, for example a tarball or a zip file,
+// that is downloaded from an HTTP or HTTPS server.
+const HTTPType BuildSourceType = "HTTP"
+
 const (
 	// Do not delete image after it was pulled
 	PruneNever PruneOption = "Never"
@@ -70,6 +78,123 @@ type Git struct {
 	//
 	// +optional
 	Depth *int `json:"depth,omitempty"`
+
+	// SparseCheckout restricts the files that are checked out of the repository to
+	// some directories, for example the directory of the build in a large monorepo.
+	//
+	// +optional
+	SparseCheckout *SparseCheckout `json:"sparseCheckout,omitempty"`
+
+	// SignatureVerificationSecret references a Secret that contains the trusted keys to
+	// verify the signature of the checked out commit, or tag if the revision is a signed tag.
+	// The Secret contains GPG public keys in the key `gpg-public-keys`, or an SSH allowed
+	// signers file in the key `allowed_signers`, or both. The source step fails if the
+	// commit or tag is not signed with one of the trusted keys.
+	//
+	// +optional
+	SignatureVerificationSecret *string `json:"signatureVerificationSecret,omitempty"`
+
+	// PullRequest builds a pull request, also known as merge request, of the repository instead
+	// of the revision. The revision is then the base branch of the pull request.
+	//
+	// +optional
+	PullRequest *PullRequest `json:"pullRequest,omitempty"`
+
+	// LFS controls which files of the Git Large File Storage (LFS) of the repository are
+	// downloaded. If not defined, all LFS files are downloaded.
+	//
+	// +optional
+	LFS *LFS `json:"lfs,omitempty"`
+
+	// FetchTags specifies whether the tags that point to the checked out commit, and the version
+	// of the commit as described by git describe --tags, are reported in the status of the BuildRun.
+	// This lists the tags of the repository with an additional request to the Git server. It is
+	// enabled automatically if a tag of the output image uses the $(source.git.describe) placeholder.
+	//
+	// +optional
+	FetchTags bool `json:"fetchTags,omitempty"`
+}
+
+// LFS describes how the files of the Git Large File Storage (LFS) of a repository are downloaded.
+type LFS struct {
+	// Enabled specifies whether the LFS files are downloaded. If disabled, the LFS pointer
+	// files are checked out instead of the file contents. If not defined, it defaults to true.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Include is a list of patterns of the LFS files that are downloaded, the patterns use
+	// the same format as the lfs.fetchinclude Git configuration. If not defined, all LFS files
+	// are downloaded.
+	//
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude is a list of patterns of the LFS files that are not downloaded, the patterns use
+	// the same format as the lfs.fetchexclude Git configuration.
+	//
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+
+	// SkipSmudge downloads the LFS files in a single batch after the checkout, instead of one
+	// by one while the files are checked out. This is faster for repositories with many LFS files.
+	//
+	// +optional
+	SkipSmudge bool `json:"skipSmudge,omitempty"`
+}
+
+// IsEnabled returns whether the LFS files are downloaded
+func (l *LFS) IsEnabled() bool {
+	return l == nil || l.Enabled == nil || *l.Enabled
+}
+
+// PullRequest describes a pull request of a Git repository. The head of the pull request is
+// fetched from the ref that the Git hosting service provides for it.
+type PullRequest struct {
+	// Number is the number of the pull request.
+	//
+	// +kubebuilder:validation:Minimum=1
+	Number int `json:"number"`
+
+	// Ref is the ref of the head of the pull request. If not defined, it defaults
+	// to refs/pull/<number>/head, which is used by GitHub, Gitea and Forgejo. For
+	// a merge request on GitLab, use refs/merge-requests/<number>/head.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^refs/[^\s~^:?*\[\\]+$`
+	Ref *string `json:"ref,omitempty"`
+
+	// Merge specifies whether the head of the pull request is merged into the base branch,
+	// which is the revision or the default branch of the repository. The build then uses the
+	// result of the merge, and fails if the pull request has merge conflicts. If not defined,
+	// the head of the pull request is built as-is.
+	//
+	// +optional
+	Merge bool `json:"merge,omitempty"`
+}
+
+// HeadRef returns the ref of the head of the pull request
+func (p *PullRequest) HeadRef() string {
+	if p.Ref != nil && *p.Ref != "" {
+		return *p.Ref
+	}
+
+	return fmt.Sprintf("refs/pull/%d/head", p.Number)
+}
+
+// SparseCheckout describes the directories of a Git repository that are checked out. The
+// directories are checked out in cone mode, which also includes the files in their parent
+// directories, and only the file contents of these directories are downloaded.
+type SparseCheckout struct {
+	// Paths is a list of directories, relative to the root of the repository, that are checked out.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// FromContextDir adds the context directory of the source to the directories that are checked out.
+	//
+	// +optional
+	FromContextDir bool `json:"fromContextDir,omitempty"`
 }
 
 // OCIArtifact describes how to obtain source code from a container image, also known as an OCI
@@ -95,10 +220,39 @@ type OCIArtifact struct {
 	PullSecret *string `json:"pullSecret,omitempty"`
 }
 
+// HTTPArchive describes how to obtain source code from an archive on an HTTP or HTTPS server.
+// Supported archive formats are tar, gzip compressed tar, and zip.
+type HTTPArchive struct {
+	// URL is the location of the archive, for example https://example.com/sources/app-1.0.tar.gz
+	URL string `json:"url"`
+
+	// SHA256 is the hex encoded SHA-256 checksum of the archive. If defined, the download
+	// fails when the checksum of the downloaded archive is different.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	SHA256 *string `json:"sha256,omitempty"`
+
+	// AuthSecret references a Secret that contains credentials to download the archive,
+	// either a username and password for basic authentication, or a token for bearer
+	// authentication.
+	//
+	// +optional
+	AuthSecret *string `json:"authSecret,omitempty"`
+
+	// StripComponents is the number of leading path elements that are removed from the file
+	// names in the archive, for example 1 for archives that contain a single top-level
+	// directory. If not defined, it defaults to 0.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StripComponents *int `json:"stripComponents,omitempty"`
+}
+
 // Source describes the source code to fetch for the build.
 type Source struct {
 	// Type is the type of source code used as input for the build. Allowed values are
-	// `Git`, `OCI`, and `Local`.
+	// `Git`, `OCI`, `HTTP`, and `Local`.
 	Type BuildSourceType `json:"type"`
 
 	// ContextDir is a path to a subdirectory within the source code that should be used as the
@@ -113,6 +267,12 @@ type Source struct {
 	// +optional
 	OCIArtifact *OCIArtifact `json:"ociArtifact,omitempty"`
 
+	// HTTPArchive contains the details for obtaining source code from an archive on an HTTP or
+	// HTTPS server.
+	//
+	// +optional
+	HTTPArchive *HTTPArchive `json:"httpArchive,omitempty"`
+
 	// Git contains the details for obtaining source code from a git repository.
 	//
 	// +optional
@@ -125,11 +285,56 @@ type Source struct {
 	Local *Local `json:"local,omitempty"`
 }
 
+// AdditionalSource describes source code that is fetched in addition to the source of the
+// Build into a subdirectory of the source root, for example shared build scripts.
+type AdditionalSource struct {
+	// Name is the name of the additional source, it must be unique within the Build.
+	//
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Type is the type of the additional source. Allowed values are `Git`, and `OCI`.
+	//
+	// +kubebuilder:validation:Enum=Git;OCI
+	Type BuildSourceType `json:"type"`
+
+	// TargetDir is the path of the subdirectory of the source root into which the source
+	// code is fetched.
+	TargetDir string `json:"targetDir"`
+
+	// OCIArtifact contains the details for obtaining source code from a container image, also
+	// known as an OCI artifact.
+	//
+	// +optional
+	OCIArtifact *OCIArtifact `json:"ociArtifact,omitempty"`
+
+	// Git contains the details for obtaining source code from a git repository.
+	//
+	// +optional
+	Git *Git `json:"git,omitempty"`
+}
+
+// GetCredentials returns the secret name of an additional source
+func (s AdditionalSource) GetCredentials() *string {
+	switch s.Type {
+	case OCIArtifactType:
+		if s.OCIArtifact != nil {
+			return s.OCIArtifact.PullSecret
+		}
+	case GitType:
+		if s.Git != nil {
+			return s.Git.CloneSecret
+		}
+	}
+	return nil
+}
+
 // BuildRunSource describes the source to use in a BuildRun, overriding the value of the parent
 // Build object.
 type BuildRunSource struct {
 	// Type is the BuildRunSource qualifier, the type of the source.
-	// Only `Local` is supported.
+	// Allowed values are `Local`, and `Git` to override the Git source of the Build.
 	//
 	Type BuildSourceType `json:"type"`
 
@@ -137,4 +342,23 @@ type BuildRunSource struct {
 	//
 	// +optional
 	Local *Local `json:"local,omitempty"`
+
+	// Git contains the details of the Git source of the Build that are overridden
+	//
+	// +optional
+	Git *BuildRunGitSource `json:"git,omitempty"`
+}
+
+// BuildRunGitSource describes the details of the Git source of the parent Build that a BuildRun overrides.
+type BuildRunGitSource struct {
+	// Revision describes the Git revision (e.g., branch, tag, commit SHA,
+	// etc.) to fetch instead of the revision of the Build.
+	//
+	// +optional
+	Revision *string `json:"revision,omitempty"`
+
+	// PullRequest describes the pull request to build instead of the pull request of the Build.
+	//
+	// +optional
+	PullRequest *PullRequest `json:"pullRequest,omitempty"`
 }
@@ -0,0 +1,45 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SourcePhaseTimeout is the failure reason of a BuildRun whose source acquisition
+	// did not finish within the source timeout
+	SourcePhaseTimeout = "SourcePhaseTimeout"
+
+	// BuildPhaseTimeout is the failure reason of a BuildRun whose build strategy steps
+	// did not finish within the build timeout
+	BuildPhaseTimeout = "BuildPhaseTimeout"
+
+	// OutputPhaseTimeout is the failure reason of a BuildRun whose output image processing
+	// did not finish within the output timeout
+	OutputPhaseTimeout = "OutputPhaseTimeout"
+)
+
+// PhaseTimeouts defines the maximum amount of time of the individual phases of a BuildRun,
+// in addition to the timeout of the whole BuildRun
+type PhaseTimeouts struct {
+	// Source defines the maximum amount of time to acquire the source code
+	//
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Source *metav1.Duration `json:"source,omitempty"`
+
+	// Build defines the maximum amount of time of the build strategy steps
+	//
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Build *metav1.Duration `json:"build,omitempty"`
+
+	// Output defines the maximum amount of time to process and push the output image
+	//
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Output *metav1.Duration `json:"output,omitempty"`
+}
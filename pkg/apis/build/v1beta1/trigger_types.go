@@ -11,11 +11,26 @@ const (
 	// GitHubWebHookTrigger GitHubWebHookTrigger trigger type name.
 	GitHubWebHookTrigger TriggerType = "GitHub"
 
+	// GitLabWebHookTrigger GitLab webhook trigger type name.
+	GitLabWebHookTrigger TriggerType = "GitLab"
+
+	// GiteaWebHookTrigger Gitea webhook trigger type name.
+	GiteaWebHookTrigger TriggerType = "Gitea"
+
+	// BitbucketWebHookTrigger Bitbucket Cloud webhook trigger type name.
+	BitbucketWebHookTrigger TriggerType = "Bitbucket"
+
 	// ImageTrigger Image trigger type name.
 	ImageTrigger TriggerType = "Image"
 
 	// PipelineTrigger Tekton Pipeline trigger type name.
 	PipelineTrigger TriggerType = "Pipeline"
+
+	// ScheduleTrigger cron schedule trigger type name.
+	ScheduleTrigger TriggerType = "Schedule"
+
+	// BuildRunTrigger Shipwright BuildRun trigger type name.
+	BuildRunTrigger TriggerType = "BuildRun"
 )
 
 // GitHubEventName set of WhenGitHub valid event names.
@@ -29,12 +44,65 @@ const (
 	GitHubPushEvent GitHubEventName = "Push"
 )
 
+// GitLabEventName set of WhenGitLab valid event names.
+type GitLabEventName string
+
+const (
+	// GitLabPushEvent GitLab push event name.
+	GitLabPushEvent GitLabEventName = "Push"
+
+	// GitLabMergeRequestEvent GitLab merge request event name.
+	GitLabMergeRequestEvent GitLabEventName = "MergeRequest"
+
+	// GitLabTagEvent GitLab tag push event name.
+	GitLabTagEvent GitLabEventName = "Tag"
+)
+
+// GiteaEventName set of WhenGitea valid event names.
+type GiteaEventName string
+
+const (
+	// GiteaPushEvent Gitea push event name.
+	GiteaPushEvent GiteaEventName = "Push"
+
+	// GiteaPullRequestEvent Gitea pull request event name.
+	GiteaPullRequestEvent GiteaEventName = "PullRequest"
+
+	// GiteaTagEvent Gitea tag push event name.
+	GiteaTagEvent GiteaEventName = "Tag"
+)
+
+// BitbucketEventName set of WhenBitbucket valid event names.
+type BitbucketEventName string
+
+const (
+	// BitbucketPushEvent Bitbucket push event name.
+	BitbucketPushEvent BitbucketEventName = "Push"
+
+	// BitbucketPullRequestEvent Bitbucket pull request event name.
+	BitbucketPullRequestEvent BitbucketEventName = "PullRequest"
+
+	// BitbucketTagEvent Bitbucket tag push event name.
+	BitbucketTagEvent BitbucketEventName = "Tag"
+)
+
 // WhenImage attributes to match Image events.
 type WhenImage struct {
 	// Names fully qualified image names.
 	//
 	// +optional
 	Names []string `json:"names,omitempty"`
+
+	// PullSecret name of the secret of type kubernetes.io/dockerconfigjson with the credentials
+	// to look up the images in their container registry.
+	//
+	// +optional
+	PullSecret *string `json:"pullSecret,omitempty"`
+
+	// Insecure defines whether the container registry of the images is not secure.
+	//
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
 }
 
 // WhenGitHub attributes to match GitHub events.
@@ -50,14 +118,76 @@ type WhenGitHub struct {
 	Branches []string `json:"branches,omitempty"`
 }
 
+// WhenGitLab attributes to match GitLab events.
+type WhenGitLab struct {
+	// Events GitLab event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []GitLabEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the push and merge request events apply.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+}
+
+// WhenGitea attributes to match Gitea events.
+type WhenGitea struct {
+	// Events Gitea event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []GiteaEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the push and pull request events apply.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+}
+
+// WhenBitbucket attributes to match Bitbucket Cloud events.
+type WhenBitbucket struct {
+	// Events Bitbucket event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []BitbucketEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the push and pull request events apply.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+}
+
+// WhenSchedule attributes to create BuildRuns on a cron schedule.
+type WhenSchedule struct {
+	// Cron schedule in the standard cron format, for example "0 2 * * *" for every night at two
+	// o'clock. The descriptors like "@daily" are supported as well.
+	Cron string `json:"cron"`
+
+	// TimeZone name of the time zone in which the cron schedule is interpreted, for example
+	// "Europe/Berlin". Defaults to UTC.
+	//
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// StartingDeadlineSeconds deadline in seconds for starting a BuildRun after its scheduled time.
+	// A run that could not be started within the deadline, for example because the controller was
+	// not running, is skipped. Without a deadline, only the most recent missed run is started.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+}
+
 // WhenObjectRef attributes to reference local Kubernetes objects.
 type WhenObjectRef struct {
-	// Name target object name.
+	// Name target object name. For the Pipeline type, this is the name of the Pipeline that a
+	// PipelineRun references, and for the BuildRun type the name of the Build of a BuildRun.
 	//
 	// +optional
 	Name string `json:"name,omitempty"`
 
-	// Status object status.
+	// Status object status, matched against the reason of the Succeeded condition of a completed
+	// object, for example Succeeded or Failed.
 	Status []string `json:"status,omitempty"`
 
 	// Selector label selector.
@@ -17,6 +17,21 @@ type TriggerWhen struct {
 	// +optional
 	GitHub *WhenGitHub `json:"github,omitempty"`
 
+	// GitLab describes how to trigger builds based on GitLab (SCM) events.
+	//
+	// +optional
+	GitLab *WhenGitLab `json:"gitlab,omitempty"`
+
+	// Gitea describes how to trigger builds based on Gitea (SCM) events.
+	//
+	// +optional
+	Gitea *WhenGitea `json:"gitea,omitempty"`
+
+	// Bitbucket describes how to trigger builds based on Bitbucket Cloud (SCM) events.
+	//
+	// +optional
+	Bitbucket *WhenBitbucket `json:"bitbucket,omitempty"`
+
 	// Image slice of image names where the event applies.
 	//
 	// +optional
@@ -27,6 +42,11 @@ type TriggerWhen struct {
 	//
 	// +optional
 	ObjectRef *WhenObjectRef `json:"objectRef,omitempty"`
+
+	// Schedule describes how to trigger builds on a cron schedule.
+	//
+	// +optional
+	Schedule *WhenSchedule `json:"schedule,omitempty"`
 }
 
 // GetBranches return a slice of branch names based on the WhenTypeName informed.
@@ -37,6 +57,21 @@ func (w *TriggerWhen) GetBranches(whenType TriggerType) []string {
 			return nil
 		}
 		return w.GitHub.Branches
+	case GitLabWebHookTrigger:
+		if w.GitLab == nil {
+			return nil
+		}
+		return w.GitLab.Branches
+	case GiteaWebHookTrigger:
+		if w.Gitea == nil {
+			return nil
+		}
+		return w.Gitea.Branches
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket == nil {
+			return nil
+		}
+		return w.Bitbucket.Branches
 	}
 	return nil
 }
@@ -14,6 +14,47 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalSource) DeepCopyInto(out *AdditionalSource) {
+	*out = *in
+	if in.OCIArtifact != nil {
+		in, out := &in.OCIArtifact, &out.OCIArtifact
+		*out = new(OCIArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(Git)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalSource.
+func (in *AdditionalSource) DeepCopy() *AdditionalSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalSourceResult) DeepCopyInto(out *AdditionalSourceResult) {
+	*out = *in
+	in.SourceResult.DeepCopyInto(&out.SourceResult)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalSourceResult.
+func (in *AdditionalSourceResult) DeepCopy() *AdditionalSourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalSourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Build) DeepCopyInto(out *Build) {
 	*out = *in
@@ -155,6 +196,56 @@ func (in *BuildRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRunAttempt) DeepCopyInto(out *BuildRunAttempt) {
+	*out = *in
+	out.Executor = in.Executor
+	if in.FailureDetails != nil {
+		in, out := &in.FailureDetails, &out.FailureDetails
+		*out = new(FailureDetails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRunAttempt.
+func (in *BuildRunAttempt) DeepCopy() *BuildRunAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRunAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRunGitSource) DeepCopyInto(out *BuildRunGitSource) {
+	*out = *in
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(string)
+		**out = **in
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequest)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRunGitSource.
+func (in *BuildRunGitSource) DeepCopy() *BuildRunGitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRunGitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildRunList) DeepCopyInto(out *BuildRunList) {
 	*out = *in
@@ -220,6 +311,11 @@ func (in *BuildRunSource) DeepCopyInto(out *BuildRunSource) {
 		*out = new(Local)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(BuildRunGitSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRunSource.
@@ -251,6 +347,11 @@ func (in *BuildRunSpec) DeepCopyInto(out *BuildRunSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(PhaseTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ParamValues != nil {
 		in, out := &in.ParamValues, &out.ParamValues
 		*out = make([]ParamValue, len(*in))
@@ -318,6 +419,11 @@ func (in *BuildRunSpec) DeepCopyInto(out *BuildRunSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(Retry)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRunSpec.
@@ -338,6 +444,13 @@ func (in *BuildRunStatus) DeepCopyInto(out *BuildRunStatus) {
 		*out = new(SourceResult)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalSources != nil {
+		in, out := &in.AdditionalSources, &out.AdditionalSources
+		*out = make([]AdditionalSourceResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Output != nil {
 		in, out := &in.Output, &out.Output
 		*out = new(Output)
@@ -378,6 +491,20 @@ func (in *BuildRunStatus) DeepCopyInto(out *BuildRunStatus) {
 		*out = new(FailureDetails)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]BuildRunAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StepResources != nil {
+		in, out := &in.StepResources, &out.StepResources
+		*out = make([]StepResourceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRunStatus.
@@ -398,6 +525,13 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		*out = new(Source)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalSources != nil {
+		in, out := &in.AdditionalSources, &out.AdditionalSources
+		*out = make([]AdditionalSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Trigger != nil {
 		in, out := &in.Trigger, &out.Trigger
 		*out = new(Trigger)
@@ -417,6 +551,11 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(PhaseTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]corev1.EnvVar, len(*in))
@@ -460,6 +599,21 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(Retry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedCA != nil {
+		in, out := &in.TrustedCA, &out.TrustedCA
+		*out = new(TrustedCA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSpec.
@@ -490,6 +644,13 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]TriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildStatus.
@@ -508,7 +669,7 @@ func (in *BuildStrategy) DeepCopyInto(out *BuildStrategy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildStrategy.
@@ -620,6 +781,13 @@ func (in *BuildStrategySpec) DeepCopy() *BuildStrategySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildStrategyStatus) DeepCopyInto(out *BuildStrategyStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildStrategyStatus.
@@ -680,7 +848,7 @@ func (in *ClusterBuildStrategy) DeepCopyInto(out *ClusterBuildStrategy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBuildStrategy.
@@ -808,6 +976,26 @@ func (in *Git) DeepCopyInto(out *Git) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = new(SparseCheckout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignatureVerificationSecret != nil {
+		in, out := &in.SignatureVerificationSecret, &out.SignatureVerificationSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LFS != nil {
+		in, out := &in.LFS, &out.LFS
+		*out = new(LFS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Git.
@@ -820,9 +1008,58 @@ func (in *Git) DeepCopy() *Git {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLFSResult) DeepCopyInto(out *GitLFSResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLFSResult.
+func (in *GitLFSResult) DeepCopy() *GitLFSResult {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLFSResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPullRequestResult) DeepCopyInto(out *GitPullRequestResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPullRequestResult.
+func (in *GitPullRequestResult) DeepCopy() *GitPullRequestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPullRequestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitSourceResult) DeepCopyInto(out *GitSourceResult) {
 	*out = *in
+	if in.CommitTimestamp != nil {
+		in, out := &in.CommitTimestamp, &out.CommitTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(GitPullRequestResult)
+		**out = **in
+	}
+	if in.LFS != nil {
+		in, out := &in.LFS, &out.LFS
+		*out = new(GitLFSResult)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSourceResult.
@@ -836,16 +1073,46 @@ func (in *GitSourceResult) DeepCopy() *GitSourceResult {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePlatform) DeepCopyInto(out *ImagePlatform) {
+func (in *HTTPArchive) DeepCopyInto(out *HTTPArchive) {
 	*out = *in
+	if in.SHA256 != nil {
+		in, out := &in.SHA256, &out.SHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthSecret != nil {
+		in, out := &in.AuthSecret, &out.AuthSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.StripComponents != nil {
+		in, out := &in.StripComponents, &out.StripComponents
+		*out = new(int)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePlatform.
-func (in *ImagePlatform) DeepCopy() *ImagePlatform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPArchive.
+func (in *HTTPArchive) DeepCopy() *HTTPArchive {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePlatform)
+	out := new(HTTPArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPArchiveSourceResult) DeepCopyInto(out *HTTPArchiveSourceResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPArchiveSourceResult.
+func (in *HTTPArchiveSourceResult) DeepCopy() *HTTPArchiveSourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPArchiveSourceResult)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -853,6 +1120,11 @@ func (in *ImagePlatform) DeepCopy() *ImagePlatform {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Insecure != nil {
 		in, out := &in.Insecure, &out.Insecure
 		*out = new(bool)
@@ -904,6 +1176,51 @@ func (in *Image) DeepCopy() *Image {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePlatform) DeepCopyInto(out *ImagePlatform) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePlatform.
+func (in *ImagePlatform) DeepCopy() *ImagePlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LFS) DeepCopyInto(out *LFS) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LFS.
+func (in *LFS) DeepCopy() *LFS {
+	if in == nil {
+		return nil
+	}
+	out := new(LFS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Local) DeepCopyInto(out *Local) {
 	*out = *in
@@ -939,6 +1256,22 @@ func (in *Location) DeepCopy() *Location {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryEscalation) DeepCopyInto(out *MemoryEscalation) {
+	*out = *in
+	out.MaxLimit = in.MaxLimit.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryEscalation.
+func (in *MemoryEscalation) DeepCopy() *MemoryEscalation {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryEscalation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
 	*out = *in
@@ -1002,11 +1335,21 @@ func (in *OciArtifactSourceResult) DeepCopy() *OciArtifactSourceResult {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Output) DeepCopyInto(out *Output) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Vulnerabilities != nil {
 		in, out := &in.Vulnerabilities, &out.Vulnerabilities
 		*out = make([]Vulnerability, len(*in))
 		copy(*out, *in)
 	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]OutputPlatform, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Output.
@@ -1019,6 +1362,21 @@ func (in *Output) DeepCopy() *Output {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputPlatform) DeepCopyInto(out *OutputPlatform) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputPlatform.
+func (in *OutputPlatform) DeepCopy() *OutputPlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputPlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParamValue) DeepCopyInto(out *ParamValue) {
 	*out = *in
@@ -1075,6 +1433,86 @@ func (in *Parameter) DeepCopy() *Parameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseTimeouts) DeepCopyInto(out *PhaseTimeouts) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseTimeouts.
+func (in *PhaseTimeouts) DeepCopy() *PhaseTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Proxy) DeepCopyInto(out *Proxy) {
+	*out = *in
+	if in.HTTPProxy != nil {
+		in, out := &in.HTTPProxy, &out.HTTPProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSProxy != nil {
+		in, out := &in.HTTPSProxy, &out.HTTPSProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Proxy.
+func (in *Proxy) DeepCopy() *Proxy {
+	if in == nil {
+		return nil
+	}
+	out := new(Proxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequest) DeepCopyInto(out *PullRequest) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequest.
+func (in *PullRequest) DeepCopy() *PullRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReferencedBuild) DeepCopyInto(out *ReferencedBuild) {
 	*out = *in
@@ -1100,6 +1538,36 @@ func (in *ReferencedBuild) DeepCopy() *ReferencedBuild {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Retry) DeepCopyInto(out *Retry) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemoryEscalation != nil {
+		in, out := &in.MemoryEscalation, &out.MemoryEscalation
+		*out = new(MemoryEscalation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
+func (in *Retry) DeepCopy() *Retry {
+	if in == nil {
+		return nil
+	}
+	out := new(Retry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleValue) DeepCopyInto(out *SingleValue) {
 	*out = *in
@@ -1143,6 +1611,11 @@ func (in *Source) DeepCopyInto(out *Source) {
 		*out = new(OCIArtifact)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTPArchive != nil {
+		in, out := &in.HTTPArchive, &out.HTTPArchive
+		*out = new(HTTPArchive)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(Git)
@@ -1171,13 +1644,18 @@ func (in *SourceResult) DeepCopyInto(out *SourceResult) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(GitSourceResult)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.OciArtifact != nil {
 		in, out := &in.OciArtifact, &out.OciArtifact
 		*out = new(OciArtifactSourceResult)
 		**out = **in
 	}
+	if in.HTTPArchive != nil {
+		in, out := &in.HTTPArchive, &out.HTTPArchive
+		*out = new(HTTPArchiveSourceResult)
+		**out = **in
+	}
 	if in.Timestamp != nil {
 		in, out := &in.Timestamp, &out.Timestamp
 		*out = (*in).DeepCopy()
@@ -1194,6 +1672,26 @@ func (in *SourceResult) DeepCopy() *SourceResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SparseCheckout) DeepCopyInto(out *SparseCheckout) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SparseCheckout.
+func (in *SparseCheckout) DeepCopy() *SparseCheckout {
+	if in == nil {
+		return nil
+	}
+	out := new(SparseCheckout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Step) DeepCopyInto(out *Step) {
 	*out = *in
@@ -1309,6 +1807,45 @@ func (in *Trigger) DeepCopy() *Trigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerImageStatus) DeepCopyInto(out *TriggerImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerImageStatus.
+func (in *TriggerImageStatus) DeepCopy() *TriggerImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]TriggerImageStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerStatus.
+func (in *TriggerStatus) DeepCopy() *TriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 	*out = *in
@@ -1317,6 +1854,21 @@ func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 		*out = new(WhenGitHub)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(WhenGitLab)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(WhenGitea)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bitbucket != nil {
+		in, out := &in.Bitbucket, &out.Bitbucket
+		*out = new(WhenBitbucket)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(WhenImage)
@@ -1327,6 +1879,11 @@ func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 		*out = new(WhenObjectRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(WhenSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerWhen.
@@ -1339,6 +1896,26 @@ func (in *TriggerWhen) DeepCopy() *TriggerWhen {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedCA) DeepCopyInto(out *TrustedCA) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedCA.
+func (in *TrustedCA) DeepCopy() *TrustedCA {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
 	*out = *in
@@ -1404,6 +1981,31 @@ func (in *VulnerabilityScanOptions) DeepCopy() *VulnerabilityScanOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenBitbucket) DeepCopyInto(out *WhenBitbucket) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]BitbucketEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenBitbucket.
+func (in *WhenBitbucket) DeepCopy() *WhenBitbucket {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenBitbucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenGitHub) DeepCopyInto(out *WhenGitHub) {
 	*out = *in
@@ -1429,6 +2031,56 @@ func (in *WhenGitHub) DeepCopy() *WhenGitHub {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitLab) DeepCopyInto(out *WhenGitLab) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GitLabEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitLab.
+func (in *WhenGitLab) DeepCopy() *WhenGitLab {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitLab)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitea) DeepCopyInto(out *WhenGitea) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GiteaEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitea.
+func (in *WhenGitea) DeepCopy() *WhenGitea {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitea)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 	*out = *in
@@ -1437,6 +2089,16 @@ func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullSecret != nil {
+		in, out := &in.PullSecret, &out.PullSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenImage.
@@ -1475,3 +2137,28 @@ func (in *WhenObjectRef) DeepCopy() *WhenObjectRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenSchedule) DeepCopyInto(out *WhenSchedule) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenSchedule.
+func (in *WhenSchedule) DeepCopy() *WhenSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,370 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+	tarMagic  = []byte("ustar")
+)
+
+// tarMagicOffset is the offset of the magic field in the header of a tar file
+const tarMagicOffset = 257
+
+// ExtractDetails contains details about the extracted files of an archive
+type ExtractDetails struct {
+	MostRecentFileTimestamp *time.Time
+}
+
+// extractor writes the entries of an archive into the target directory
+type extractor struct {
+	targetPath      string
+	stripComponents int
+	details         ExtractDetails
+}
+
+// Extract extracts the archive file into the target directory. The format of the archive is
+// detected from its content, supported are tar, gzip compressed tar, and zip. The given number
+// of leading path elements is removed from the names of the files in the archive, files with
+// fewer path elements are skipped.
+func Extract(file string, targetPath string, stripComponents int) (*ExtractDetails, error) {
+	if stripComponents < 0 {
+		return nil, fmt.Errorf("the number of path elements to strip must not be negative")
+	}
+
+	// Make sure the target path exists and is a directory
+	if stat, err := os.Stat(targetPath); err != nil {
+		if err := os.MkdirAll(targetPath, os.FileMode(0755)); err != nil {
+			return nil, err
+		}
+	} else if !stat.IsDir() {
+		return nil, fmt.Errorf("target %q exists, but it's not a directory", targetPath)
+	}
+
+	absoluteTargetPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Symbolic links are resolved before the entries are written, resolve the target path the same way
+	absoluteTargetPath, err = filepath.EvalSymlinks(absoluteTargetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &extractor{
+		targetPath:      absoluteTargetPath,
+		stripComponents: stripComponents,
+	}
+
+	// #nosec G304 the file is the archive that was downloaded before
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	header, err := reader.Peek(tarMagicOffset + len(tarMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+
+		if err := e.extractTar(gzipReader); err != nil {
+			return nil, err
+		}
+
+	case bytes.HasPrefix(header, zipMagic):
+		if err := e.extractZip(file); err != nil {
+			return nil, err
+		}
+
+	case len(header) >= tarMagicOffset+len(tarMagic) && bytes.Equal(header[tarMagicOffset:], tarMagic):
+		if err := e.extractTar(reader); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported archive format, only tar, gzip compressed tar, and zip archives are supported")
+	}
+
+	return &e.details, nil
+}
+
+func (e *extractor) extractTar(in io.Reader) error {
+	tr := tar.NewReader(in)
+	for {
+		header, err := tr.Next()
+		switch {
+		case err == io.EOF:
+			return nil
+
+		case err != nil:
+			return err
+
+		case header == nil:
+			continue
+		}
+
+		// the global header of a pax archive, for example the one of archives created by git archive
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		target, ok, err := e.target(header.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if _, err := e.directory(target); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			if err := e.writeFile(target, tr, header.FileInfo().Mode(), header.ModTime); err != nil {
+				return err
+			}
+
+		case tar.TypeSymlink:
+			if err := e.symlink(target, header.Linkname); err != nil {
+				return err
+			}
+
+		case tar.TypeLink:
+			linkTarget, ok, err := e.target(header.Linkname)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("the hard link %q points to the stripped path %q", header.Name, header.Linkname)
+			}
+
+			linkTargetDir, err := filepath.EvalSymlinks(filepath.Dir(linkTarget))
+			if err != nil {
+				return err
+			}
+			if !e.contains(linkTargetDir) {
+				return fmt.Errorf("targetPath validation failed, the hard link %q points outside of the target directory", header.Name)
+			}
+
+			dir, err := e.directory(filepath.Dir(target))
+			if err != nil {
+				return err
+			}
+
+			if err := os.Link(filepath.Join(linkTargetDir, filepath.Base(linkTarget)), filepath.Join(dir, filepath.Base(target))); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("archive contains the unsupported file %q, only directories, regular files and links are supported", header.Name)
+		}
+	}
+}
+
+func (e *extractor) extractZip(file string) error {
+	zipReader, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	for _, zipFile := range zipReader.File {
+		target, ok, err := e.target(zipFile.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		mode := zipFile.Mode()
+		switch {
+		case mode.IsDir():
+			if _, err := e.directory(target); err != nil {
+				return err
+			}
+
+		case mode&os.ModeSymlink != 0:
+			linkname, err := readZipFile(zipFile)
+			if err != nil {
+				return err
+			}
+
+			if err := e.symlink(target, string(linkname)); err != nil {
+				return err
+			}
+
+		case mode.IsRegular():
+			rc, err := zipFile.Open()
+			if err != nil {
+				return err
+			}
+
+			err = e.writeFile(target, rc, mode, zipFile.Modified)
+			_ = rc.Close()
+			if err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("archive contains the unsupported file %q, only directories, regular files and links are supported", zipFile.Name)
+		}
+	}
+
+	return nil
+}
+
+// target returns the location of an entry of the archive in the target directory, it returns
+// false if the entry is stripped
+func (e *extractor) target(name string) (string, bool, error) {
+	var elements []string
+	for _, element := range strings.Split(path.Clean("/"+name), "/") {
+		if element != "" {
+			elements = append(elements, element)
+		}
+	}
+
+	if len(elements) <= e.stripComponents {
+		return "", false, nil
+	}
+
+	target := filepath.Join(append([]string{e.targetPath}, elements[e.stripComponents:]...)...)
+	if !e.contains(target) {
+		return "", false, fmt.Errorf("targetPath validation failed, path %q is outside of the target directory", name)
+	}
+
+	return target, true, nil
+}
+
+// contains returns true if the path is inside of the target directory
+func (e *extractor) contains(path string) bool {
+	return path == e.targetPath || strings.HasPrefix(path, e.targetPath+string(filepath.Separator))
+}
+
+// directory creates a directory inside of the target directory including its parents, and returns
+// its location with all symbolic links resolved. Symbolic links that were extracted before can be
+// part of the path, it fails if one of them resolves to a location outside of the target directory.
+func (e *extractor) directory(dir string) (string, error) {
+	relative, err := filepath.Rel(e.targetPath, dir)
+	if err != nil {
+		return "", err
+	}
+
+	current := e.targetPath
+	if relative == "." {
+		return current, nil
+	}
+
+	for _, element := range strings.Split(relative, string(filepath.Separator)) {
+		next := filepath.Join(current, element)
+		if err := os.Mkdir(next, os.FileMode(0755)); err != nil && !errors.Is(err, os.ErrExist) {
+			return "", err
+		}
+
+		resolved, err := filepath.EvalSymlinks(next)
+		if err != nil {
+			return "", err
+		}
+		if !e.contains(resolved) {
+			return "", fmt.Errorf("targetPath validation failed, path %q resolves to a location outside of the target directory", dir)
+		}
+
+		current = resolved
+	}
+
+	return current, nil
+}
+
+func (e *extractor) writeFile(target string, in io.Reader, mode os.FileMode, modTime time.Time) error {
+	// Edge case in which that archive did not have a directory entry
+	dir, err := e.directory(filepath.Dir(target))
+	if err != nil {
+		return err
+	}
+	target = filepath.Join(dir, filepath.Base(target))
+
+	// #nosec G304 the target is validated to be inside of the target directory
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G110 the archive is downloaded from a source that the Build owner configured
+	if _, err := io.Copy(file, in); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chtimes(target, modTime, modTime); err != nil {
+		return err
+	}
+
+	if e.details.MostRecentFileTimestamp == nil || e.details.MostRecentFileTimestamp.Before(modTime) {
+		e.details.MostRecentFileTimestamp = &modTime
+	}
+
+	return nil
+}
+
+// symlink creates a symbolic link, the link must be relative and point to a location inside of the target directory.
+// The link is cleaned, so that parent directory references only lead the link and are resolved from the directory
+// of the link with all symbolic links resolved, a later symbolic link in the path can't move them outside.
+func (e *extractor) symlink(target string, linkname string) error {
+	dir, err := e.directory(filepath.Dir(target))
+	if err != nil {
+		return err
+	}
+
+	linkname = filepath.Clean(linkname)
+	if filepath.IsAbs(linkname) || !e.contains(filepath.Join(dir, linkname)) {
+		return fmt.Errorf("targetPath validation failed, the symbolic link %q points outside of the target directory", linkname)
+	}
+
+	return os.Symlink(linkname, filepath.Join(dir, filepath.Base(target)))
+}
+
+func readZipFile(zipFile *zip.File) ([]byte, error) {
+	rc, err := zipFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,194 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/archive"
+)
+
+var _ = Describe("Extract", func() {
+	var (
+		tempDir string
+		target  string
+		modTime = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	type entry struct {
+		name     string
+		content  string
+		typeflag byte
+		linkname string
+	}
+
+	writeTar := func(w io.Writer, entries ...entry) {
+		tw := tar.NewWriter(w)
+		for _, e := range entries {
+			header := &tar.Header{
+				Name:     e.name,
+				Typeflag: e.typeflag,
+				Linkname: e.linkname,
+				Mode:     0644,
+				Size:     int64(len(e.content)),
+				ModTime:  modTime,
+				Format:   tar.FormatPAX,
+			}
+			if e.typeflag == tar.TypeDir {
+				header.Mode = 0755
+			}
+
+			Expect(tw.WriteHeader(header)).To(Succeed())
+			if e.typeflag == tar.TypeReg {
+				_, err := tw.Write([]byte(e.content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+		}
+		Expect(tw.Close()).To(Succeed())
+	}
+
+	createFile := func(name string, write func(w io.Writer)) string {
+		path := filepath.Join(tempDir, name)
+		file, err := os.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+
+		write(file)
+		return path
+	}
+
+	filecontent := func(path string) string {
+		// #nosec G304 ok in tests
+		data, err := os.ReadFile(filepath.Join(target, path))
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "archive")
+		Expect(err).ToNot(HaveOccurred())
+		target = filepath.Join(tempDir, "target")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	It("extracts a gzip compressed tar archive and strips the leading path element", func() {
+		file := createFile("source.tar.gz", func(w io.Writer) {
+			gw := gzip.NewWriter(w)
+			writeTar(gw,
+				entry{name: "project-1.0/", typeflag: tar.TypeDir},
+				entry{name: "project-1.0/README.md", typeflag: tar.TypeReg, content: "readme"},
+				entry{name: "project-1.0/src/main.go", typeflag: tar.TypeReg, content: "package main"},
+				entry{name: "project-1.0/LINK.md", typeflag: tar.TypeSymlink, linkname: "README.md"},
+			)
+			Expect(gw.Close()).To(Succeed())
+		})
+
+		details, err := archive.Extract(file, target, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filecontent("README.md")).To(Equal("readme"))
+		Expect(filecontent("src/main.go")).To(Equal("package main"))
+		Expect(filecontent("LINK.md")).To(Equal("readme"))
+		Expect(details.MostRecentFileTimestamp).ToNot(BeNil())
+		Expect(details.MostRecentFileTimestamp.Unix()).To(Equal(modTime.Unix()))
+	})
+
+	It("extracts a tar archive", func() {
+		file := createFile("source.tar", func(w io.Writer) {
+			writeTar(w, entry{name: "README.md", typeflag: tar.TypeReg, content: "readme"})
+		})
+
+		_, err := archive.Extract(file, target, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filecontent("README.md")).To(Equal("readme"))
+	})
+
+	It("extracts a zip archive", func() {
+		file := createFile("source.zip", func(w io.Writer) {
+			zw := zip.NewWriter(w)
+			fw, err := zw.CreateHeader(&zip.FileHeader{Name: "project/main.go", Method: zip.Deflate, Modified: modTime})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = fw.Write([]byte("package main"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(zw.Close()).To(Succeed())
+		})
+
+		details, err := archive.Extract(file, target, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filecontent("main.go")).To(Equal("package main"))
+		Expect(details.MostRecentFileTimestamp.Unix()).To(Equal(modTime.Unix()))
+	})
+
+	It("keeps files with parent directory references inside of the target directory", func() {
+		file := createFile("source.tar", func(w io.Writer) {
+			writeTar(w, entry{name: "../../evil.sh", typeflag: tar.TypeReg, content: "evil"})
+		})
+
+		_, err := archive.Extract(file, target, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filecontent("evil.sh")).To(Equal("evil"))
+		Expect(filepath.Join(tempDir, "evil.sh")).ToNot(BeAnExistingFile())
+	})
+
+	It("fails for symbolic links that point outside of the target directory", func() {
+		file := createFile("source.tar", func(w io.Writer) {
+			writeTar(w, entry{name: "passwd", typeflag: tar.TypeSymlink, linkname: "../../etc/passwd"})
+		})
+
+		_, err := archive.Extract(file, target, 0)
+		Expect(err).To(MatchError(ContainSubstring("points outside of the target directory")))
+	})
+
+	It("fails for chains of symbolic links that point outside of the target directory", func() {
+		file := createFile("source.tar", func(w io.Writer) {
+			writeTar(w,
+				entry{name: "a", typeflag: tar.TypeSymlink, linkname: "."},
+				entry{name: "a/a/b", typeflag: tar.TypeSymlink, linkname: "../.."},
+				entry{name: "a/a/b/x", typeflag: tar.TypeReg, content: "evil"},
+			)
+		})
+
+		_, err := archive.Extract(file, target, 0)
+		Expect(err).To(MatchError(ContainSubstring("points outside of the target directory")))
+		Expect(filepath.Join(tempDir, "x")).ToNot(BeAnExistingFile())
+	})
+
+	It("keeps symbolic links inside of the target directory when a later link is part of their path", func() {
+		file := createFile("source.tar", func(w io.Writer) {
+			writeTar(w,
+				entry{name: "c", typeflag: tar.TypeSymlink, linkname: "d/.."},
+				entry{name: "d", typeflag: tar.TypeSymlink, linkname: "."},
+				entry{name: "c/x", typeflag: tar.TypeReg, content: "inside"},
+			)
+		})
+
+		_, err := archive.Extract(file, target, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filecontent("x")).To(Equal("inside"))
+		Expect(filepath.Join(tempDir, "x")).ToNot(BeAnExistingFile())
+	})
+
+	It("fails for files that are no archive", func() {
+		file := createFile("source.txt", func(w io.Writer) {
+			_, err := w.Write([]byte("hello world"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		_, err := archive.Extract(file, target, 0)
+		Expect(err).To(MatchError(ContainSubstring("unsupported archive format")))
+	})
+})
@@ -6,6 +6,7 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -43,6 +44,11 @@ const (
 	bundleImageEnvVar             = "BUNDLE_CONTAINER_IMAGE"
 	bundleContainerTemplateEnvVar = "BUNDLE_CONTAINER_TEMPLATE"
 
+	// Analog to the bundle image, the HTTP archive image is also created by ko
+	httpArchiveDefaultImage            = "ghcr.io/shipwright-io/build/http-archive:latest"
+	httpArchiveImageEnvVar             = "HTTP_ARCHIVE_CONTAINER_IMAGE"
+	httpArchiveContainerTemplateEnvVar = "HTTP_ARCHIVE_CONTAINER_TEMPLATE"
+
 	// environment variable to hold waiter's container image, created by ko
 	waiterDefaultImage            = "ghcr.io/shipwright-io/build/waiter:latest"
 	waiterImageEnvVar             = "WAITER_CONTAINER_IMAGE"
@@ -52,6 +58,7 @@ const (
 	metricBuildRunCompletionDurationBucketsEnvVar = "PROMETHEUS_BR_COMP_DUR_BUCKETS"
 	metricBuildRunEstablishDurationBucketsEnvVar  = "PROMETHEUS_BR_EST_DUR_BUCKETS"
 	metricBuildRunRampUpDurationBucketsEnvVar     = "PROMETHEUS_BR_RAMPUP_DUR_BUCKETS"
+	metricBuildRunQueueDurationBucketsEnvVar      = "PROMETHEUS_BR_QUEUE_DUR_BUCKETS"
 
 	// environment variable to enable prometheus metric labels
 	prometheusEnabledLabelsEnvVar = "PROMETHEUS_ENABLED_LABELS"
@@ -70,6 +77,16 @@ const (
 	controllerClusterBuildStrategyMaxConcurrentReconciles = "CLUSTERBUILDSTRATEGY_MAX_CONCURRENT_RECONCILES"
 	controllerBuildrunExecutorEnvVar                      = "BUILDRUN_EXECUTOR"
 
+	// environment variable to hold the maximum number of concurrently executing BuildRuns per namespace
+	namespaceBuildRunConcurrencyLimitEnvVar = "NAMESPACE_BUILDRUN_CONCURRENCY_LIMIT"
+
+	// environment variable to hold the name of a StorageClass that provides ReadWriteMany volumes for multi-platform builds
+	multiPlatformStorageClassEnvVar = "MULTI_PLATFORM_STORAGE_CLASS"
+
+	// environment variable to hold the interval in which the images of Image triggers are looked up
+	imageTriggerPollIntervalDefault = 5 * time.Minute
+	imageTriggerPollIntervalEnvVar  = "IMAGE_TRIGGER_POLL_INTERVAL"
+
 	// environment variables for the kube API
 	kubeAPIBurst = "KUBE_API_BURST"
 	kubeAPIQPS   = "KUBE_API_QPS"
@@ -118,6 +135,7 @@ var (
 	metricBuildRunCompletionDurationBuckets = prometheus.LinearBuckets(50, 50, 10)
 	metricBuildRunEstablishDurationBuckets  = []float64{0, 1, 2, 3, 5, 7, 10, 15, 20, 30}
 	metricBuildRunRampUpDurationBuckets     = prometheus.LinearBuckets(0, 1, 10)
+	metricBuildRunQueueDurationBuckets      = prometheus.ExponentialBuckets(1, 2, 12)
 
 	root    = ptr.To[int64](0)
 	nonRoot = ptr.To[int64](1000)
@@ -130,6 +148,7 @@ type Config struct {
 	GitContainerTemplate             Step
 	ImageProcessingContainerTemplate Step
 	BundleContainerTemplate          Step
+	HTTPArchiveContainerTemplate     Step
 	WaiterContainerTemplate          Step
 	RemoteArtifactsContainerImage    string
 	TerminationLogPath               string
@@ -141,6 +160,15 @@ type Config struct {
 	VulnerabilityCountLimit          int
 	BuildrunExecutor                 string
 	ForbiddenEnvVarNames             []string
+	// NamespaceBuildRunConcurrencyLimit is the maximum number of concurrently executing BuildRuns
+	// in a namespace, zero means no limit
+	NamespaceBuildRunConcurrencyLimit int
+	// ImageTriggerPollInterval is the interval in which the digests of the images of Image triggers are looked up
+	ImageTriggerPollInterval time.Duration
+	// MultiPlatformStorageClass is the name of a StorageClass that provides ReadWriteMany volumes, the
+	// build tasks of the platforms of a multi-platform build run on different nodes and share the source
+	// volume, an empty value means that multi-platform builds are not supported
+	MultiPlatformStorageClass string
 }
 
 // PrometheusConfig contains the specific configuration for the
@@ -148,6 +176,7 @@ type PrometheusConfig struct {
 	BuildRunCompletionDurationBuckets []float64
 	BuildRunEstablishDurationBuckets  []float64
 	BuildRunRampUpDurationBuckets     []float64
+	BuildRunQueueDurationBuckets      []float64
 	EnabledLabels                     []string
 }
 
@@ -199,6 +228,7 @@ func NewDefaultConfig() *Config {
 		VulnerabilityCountLimit:       50,
 		BuildrunExecutor:              "TaskRun",
 		ForbiddenEnvVarNames:          defaultForbiddenEnvVarNames,
+		ImageTriggerPollInterval:      imageTriggerPollIntervalDefault,
 
 		GitContainerTemplate: Step{
 			Image: gitDefaultImage,
@@ -258,6 +288,31 @@ func NewDefaultConfig() *Config {
 			},
 		},
 
+		HTTPArchiveContainerTemplate: Step{
+			Image: httpArchiveDefaultImage,
+			Command: []string{
+				"/ko-app/http-archive",
+			},
+			// This directory is created in the base image as writable for everybody
+			Env: []corev1.EnvVar{
+				{
+					Name:  "HOME",
+					Value: "/shared-home",
+				},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				RunAsUser:              nonRoot,
+				RunAsGroup:             nonRoot,
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+		},
+
 		ImageProcessingContainerTemplate: Step{
 			Image: imageProcessingDefaultImage,
 			Command: []string{
@@ -324,6 +379,7 @@ func NewDefaultConfig() *Config {
 			BuildRunCompletionDurationBuckets: metricBuildRunCompletionDurationBuckets,
 			BuildRunEstablishDurationBuckets:  metricBuildRunEstablishDurationBuckets,
 			BuildRunRampUpDurationBuckets:     metricBuildRunRampUpDurationBuckets,
+			BuildRunQueueDurationBuckets:      metricBuildRunQueueDurationBuckets,
 		},
 
 		ManagerOptions: ManagerOptions{
@@ -402,6 +458,32 @@ func (c *Config) SetConfigFromEnv() error {
 		c.VulnerabilityCountLimit = vc
 	}
 
+	if pollInterval := os.Getenv(imageTriggerPollIntervalEnvVar); pollInterval != "" {
+		d, err := time.ParseDuration(pollInterval)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return fmt.Errorf("the value %s of %s must be positive", pollInterval, imageTriggerPollIntervalEnvVar)
+		}
+		c.ImageTriggerPollInterval = d
+	}
+
+	if limitStr := os.Getenv(namespaceBuildRunConcurrencyLimitEnvVar); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return err
+		}
+		if limit < 0 {
+			return fmt.Errorf("the value %d of %s must not be negative", limit, namespaceBuildRunConcurrencyLimitEnvVar)
+		}
+		c.NamespaceBuildRunConcurrencyLimit = limit
+	}
+
+	if storageClass := os.Getenv(multiPlatformStorageClassEnvVar); storageClass != "" {
+		c.MultiPlatformStorageClass = storageClass
+	}
+
 	// set environment variable for executor type
 	if executor := os.Getenv(controllerBuildrunExecutorEnvVar); executor != "" {
 		c.BuildrunExecutor = executor
@@ -427,6 +509,21 @@ func (c *Config) SetConfigFromEnv() error {
 		c.BundleContainerTemplate.Image = bundleImage
 	}
 
+	if httpArchiveContainerTemplate := os.Getenv(httpArchiveContainerTemplateEnvVar); httpArchiveContainerTemplate != "" {
+		c.HTTPArchiveContainerTemplate = Step{}
+		if err := json.Unmarshal([]byte(httpArchiveContainerTemplate), &c.HTTPArchiveContainerTemplate); err != nil {
+			return err
+		}
+		if c.HTTPArchiveContainerTemplate.Image == "" {
+			c.HTTPArchiveContainerTemplate.Image = httpArchiveDefaultImage
+		}
+	}
+
+	// the dedicated environment variable for the image overwrites what is defined in the HTTP archive container template
+	if httpArchiveImage := os.Getenv(httpArchiveImageEnvVar); httpArchiveImage != "" {
+		c.HTTPArchiveContainerTemplate.Image = httpArchiveImage
+	}
+
 	if waiterContainerTemplate := os.Getenv(waiterContainerTemplateEnvVar); waiterContainerTemplate != "" {
 		c.WaiterContainerTemplate = Step{}
 		if err := json.Unmarshal([]byte(waiterContainerTemplate), &c.WaiterContainerTemplate); err != nil {
@@ -457,6 +554,10 @@ func (c *Config) SetConfigFromEnv() error {
 		return err
 	}
 
+	if err := updateBucketsConfig(&c.Prometheus.BuildRunQueueDurationBuckets, metricBuildRunQueueDurationBucketsEnvVar); err != nil {
+		return err
+	}
+
 	c.Prometheus.EnabledLabels = strings.Split(os.Getenv(prometheusEnabledLabelsEnvVar), ",")
 
 	if leaderElectionNamespace := os.Getenv(leaderElectionNamespaceEnvVar); leaderElectionNamespace != "" {
@@ -37,12 +37,14 @@ var _ = Describe("Config", func() {
 				"PROMETHEUS_BR_COMP_DUR_BUCKETS":   "1,2,3,4",
 				"PROMETHEUS_BR_EST_DUR_BUCKETS":    "10,20,30,40",
 				"PROMETHEUS_BR_RAMPUP_DUR_BUCKETS": "1,2,3,5,8,12,20",
+				"PROMETHEUS_BR_QUEUE_DUR_BUCKETS":  "5,10,60",
 			}
 
 			configWithEnvVariableOverrides(overrides, func(config *Config) {
 				Expect(config.Prometheus.BuildRunCompletionDurationBuckets).To(Equal([]float64{1, 2, 3, 4}))
 				Expect(config.Prometheus.BuildRunEstablishDurationBuckets).To(Equal([]float64{10, 20, 30, 40}))
 				Expect(config.Prometheus.BuildRunRampUpDurationBuckets).To(Equal([]float64{1, 2, 3, 5, 8, 12, 20}))
+				Expect(config.Prometheus.BuildRunQueueDurationBuckets).To(Equal([]float64{5, 10, 60}))
 			})
 		})
 
@@ -90,6 +92,27 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		It("should allow for an override of the namespace BuildRun concurrency limit", func() {
+			var overrides = map[string]string{"NAMESPACE_BUILDRUN_CONCURRENCY_LIMIT": "5"}
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.NamespaceBuildRunConcurrencyLimit).To(Equal(5))
+			})
+		})
+
+		It("should allow for an override of the image trigger poll interval", func() {
+			var overrides = map[string]string{"IMAGE_TRIGGER_POLL_INTERVAL": "90s"}
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.ImageTriggerPollInterval).To(Equal(90 * time.Second))
+			})
+		})
+
+		It("should allow to set the storage class of multi-platform builds", func() {
+			var overrides = map[string]string{"MULTI_PLATFORM_STORAGE_CLASS": "nfs-client"}
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.MultiPlatformStorageClass).To(Equal("nfs-client"))
+			})
+		})
+
 		It("should allow for an override of kube API client configuration", func() {
 			var overrides = map[string]string{
 				"KUBE_API_BURST": "200",
@@ -26,6 +26,9 @@ import (
 	"github.com/shipwright-io/build/pkg/reconciler/buildrunttlcleanup"
 	"github.com/shipwright-io/build/pkg/reconciler/buildstrategy"
 	"github.com/shipwright-io/build/pkg/reconciler/clusterbuildstrategy"
+	"github.com/shipwright-io/build/pkg/reconciler/imagetrigger"
+	"github.com/shipwright-io/build/pkg/reconciler/objectreftrigger"
+	"github.com/shipwright-io/build/pkg/reconciler/scheduletrigger"
 )
 
 // NewManager add all the controllers to the manager and register the required schemes
@@ -73,12 +76,15 @@ func NewManager(ctx context.Context, config *config.Config, cfg *rest.Config, op
 
 	ctxlog.Info(ctx, "Registering Components.")
 
+	// All reconcilers share one recorder so that events show up with the same reporting controller
+	recorder := mgr.GetEventRecorder("shipwright-build-controller")
+
 	// Add Reconcilers.
-	if err := build.Add(ctx, config, mgr); err != nil {
+	if err := build.Add(ctx, config, mgr, recorder); err != nil {
 		return nil, err
 	}
 
-	if err := buildrun.Add(ctx, config, mgr); err != nil {
+	if err := buildrun.Add(ctx, config, mgr, recorder); err != nil {
 		return nil, err
 	}
 
@@ -90,11 +96,23 @@ func NewManager(ctx context.Context, config *config.Config, cfg *rest.Config, op
 		return nil, err
 	}
 
-	if err := buildlimitcleanup.Add(ctx, config, mgr); err != nil {
+	if err := buildlimitcleanup.Add(ctx, config, mgr, recorder); err != nil {
+		return nil, err
+	}
+
+	if err := buildrunttlcleanup.Add(ctx, config, mgr, recorder); err != nil {
+		return nil, err
+	}
+
+	if err := scheduletrigger.Add(ctx, config, mgr, recorder); err != nil {
+		return nil, err
+	}
+
+	if err := imagetrigger.Add(ctx, config, mgr, recorder); err != nil {
 		return nil, err
 	}
 
-	if err := buildrunttlcleanup.Add(ctx, config, mgr); err != nil {
+	if err := objectreftrigger.Add(ctx, config, mgr, recorder); err != nil {
 		return nil, err
 	}
 
@@ -49,8 +49,44 @@ const (
 	RepositoryNotFound
 	// AuthPrompted is caused when a repo is not found, is private and authentication is insufficient
 	AuthPrompted
+	// SignatureUntrusted expresses that the checked out commit or tag is not signed, or that its signature
+	// was not made with one of the trusted keys.
+	SignatureUntrusted
+	// MergeConflict expresses that the pull request cannot be merged into the base branch because of conflicts.
+	MergeConflict
+	// AuthGitHubAppIncomplete expresses that the app ID, installation ID or private key of a GitHub App is missing.
+	AuthGitHubAppIncomplete
+	// AuthGitHubAppTokenFailed expresses that no installation token could be created for a GitHub App.
+	AuthGitHubAppTokenFailed
+	// TLSVerificationFailed expresses that no TLS connection to the Git server could be established, for example
+	// because its certificate is not signed by a trusted certificate authority.
+	TLSVerificationFailed
+	// DNSResolutionFailed expresses that the hostname of the Git server could not be resolved.
+	DNSResolutionFailed
+	// ConnectionTimeout expresses that the connection to the Git server timed out.
+	ConnectionTimeout
+	// RemoteUnavailable expresses that the Git server rate limits the requests or is unavailable, which it reports
+	// with the HTTP status code 429 or a 5xx one.
+	RemoteUnavailable
+	// ShallowCloneNotSupported expresses that the Git server does not support shallow clones, for example because
+	// it only provides the dumb HTTP protocol.
+	ShallowCloneNotSupported
+	// SubmoduleFailed expresses that a submodule of the repository could not be cloned or updated.
+	SubmoduleFailed
 )
 
+// priorityClasses are the error classes that are the cause of a failure if any line of the error message
+// has them, regardless of its prefix and of the classes of the other lines. For example, the hostname of a
+// SSH Git URL that cannot be resolved leads to an additional line that the remote could not be read from.
+var priorityClasses = []ErrorClass{
+	TLSVerificationFailed,
+	DNSResolutionFailed,
+	ConnectionTimeout,
+	RemoteUnavailable,
+	ShallowCloneNotSupported,
+	SubmoduleFailed,
+}
+
 type rawToken struct {
 	raw string
 }
@@ -100,6 +136,26 @@ func (class ErrorClass) String() string {
 		return "GitSSHAuthExpected"
 	case AuthUnexpectedHTTP:
 		return "AuthUnexpectedHTTP"
+	case SignatureUntrusted:
+		return "GitSignatureUntrusted"
+	case MergeConflict:
+		return "GitMergeConflict"
+	case AuthGitHubAppIncomplete:
+		return "GitGitHubAppAuthIncomplete"
+	case AuthGitHubAppTokenFailed:
+		return "GitGitHubAppTokenFailed"
+	case TLSVerificationFailed:
+		return "GitTLSVerificationFailed"
+	case DNSResolutionFailed:
+		return "GitDNSResolutionFailed"
+	case ConnectionTimeout:
+		return "GitConnectionTimeout"
+	case RemoteUnavailable:
+		return "GitRemoteUnavailable"
+	case ShallowCloneNotSupported:
+		return "GitShallowCloneNotSupported"
+	case SubmoduleFailed:
+		return "GitSubmoduleFailed"
 	}
 
 	return "GitError"
@@ -126,6 +182,26 @@ func (class ErrorClass) ToMessage() string {
 		return "Basic Auth incomplete: Both username and password need to be configured."
 	case AuthUnexpectedHTTP:
 		return "Refusing to continue with basic authentication (username and password) over insecure HTTP connection"
+	case SignatureUntrusted:
+		return "The signature verification has failed. The commit or tag is not signed, or it is not signed with one of the trusted keys."
+	case MergeConflict:
+		return "The pull request cannot be merged into the base branch because of merge conflicts."
+	case AuthGitHubAppIncomplete:
+		return "GitHub App authentication incomplete: The app ID, installation ID and private key need to be configured."
+	case AuthGitHubAppTokenFailed:
+		return "The installation token of the GitHub App could not be created. Check the app ID, installation ID and private key, and that the app is installed for the repository."
+	case TLSVerificationFailed:
+		return "The TLS connection to the Git server failed. Check that its certificate is signed by a trusted certificate authority and matches its hostname."
+	case DNSResolutionFailed:
+		return "The hostname of the Git server could not be resolved. Check the URL of the repository and the DNS configuration of the cluster."
+	case ConnectionTimeout:
+		return "The connection to the Git server timed out. Check the URL of the repository and the proxy and network configuration of the cluster."
+	case RemoteUnavailable:
+		return "The Git server is unavailable or rate limits the requests. This is usually a transient failure, try again later."
+	case ShallowCloneNotSupported:
+		return "The Git server does not support shallow clones. Set the depth of the clone to 0 to clone the full history."
+	case SubmoduleFailed:
+		return "A submodule of the repository could not be cloned or updated. Check the URL of the submodule and that the credentials grant access to it."
 	}
 
 	return "Git encountered an unknown error."
@@ -210,6 +286,39 @@ func isRepositoryNotFound(raw string) bool {
 	return isMatch
 }
 
+func isTLSVerificationFailed(raw string) bool {
+	return strings.Contains(raw, "ssl certificate problem") ||
+		strings.Contains(raw, "server certificate verification failed") ||
+		strings.Contains(raw, "no alternative certificate subject name matches") ||
+		strings.Contains(raw, "gnutls_handshake() failed")
+}
+
+func isDNSResolutionFailed(raw string) bool {
+	return strings.Contains(raw, "could not resolve host") ||
+		strings.Contains(raw, "temporary failure in name resolution")
+}
+
+func isConnectionTimeout(raw string) bool {
+	return strings.Contains(raw, "connection timed out") ||
+		strings.Contains(raw, "operation timed out after")
+}
+
+var remoteUnavailableRegex = regexp.MustCompile(`(returned error:|rpc failed; http) (429|5[0-9][0-9])\b`)
+
+func isRemoteUnavailable(raw string) bool {
+	return remoteUnavailableRegex.MatchString(raw)
+}
+
+func isShallowCloneNotSupported(raw string) bool {
+	return strings.Contains(raw, "does not support shallow")
+}
+
+var submoduleFailedRegex = regexp.MustCompile(`submodule path .* failed|failed to recurse into submodule|no url found for submodule|unable to fetch in submodule`)
+
+func isSubmoduleFailed(raw string) bool {
+	return submoduleFailedRegex.MatchString(raw)
+}
+
 func isBranchNotFound(raw string) bool {
 	return strings.Contains(raw, "remote branch") && strings.Contains(raw, "not found")
 }
@@ -219,6 +328,18 @@ func parseErrorMessage(raw string) errorClassToken {
 	toCheck := strings.ToLower(strings.TrimSpace(raw))
 
 	switch {
+	case isTLSVerificationFailed(toCheck):
+		errorClass = TLSVerificationFailed
+	case isDNSResolutionFailed(toCheck):
+		errorClass = DNSResolutionFailed
+	case isConnectionTimeout(toCheck):
+		errorClass = ConnectionTimeout
+	case isRemoteUnavailable(toCheck):
+		errorClass = RemoteUnavailable
+	case isShallowCloneNotSupported(toCheck):
+		errorClass = ShallowCloneNotSupported
+	case isSubmoduleFailed(toCheck):
+		errorClass = SubmoduleFailed
 	case isAuthInvalidUserOrPass(toCheck):
 		errorClass = AuthInvalidUserOrPass
 	case isAuthPrompted(toCheck):
@@ -276,7 +397,23 @@ func classifyTokensWithFatalPrefix(tokens []errorToken) ErrorClass {
 	return Unknown
 }
 
+func classifyTokensWithPriorityClass(tokens []errorToken) ErrorClass {
+	for _, class := range priorityClasses {
+		for _, token := range tokens {
+			if token.classToken.class == class {
+				return class
+			}
+		}
+	}
+
+	return Unknown
+}
+
 func classifyErrorFromTokens(tokens []errorToken) ErrorClass {
+	if errorClass := classifyTokensWithPriorityClass(tokens); errorClass != Unknown {
+		return errorClass
+	}
+
 	classifierMap := map[Prefix][]errorToken{}
 	for _, token := range tokens {
 		classifierMap[token.prefixToken.scope] = append(classifierMap[token.prefixToken.scope], token)
@@ -51,11 +51,64 @@ var _ = Describe("Parsing Git Error Messages", func() {
 			parsed := parseErrorMessage("Repository not found.")
 			Expect(parsed.class).To(Equal(RepositoryNotFound))
 		})
+		It("should recognize an untrusted certificate", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/org/repo/': SSL certificate problem: unable to get local issuer certificate")
+			Expect(parsed.class).To(Equal(TLSVerificationFailed))
+		})
+		It("should not recognize a failed TLS connection as an untrusted certificate", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/org/repo/': OpenSSL SSL_connect: SSL_ERROR_SYSCALL in connection to git.example.com:443")
+			Expect(parsed.class).To(Equal(Unknown))
+		})
+		It("should recognize a hostname that cannot be resolved", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/org/repo/': Could not resolve host: git.example.com")
+			Expect(parsed.class).To(Equal(DNSResolutionFailed))
+		})
+		It("should recognize a connection timeout", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/org/repo/': Failed to connect to git.example.com port 443 after 130521 ms: Connection timed out")
+			Expect(parsed.class).To(Equal(ConnectionTimeout))
+		})
+		It("should recognize a transfer that timed out", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/org/repo/': Operation timed out after 300000 milliseconds with 0 out of 0 bytes received")
+			Expect(parsed.class).To(Equal(ConnectionTimeout))
+		})
+		It("should recognize a rate limited request", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/org/repo/': The requested URL returned error: 429")
+			Expect(parsed.class).To(Equal(RemoteUnavailable))
+		})
+		It("should recognize an unavailable server", func() {
+			parsed := parseErrorMessage("RPC failed; HTTP 503 curl 22 The requested URL returned error: 503")
+			Expect(parsed.class).To(Equal(RemoteUnavailable))
+		})
+		It("should not recognize a client error as an unavailable server", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/org/repo/': The requested URL returned error: 403")
+			Expect(parsed.class).To(Equal(Unknown))
+		})
+		It("should recognize a server without support for shallow clones", func() {
+			parsed := parseErrorMessage("dumb http transport does not support shallow capabilities")
+			Expect(parsed.class).To(Equal(ShallowCloneNotSupported))
+		})
+		It("should recognize a submodule that cannot be cloned", func() {
+			parsed := parseErrorMessage("clone of 'https://git.example.com/org/lib' into submodule path '/workspace/source/lib' failed")
+			Expect(parsed.class).To(Equal(SubmoduleFailed))
+		})
 		It("should not be able to specify exact error class for unknown message type", func() {
 			parsed := parseErrorMessage("Something went wrong")
 			Expect(parsed.class).To(Equal(Unknown))
 		})
 	})
+	Context("If a line has a priority class then prioritize it", func() {
+		It("case with a SSH hostname that cannot be resolved", func() {
+			errorResult := NewErrorResultFromMessage("ssh: Could not resolve hostname git.example.com: Name or service not known\nfatal: Could not read from remote repository.\n\nPlease make sure you have the correct access rights\nand the repository exists.")
+			Expect(errorResult.Reason).To(Equal(DNSResolutionFailed))
+			Expect(errorResult.Reason.String()).To(Equal("GitDNSResolutionFailed"))
+			Expect(errorResult.Message).To(Equal(DNSResolutionFailed.ToMessage()))
+		})
+		It("case with a submodule of a private repository", func() {
+			errorResult := NewErrorResultFromMessage("Cloning into '/workspace/source/lib'...\nfatal: could not read Username for 'https://git.example.com': terminal prompts disabled\nfatal: clone of 'https://git.example.com/org/lib' into submodule path '/workspace/source/lib' failed\nFailed to clone 'lib'. Retry scheduled")
+			Expect(errorResult.Reason).To(Equal(SubmoduleFailed))
+			Expect(errorResult.Reason.String()).To(Equal("GitSubmoduleFailed"))
+		})
+	})
 	Context("If remote exists then prioritize it", func() {
 		It("case with repo not found", func() {
 			tokens := parse("remote:\nremote: ========================================================================\nremote:\nremote: The project you were looking for could not be found or you don't have permission to view it.\nremote:\nremote: ========================================================================\nremote:\nfatal: Could not read from remote repository.\n\nPlease make sure you have the correct access rights\nand the repository exists.")
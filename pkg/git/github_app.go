@@ -0,0 +1,140 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// GitHubAppIDKey is the key of the ID of the GitHub App in a secret
+	GitHubAppIDKey = "github-app-id"
+
+	// GitHubAppInstallationIDKey is the key of the ID of the installation of the GitHub App in a secret
+	GitHubAppInstallationIDKey = "github-app-installation-id"
+
+	// GitHubAppPrivateKeyKey is the key of the PEM encoded private key of the GitHub App in a secret
+	GitHubAppPrivateKeyKey = "github-app-private-key"
+
+	// GitHubAppAPIURLKey is the key of the optional URL of the GitHub API in a secret, for example
+	// https://github.example.com/api/v3 for GitHub Enterprise Server
+	GitHubAppAPIURLKey = "github-app-api-url"
+
+	// DefaultGitHubAPIURL is the URL of the GitHub API that is used if a secret does not define one
+	DefaultGitHubAPIURL = "https://api.github.com"
+
+	// GitHubAppTokenUsername is the username to use with an installation token of a GitHub App
+	GitHubAppTokenUsername = "x-access-token"
+)
+
+// IsGitHubAppSecret returns whether the data of a secret contains any of the keys of a GitHub App
+func IsGitHubAppSecret(data map[string][]byte) bool {
+	for _, key := range []string{GitHubAppIDKey, GitHubAppInstallationIDKey, GitHubAppPrivateKeyKey} {
+		if _, ok := data[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateGitHubAppSecret validates that the data of a secret contains the ID, the installation
+// ID and a valid private key of a GitHub App
+func ValidateGitHubAppSecret(data map[string][]byte) error {
+	for _, key := range []string{GitHubAppIDKey, GitHubAppInstallationIDKey} {
+		value := strings.TrimSpace(string(data[key]))
+		if value == "" {
+			return fmt.Errorf("the key %s is missing", key)
+		}
+
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("the value of the key %s is not a number", key)
+		}
+	}
+
+	if len(data[GitHubAppPrivateKeyKey]) == 0 {
+		return fmt.Errorf("the key %s is missing", GitHubAppPrivateKeyKey)
+	}
+
+	if _, err := jwt.ParseRSAPrivateKeyFromPEM(data[GitHubAppPrivateKeyKey]); err != nil {
+		return fmt.Errorf("the value of the key %s is not a PEM encoded RSA private key: %w", GitHubAppPrivateKeyKey, err)
+	}
+
+	return nil
+}
+
+// GitHubAppInstallationToken creates a short-lived installation access token of a GitHub App
+// using the app token endpoint of the GitHub API, see
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
+func GitHubAppInstallationToken(ctx context.Context, client *http.Client, apiURL string, appID string, installationID string, privateKey []byte) (string, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the private key of the GitHub App: %w", err)
+	}
+
+	// the issued at time is set into the past to allow for clock drift, the
+	// expiration time must not be more than ten minutes into the future
+	now := time.Now()
+	appToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    strings.TrimSpace(appID),
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+	}).SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the token of the GitHub App: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/app/installations/%s/access_tokens", strings.TrimSuffix(apiURL, "/"), strings.TrimSpace(installationID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+appToken)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request an installation token of the GitHub App: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Token   string `json:"token"`
+		Message string `json:"message"`
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		// the message of the error response is optional
+		_ = json.Unmarshal(body, &result)
+		return "", fmt.Errorf("failed to request an installation token of the GitHub App: %s", strings.TrimSpace(resp.Status+" "+result.Message))
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode the installation token of the GitHub App: %w", err)
+	}
+
+	if result.Token == "" {
+		return "", errors.New("the response does not contain an installation token of the GitHub App")
+	}
+
+	return result.Token, nil
+}
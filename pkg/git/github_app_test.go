@@ -0,0 +1,114 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/git"
+)
+
+var _ = Describe("GitHub App", func() {
+	var privateKey *rsa.PrivateKey
+	var privateKeyPEM []byte
+
+	BeforeEach(func() {
+		var err error
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		privateKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	})
+
+	Context("validating a secret", func() {
+		It("recognizes a secret with a key of a GitHub App", func() {
+			Expect(git.IsGitHubAppSecret(map[string][]byte{git.GitHubAppIDKey: []byte("12345")})).To(BeTrue())
+			Expect(git.IsGitHubAppSecret(map[string][]byte{"username": []byte("user"), "password": []byte("pass")})).To(BeFalse())
+		})
+
+		It("accepts a complete secret", func() {
+			Expect(git.ValidateGitHubAppSecret(map[string][]byte{
+				git.GitHubAppIDKey:             []byte("12345"),
+				git.GitHubAppInstallationIDKey: []byte("67890\n"),
+				git.GitHubAppPrivateKeyKey:     privateKeyPEM,
+			})).To(Succeed())
+		})
+
+		It("rejects a secret without an installation ID", func() {
+			Expect(git.ValidateGitHubAppSecret(map[string][]byte{
+				git.GitHubAppIDKey:         []byte("12345"),
+				git.GitHubAppPrivateKeyKey: privateKeyPEM,
+			})).To(MatchError("the key github-app-installation-id is missing"))
+		})
+
+		It("rejects a secret with an app ID that is not a number", func() {
+			Expect(git.ValidateGitHubAppSecret(map[string][]byte{
+				git.GitHubAppIDKey:             []byte("my-app"),
+				git.GitHubAppInstallationIDKey: []byte("67890"),
+				git.GitHubAppPrivateKeyKey:     privateKeyPEM,
+			})).To(MatchError("the value of the key github-app-id is not a number"))
+		})
+
+		It("rejects a secret with an invalid private key", func() {
+			Expect(git.ValidateGitHubAppSecret(map[string][]byte{
+				git.GitHubAppIDKey:             []byte("12345"),
+				git.GitHubAppInstallationIDKey: []byte("67890"),
+				git.GitHubAppPrivateKeyKey:     []byte("not a key"),
+			})).To(MatchError(ContainSubstring("is not a PEM encoded RSA private key")))
+		})
+	})
+
+	Context("creating an installation token", func() {
+		It("requests the token with a token of the app", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/api/v3/app/installations/67890/access_tokens"))
+
+				token, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &jwt.RegisteredClaims{}, func(*jwt.Token) (interface{}, error) {
+					return &privateKey.PublicKey, nil
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(token.Claims.(*jwt.RegisteredClaims).Issuer).To(Equal("12345"))
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"token":"ghs_installation-token","expires_at":"2026-10-17T06:00:00Z"}`))
+			}))
+			defer server.Close()
+
+			token, err := git.GitHubAppInstallationToken(context.TODO(), server.Client(), server.URL+"/api/v3/", "12345", "67890", privateKeyPEM)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(token).To(Equal("ghs_installation-token"))
+		})
+
+		It("fails if the endpoint rejects the request", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"A JSON web token could not be decoded"}`))
+			}))
+			defer server.Close()
+
+			_, err := git.GitHubAppInstallationToken(context.TODO(), server.Client(), server.URL, "12345", "67890", privateKeyPEM)
+			Expect(err).To(MatchError("failed to request an installation token of the GitHub App: 401 Unauthorized A JSON web token could not be decoded"))
+		})
+
+		It("fails for an invalid private key", func() {
+			_, err := git.GitHubAppInstallationToken(context.TODO(), http.DefaultClient, git.DefaultGitHubAPIURL, "12345", "67890", []byte("not a key"))
+			Expect(err).To(MatchError(ContainSubstring("failed to parse the private key of the GitHub App")))
+		})
+	})
+})
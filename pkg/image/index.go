@@ -0,0 +1,84 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"fmt"
+	"strings"
+
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// PlatformImage is an image that was built for a specific operating system and CPU architecture
+type PlatformImage struct {
+	OS    string
+	Arch  string
+	Image containerreg.Image
+}
+
+// ParsePlatform splits a platform string in the format os/arch into its parts
+func ParsePlatform(platform string) (string, string, error) {
+	os, arch, found := strings.Cut(platform, "/")
+	if !found || os == "" || arch == "" || strings.Contains(arch, "/") {
+		return "", "", fmt.Errorf("invalid platform %q, must be in the format os/arch", platform)
+	}
+
+	return os, arch, nil
+}
+
+// NewImageIndex assembles an OCI image index that references the provided platform images
+func NewImageIndex(platformImages []PlatformImage) (containerreg.ImageIndex, error) {
+	if len(platformImages) == 0 {
+		return nil, fmt.Errorf("at least one platform image is required to assemble an image index")
+	}
+
+	imageIndex := mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+	for _, platformImage := range platformImages {
+		if platformImage.Image == nil {
+			return nil, fmt.Errorf("no image provided for platform %s/%s", platformImage.OS, platformImage.Arch)
+		}
+
+		mediaType, err := platformImage.Image.MediaType()
+		if err != nil {
+			return nil, err
+		}
+
+		imageIndex = mutate.AppendManifests(imageIndex, mutate.IndexAddendum{
+			Add: platformImage.Image,
+			Descriptor: containerreg.Descriptor{
+				MediaType: mediaType,
+				Platform: &containerreg.Platform{
+					OS:           platformImage.OS,
+					Architecture: platformImage.Arch,
+				},
+			},
+		})
+	}
+
+	return imageIndex, nil
+}
+
+// PlatformDigests returns the manifest digest of each platform of an image index
+// in the format os/arch=digest
+func PlatformDigests(imageIndex containerreg.ImageIndex) ([]string, error) {
+	indexManifest, err := imageIndex.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, descriptor := range indexManifest.Manifests {
+		if descriptor.Platform == nil {
+			continue
+		}
+
+		result = append(result, fmt.Sprintf("%s/%s=%s", descriptor.Platform.OS, descriptor.Platform.Architecture, descriptor.Digest.String()))
+	}
+
+	return result, nil
+}
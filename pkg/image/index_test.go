@@ -0,0 +1,84 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+var _ = Describe("Image index", func() {
+
+	Context("ParsePlatform", func() {
+
+		It("splits a valid platform", func() {
+			os, arch, err := image.ParsePlatform("linux/arm64")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os).To(Equal("linux"))
+			Expect(arch).To(Equal("arm64"))
+		})
+
+		It("fails for invalid platforms", func() {
+			for _, platform := range []string{"", "linux", "linux/", "/amd64", "linux/arm/v7"} {
+				_, _, err := image.ParsePlatform(platform)
+				Expect(err).To(HaveOccurred(), platform)
+			}
+		})
+	})
+
+	Context("NewImageIndex", func() {
+
+		var amd64Image, arm64Image containerreg.Image
+
+		BeforeEach(func() {
+			var err error
+			amd64Image, err = random.Image(1234, 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			arm64Image, err = random.Image(2345, 1)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fails without images", func() {
+			_, err := image.NewImageIndex(nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("assembles an image index with one manifest per platform", func() {
+			imageIndex, err := image.NewImageIndex([]image.PlatformImage{
+				{OS: "linux", Arch: "amd64", Image: amd64Image},
+				{OS: "linux", Arch: "arm64", Image: arm64Image},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			mediaType, err := imageIndex.MediaType()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mediaType).To(Equal(types.OCIImageIndex))
+
+			indexManifest, err := imageIndex.IndexManifest()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(indexManifest.Manifests).To(HaveLen(2))
+			Expect(indexManifest.Manifests[0].Platform).To(Equal(&containerreg.Platform{OS: "linux", Architecture: "amd64"}))
+			Expect(indexManifest.Manifests[1].Platform).To(Equal(&containerreg.Platform{OS: "linux", Architecture: "arm64"}))
+
+			amd64Digest, err := amd64Image.Digest()
+			Expect(err).ToNot(HaveOccurred())
+			arm64Digest, err := arm64Image.Digest()
+			Expect(err).ToNot(HaveOccurred())
+
+			platformDigests, err := image.PlatformDigests(imageIndex)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(platformDigests).To(Equal([]string{
+				"linux/amd64=" + amd64Digest.String(),
+				"linux/arm64=" + arm64Digest.String(),
+			}))
+		})
+	})
+})
@@ -6,12 +6,23 @@ package image_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"log"
+	"math/big"
 	"net"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/registry"
 	containerreg "github.com/google/go-containerregistry/pkg/v1"
@@ -63,7 +74,7 @@ var _ = Describe("Insecure registries", func() {
 			imageName, err := image.ParseReference(fmt.Sprintf("%s/%s/%s", registryHost, "test-namespace", "test-image"), insecure)
 			Expect(err).ToNot(HaveOccurred())
 
-			options, _, err := image.GetOptions(context.TODO(), imageName, insecure, "", "test-agent")
+			options, _, err := image.GetOptions(context.TODO(), imageName, insecure, "", "", "test-agent")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, _, err = image.PushImageOrImageIndex(imageName, img, nil, options)
@@ -78,4 +89,68 @@ var _ = Describe("Insecure registries", func() {
 		Entry("fails for an HTTP registry when insecure is not set", false, false, false),
 		Entry("fails for an HTTPS registry with a self-signed certificate when insecure is not set", true, false, false),
 	)
+
+	It("pushes to an HTTPS registry whose certificate authority is in the CA bundle", func() {
+		caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		caTemplate := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "Test CA"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+		Expect(err).ToNot(HaveOccurred())
+		caCert, err := x509.ParseCertificate(caDER)
+		Expect(err).ToNot(HaveOccurred())
+
+		serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		serverDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "registry"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.2")},
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}, caCert, &serverKey.PublicKey, caKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		logger := log.New(io.Discard, "", 0)
+		server := httptest.NewUnstartedServer(registry.New(registry.Logger(logger)))
+		server.Config.ErrorLog = logger
+		Expect(server.Listener.Close()).To(Succeed())
+		server.Listener, err = net.Listen("tcp", "127.0.0.2:0")
+		Expect(err).ToNot(HaveOccurred())
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{serverDER}, PrivateKey: serverKey}}}
+		server.StartTLS()
+		DeferCleanup(server.Close)
+
+		caBundleFile := filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt")
+		Expect(os.WriteFile(caBundleFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0644)).To(Succeed())
+
+		imageName, err := image.ParseReference(fmt.Sprintf("%s/%s/%s", strings.TrimPrefix(server.URL, "https://"), "test-namespace", "test-image"), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		options, _, err := image.GetOptions(context.TODO(), imageName, false, caBundleFile, "", "test-agent")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = image.PushImageOrImageIndex(imageName, img, nil, options)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails for a CA bundle without certificates", func() {
+		caBundleFile := filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt")
+		Expect(os.WriteFile(caBundleFile, []byte("no certificate"), 0644)).To(Succeed())
+
+		imageName, err := image.ParseReference("registry.example.com/test-namespace/test-image", false)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = image.GetOptions(context.TODO(), imageName, false, caBundleFile, "", "test-agent")
+		Expect(err).To(MatchError(ContainSubstring("does not contain any PEM encoded certificate")))
+	})
 })
@@ -91,3 +91,18 @@ func LoadImageOrImageIndexFromRegistry(imageName name.Reference, options []remot
 	image, err := remote.Image(imageName, options...)
 	return image, nil, err
 }
+
+// GetDigestFromRegistry returns the digest of the image or image index that an image name references
+func GetDigestFromRegistry(imageName name.Reference, options []remote.Option) (string, error) {
+	descriptor, err := remote.Head(imageName, options...)
+	if err != nil {
+		// not every registry supports a HEAD request for manifests, fall back to a GET
+		getDescriptor, getErr := remote.Get(imageName, options...)
+		if getErr != nil {
+			return "", err
+		}
+		return getDescriptor.Digest.String(), nil
+	}
+
+	return descriptor.Digest.String(), nil
+}
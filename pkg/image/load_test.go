@@ -5,10 +5,17 @@
 package image_test
 
 import (
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -128,3 +135,38 @@ var _ = Describe("For a local directory", func() {
 		})
 	})
 })
+
+var _ = Describe("GetDigestFromRegistry", func() {
+
+	var registryHost string
+
+	BeforeEach(func() {
+		server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		DeferCleanup(server.Close)
+		registryHost = strings.ReplaceAll(server.URL, "http://", "")
+	})
+
+	It("returns the digest of an image", func() {
+		img, err := random.Image(1024, 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		imageName, err := name.ParseReference(fmt.Sprintf("%s/test-namespace/base-image:latest", registryHost))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remote.Write(imageName, img)).To(Succeed())
+
+		expected, err := img.Digest()
+		Expect(err).ToNot(HaveOccurred())
+
+		digest, err := image.GetDigestFromRegistry(imageName, []remote.Option{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal(expected.String()))
+	})
+
+	It("fails for an image that does not exist", func() {
+		imageName, err := name.ParseReference(fmt.Sprintf("%s/test-namespace/non-existing:latest", registryHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = image.GetDigestFromRegistry(imageName, []remote.Option{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -7,6 +7,7 @@ package image
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -45,8 +46,9 @@ func (ort *optionsRoundTripper) RoundTrip(in *http.Request) (*http.Response, err
 	return ort.inner.RoundTrip(in)
 }
 
-// GetOptions constructs go-containerregistry options to access the remote registry, in addition, it returns the authentication separately which can be an empty object
-func GetOptions(ctx context.Context, imageName name.Reference, insecure bool, dockerConfigJSONPath string, userAgent string) ([]remote.Option, *authn.AuthConfig, error) {
+// GetOptions constructs go-containerregistry options to access the remote registry, in addition, it returns the authentication separately which can be an empty object.
+// The certificate authorities of the optional CA bundle file are trusted in addition to the ones of the system.
+func GetOptions(ctx context.Context, imageName name.Reference, insecure bool, caBundleFile string, dockerConfigJSONPath string, userAgent string) ([]remote.Option, *authn.AuthConfig, error) {
 	var options []remote.Option
 
 	options = append(options, remote.WithContext(ctx))
@@ -57,6 +59,14 @@ func GetOptions(ctx context.Context, imageName name.Reference, insecure bool, do
 		InsecureSkipVerify: false,
 	}
 
+	if caBundleFile != "" {
+		rootCAs, err := LoadCertPool(caBundleFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
 	if insecure {
 		// #nosec:G402 insecure is explicitly requested by user, make sure to skip verification and reset empty defaults
 		transport.TLSClientConfig.InsecureSkipVerify = insecure
@@ -142,3 +152,24 @@ func GetOptions(ctx context.Context, imageName name.Reference, insecure bool, do
 
 	return options, &auth, nil
 }
+
+// LoadCertPool returns the certificate authorities of the system together with the PEM
+// encoded certificate authorities of the CA bundle file
+func LoadCertPool(caBundleFile string) (*x509.CertPool, error) {
+	// #nosec G304 the file is the CA bundle that the Build references
+	data, err := os.ReadFile(caBundleFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA bundle: %w", err)
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		certPool = x509.NewCertPool()
+	}
+
+	if !certPool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("the CA bundle %s does not contain any PEM encoded certificate", caBundleFile)
+	}
+
+	return certPool, nil
+}
@@ -42,7 +42,7 @@ var _ = Describe("GetOptions", func() {
 	Context("without a dockerconfigjson", func() {
 
 		It("constructs options and empty auth", func() {
-			options, auth, err := image.GetOptions(context.TODO(), imageName, true, "", "test-agent")
+			options, auth, err := image.GetOptions(context.TODO(), imageName, true, "", "", "test-agent")
 			Expect(err).ToNot(HaveOccurred())
 
 			// there is no way to further check what is in because the options are functions
@@ -60,7 +60,7 @@ var _ = Describe("GetOptions", func() {
 		It("constructs options and auth with the matching user", func() {
 			withDockerConfigJSON(authn.DefaultAuthKey, "aUser", "aPassword", func(dockerConfigJSONPath string) {
 
-				options, auth, err := image.GetOptions(context.TODO(), imageName, true, dockerConfigJSONPath, "test-agent")
+				options, auth, err := image.GetOptions(context.TODO(), imageName, true, "", dockerConfigJSONPath, "test-agent")
 				Expect(err).ToNot(HaveOccurred())
 
 				// there is no way to further check what is in because the options are functions
@@ -78,7 +78,7 @@ var _ = Describe("GetOptions", func() {
 
 		It("fails with an error", func() {
 			withDockerConfigJSON("ghcr.io", "aUser", "aPassword", func(dockerConfigJSONPath string) {
-				_, _, err := image.GetOptions(context.TODO(), imageName, true, dockerConfigJSONPath, "test-agent")
+				_, _, err := image.GetOptions(context.TODO(), imageName, true, "", dockerConfigJSONPath, "test-agent")
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -0,0 +1,67 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// tagPlaceholderRegex matches a placeholder in a tag, for example $(buildrun.name)
+	tagPlaceholderRegex = regexp.MustCompile(`\$\([^)]*\)`)
+
+	// invalidTagCharactersRegex matches the characters that are not valid in a tag
+	invalidTagCharactersRegex = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+	// tagRegex matches a valid tag, see https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-manifests
+	tagRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+)
+
+// TagPlaceholders returns the placeholders in the value, for example $(buildrun.name)
+func TagPlaceholders(value string) []string {
+	return tagPlaceholderRegex.FindAllString(value, -1)
+}
+
+// ResolveTagPlaceholders replaces the placeholders in the value with their values. Characters
+// of the values that are not valid in a tag, for example the slash of a Git tag, are replaced
+// with a dash. It fails for placeholders without a value.
+func ResolveTagPlaceholders(value string, values map[string]string) (string, error) {
+	var err error
+	resolved := tagPlaceholderRegex.ReplaceAllStringFunc(value, func(placeholder string) string {
+		placeholderValue := strings.TrimSpace(values[placeholder])
+		if placeholderValue == "" && err == nil {
+			err = fmt.Errorf("there is no value for the placeholder %s in %q", placeholder, value)
+		}
+
+		return invalidTagCharactersRegex.ReplaceAllString(placeholderValue, "-")
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resolved, nil
+}
+
+// WithoutTagPlaceholders returns the image reference without its tag if the tag contains
+// placeholders, so that the reference can be passed to tools that do not resolve them
+func WithoutTagPlaceholders(reference string) string {
+	if len(TagPlaceholders(reference)) == 0 {
+		return reference
+	}
+
+	// the placeholders can only be used in the tag, which follows the last slash
+	if index := strings.LastIndex(reference, ":"); index > strings.LastIndex(reference, "/") {
+		return reference[:index]
+	}
+
+	return reference
+}
+
+// IsValidTag returns whether the value is a valid tag of an image
+func IsValidTag(value string) bool {
+	return tagRegex.MatchString(value)
+}
@@ -0,0 +1,70 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+var _ = Describe("Tag placeholders", func() {
+	values := map[string]string{
+		"$(source.git.commitSha)": "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+		"$(source.git.describe)":  "release/v1.1.0-3-g0e05834",
+		"$(buildrun.name)":        "sample-go-abcde",
+	}
+
+	Context("TagPlaceholders", func() {
+		It("returns the placeholders of the value", func() {
+			Expect(image.TagPlaceholders("registry.example.com/app:$(buildrun.name)-$(date)")).To(Equal([]string{"$(buildrun.name)", "$(date)"}))
+		})
+
+		It("returns nothing for a value without placeholders", func() {
+			Expect(image.TagPlaceholders("registry.example.com/app:latest")).To(BeEmpty())
+		})
+	})
+
+	Context("ResolveTagPlaceholders", func() {
+		It("replaces the placeholders with their values", func() {
+			Expect(image.ResolveTagPlaceholders("registry.example.com/app:$(buildrun.name)-$(source.git.commitSha)", values)).
+				To(Equal("registry.example.com/app:sample-go-abcde-0e0583421a5e4bf562ffe33f3651e16ba0c78591"))
+		})
+
+		It("replaces the characters of the values that are not valid in a tag", func() {
+			Expect(image.ResolveTagPlaceholders("$(source.git.describe)", values)).To(Equal("release-v1.1.0-3-g0e05834"))
+		})
+
+		It("fails for a placeholder without a value", func() {
+			_, err := image.ResolveTagPlaceholders("$(date)", values)
+			Expect(err).To(MatchError(`there is no value for the placeholder $(date) in "$(date)"`))
+		})
+	})
+
+	Context("WithoutTagPlaceholders", func() {
+		It("removes a tag with placeholders", func() {
+			Expect(image.WithoutTagPlaceholders("registry.example.com:5000/org/app:$(source.git.commitSha)")).To(Equal("registry.example.com:5000/org/app"))
+		})
+
+		It("keeps a tag without placeholders", func() {
+			Expect(image.WithoutTagPlaceholders("registry.example.com:5000/org/app:latest")).To(Equal("registry.example.com:5000/org/app:latest"))
+			Expect(image.WithoutTagPlaceholders("registry.example.com:5000/org/app")).To(Equal("registry.example.com:5000/org/app"))
+		})
+	})
+
+	Context("IsValidTag", func() {
+		It("accepts valid tags", func() {
+			Expect(image.IsValidTag("v1.1.0")).To(BeTrue())
+			Expect(image.IsValidTag("latest_build-1")).To(BeTrue())
+		})
+
+		It("rejects invalid tags", func() {
+			Expect(image.IsValidTag("")).To(BeFalse())
+			Expect(image.IsValidTag(".hidden")).To(BeFalse())
+			Expect(image.IsValidTag("release/v1")).To(BeFalse())
+		})
+	})
+})
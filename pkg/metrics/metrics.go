@@ -33,6 +33,9 @@ var (
 	taskRunRampUpDuration    *prometheus.HistogramVec
 	taskRunPodRampUpDuration *prometheus.HistogramVec
 
+	buildRunQueueDepth    *prometheus.GaugeVec
+	buildRunQueueDuration *prometheus.HistogramVec
+
 	buildStrategyLabelEnabled = false
 	namespaceLabelEnabled     = false
 	buildLabelEnabled         = false
@@ -52,6 +55,7 @@ func InitPrometheus(config *config.Config) {
 
 	initialized = true
 
+	var namespaceLabels []string
 	var buildLabels []string
 	var buildRunLabels []string
 	if contains(config.Prometheus.EnabledLabels, BuildStrategyLabel) {
@@ -60,6 +64,7 @@ func InitPrometheus(config *config.Config) {
 		buildStrategyLabelEnabled = true
 	}
 	if contains(config.Prometheus.EnabledLabels, NamespaceLabel) {
+		namespaceLabels = append(namespaceLabels, NamespaceLabel)
 		buildLabels = append(buildLabels, NamespaceLabel)
 		buildRunLabels = append(buildRunLabels, NamespaceLabel)
 		namespaceLabelEnabled = true
@@ -128,6 +133,21 @@ func InitPrometheus(config *config.Config) {
 		},
 		buildRunLabels)
 
+	buildRunQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "build_buildruns_queued",
+			Help: "Number of BuildRuns that wait for their executor to be created.",
+		},
+		namespaceLabels)
+
+	buildRunQueueDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "build_buildrun_queue_duration_seconds",
+			Help:    "BuildRun queue duration in seconds (time a queued buildrun waited before its executor was created).",
+			Buckets: config.Prometheus.BuildRunQueueDurationBuckets,
+		},
+		buildRunLabels)
+
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(
 		buildCount,
@@ -137,6 +157,8 @@ func InitPrometheus(config *config.Config) {
 		buildRunRampUpDuration,
 		taskRunRampUpDuration,
 		taskRunPodRampUpDuration,
+		buildRunQueueDepth,
+		buildRunQueueDuration,
 	)
 }
 
@@ -155,6 +177,16 @@ func contains(slice []string, element string) bool {
 	return false
 }
 
+func createNamespaceLabels(namespace string) prometheus.Labels {
+	labels := prometheus.Labels{}
+
+	if namespaceLabelEnabled {
+		labels[NamespaceLabel] = namespace
+	}
+
+	return labels
+}
+
 func createBuildLabels(buildStrategy string, namespace string, build string) prometheus.Labels {
 	labels := prometheus.Labels{}
 
@@ -238,3 +270,17 @@ func TaskRunPodRampUpDurationObserve(buildStrategy string, namespace string, bui
 		taskRunPodRampUpDuration.With(createBuildRunLabels(buildStrategy, namespace, build, buildRun)).Observe(duration.Seconds())
 	}
 }
+
+// BuildRunQueueDepthSet sets the number of BuildRuns that wait for their executor to be created
+func BuildRunQueueDepthSet(namespace string, depth int) {
+	if buildRunQueueDepth != nil {
+		buildRunQueueDepth.With(createNamespaceLabels(namespace)).Set(float64(depth))
+	}
+}
+
+// BuildRunQueueDurationObserve processes the observation of a new buildrun queue duration
+func BuildRunQueueDurationObserve(buildStrategy string, namespace string, build string, buildRun string, duration time.Duration) {
+	if buildRunQueueDuration != nil {
+		buildRunQueueDuration.With(createBuildRunLabels(buildStrategy, namespace, build, buildRun)).Observe(duration.Seconds())
+	}
+}
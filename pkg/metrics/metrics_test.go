@@ -33,6 +33,7 @@ var (
 	buildCounterMetrics      map[string]map[buildLabels]float64
 	buildRunCounterMetrics   map[string]map[buildRunLabels]float64
 	buildRunHistogramMetrics map[string]map[buildRunLabels]float64
+	namespaceGaugeMetrics    map[string]map[string]float64
 )
 
 func promLabelPairToBuildLabels(in []*io_prometheus_client.LabelPair) buildLabels {
@@ -73,6 +74,7 @@ var _ = BeforeSuite(func() {
 	buildCounterMetrics = map[string]map[buildLabels]float64{}
 	buildRunCounterMetrics = map[string]map[buildRunLabels]float64{}
 	buildRunHistogramMetrics = map[string]map[buildRunLabels]float64{}
+	namespaceGaugeMetrics = map[string]map[string]float64{}
 
 	var (
 		testLabels = []buildRunLabels{
@@ -86,6 +88,7 @@ var _ = BeforeSuite(func() {
 			"build_buildrun_rampup_duration_seconds",
 			"build_buildrun_taskrun_rampup_duration_seconds",
 			"build_buildrun_taskrun_pod_rampup_duration_seconds",
+			"build_buildrun_queue_duration_seconds",
 		}
 	)
 
@@ -93,6 +96,9 @@ var _ = BeforeSuite(func() {
 	buildCounterMetrics["build_builds_registered_total"] = map[buildLabels]float64{}
 	buildRunCounterMetrics["build_buildruns_completed_total"] = map[buildRunLabels]float64{}
 
+	// initialize the gauge metrics result map with an empty map
+	namespaceGaugeMetrics["build_buildruns_queued"] = map[string]float64{}
+
 	// initialize the histogram metrics result map with empty maps
 	for _, name := range knownHistogramMetrics {
 		buildRunHistogramMetrics[name] = map[buildRunLabels]float64{}
@@ -115,8 +121,11 @@ var _ = BeforeSuite(func() {
 		BuildRunRampUpDurationObserve(buildStrategy, namespace, build, buildRun, time.Duration(1)*time.Second)
 		TaskRunRampUpDurationObserve(buildStrategy, namespace, build, buildRun, time.Duration(2)*time.Second)
 		TaskRunPodRampUpDurationObserve(buildStrategy, namespace, build, buildRun, time.Duration(3)*time.Second)
+		BuildRunQueueDurationObserve(buildStrategy, namespace, build, buildRun, time.Duration(4)*time.Second)
 	}
 
+	BuildRunQueueDepthSet("default", 3)
+
 	// gather metrics from prometheus and fill the result maps
 	metrics, err := crmetrics.Registry.Gather()
 	if err != nil {
@@ -138,6 +147,10 @@ var _ = BeforeSuite(func() {
 				for _, metric := range metricFamily.GetMetric() {
 					buildRunCounterMetrics[metricFamily.GetName()][promLabelPairToBuildRunLabels(metric.GetLabel())] = metric.GetCounter().GetValue()
 				}
+			case "build_buildruns_queued":
+				for _, metric := range metricFamily.GetMetric() {
+					namespaceGaugeMetrics[metricFamily.GetName()][promLabelPairToBuildLabels(metric.GetLabel()).namespace] = metric.GetGauge().GetValue()
+				}
 			}
 		}
 	}
@@ -208,4 +221,16 @@ var _ = Describe("Custom Metrics", func() {
 			Expect(buildRunHistogramMetrics["build_buildrun_taskrun_pod_rampup_duration_seconds"][buildRunLabels{"buildpacks", "default", "buildpacks-build", "buildpacks-buildrun"}]).To(BeNumerically(">", 0.0))
 		})
 	})
+
+	Context("when BuildRuns are queued", func() {
+		It("should record the number of queued buildruns per namespace", func() {
+			Expect(namespaceGaugeMetrics).To(HaveKey("build_buildruns_queued"))
+			Expect(namespaceGaugeMetrics["build_buildruns_queued"]["default"]).To(Equal(3.0))
+		})
+
+		It("should record the buildrun queue durations", func() {
+			Expect(buildRunHistogramMetrics).To(HaveKey("build_buildrun_queue_duration_seconds"))
+			Expect(buildRunHistogramMetrics["build_buildrun_queue_duration_seconds"][buildRunLabels{"kaniko", "default", "kaniko-build", "kaniko-buildrun"}]).To(BeNumerically(">", 0.0))
+		})
+	})
 })
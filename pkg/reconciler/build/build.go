@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -37,6 +38,7 @@ var validationTypes = [...]string{
 	validate.Tolerations,
 	validate.SchedulerName,
 	validate.RuntimeClassName,
+	validate.TrustedCA,
 }
 
 // ReconcileBuild reconciles a Build object
@@ -46,15 +48,17 @@ type ReconcileBuild struct {
 	config                *config.Config
 	client                client.Client
 	scheme                *runtime.Scheme
+	recorder              events.EventRecorder
 	setOwnerReferenceFunc setOwnerReferenceFunc
 }
 
 // NewReconciler returns a new reconcile.Reconciler
-func NewReconciler(c *config.Config, mgr manager.Manager, ownerRef setOwnerReferenceFunc) reconcile.Reconciler {
+func NewReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder, ownerRef setOwnerReferenceFunc) reconcile.Reconciler {
 	return &ReconcileBuild{
 		config:                c,
 		client:                client.WithFieldOwner(mgr.GetClient(), "shipwright-build-controller"),
 		scheme:                mgr.GetScheme(),
+		recorder:              recorder,
 		setOwnerReferenceFunc: ownerRef,
 	}
 }
@@ -78,6 +82,9 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{}, err
 	}
 
+	// Remember the previous status so that events are only emitted on transitions
+	previousStatus := b.Status.DeepCopy()
+
 	// Populate the status struct with default values
 	b.Status.Registered = ptr.To[corev1.ConditionStatus](corev1.ConditionFalse)
 	b.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.SucceedStatus)
@@ -93,7 +100,7 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		if err := v.ValidatePath(ctx); err != nil {
 			// We enqueue another reconcile here. This is done only for validation
 			// types where the error can be produced from a failed API call.
-			if validationType == validate.Secrets || validationType == validate.Strategies {
+			if validationType == validate.Secrets || validationType == validate.Strategies || validationType == validate.TrustedCA {
 				return reconcile.Result{}, err
 			}
 
@@ -113,6 +120,7 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 				return reconcile.Result{}, err
 			}
 
+			r.recordStatusEvent(b, previousStatus)
 			return reconcile.Result{}, nil
 		}
 	}
@@ -123,9 +131,31 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{}, err
 	}
 
+	r.recordStatusEvent(b, previousStatus)
+
 	// Increase Build count in metrics
 	buildmetrics.BuildCountInc(b.Spec.Strategy.Name, b.Namespace, b.Name)
 
 	ctxlog.Debug(ctx, "finishing reconciling Build", namespace, request.Namespace, name, request.Name)
 	return reconcile.Result{}, nil
 }
+
+// recordStatusEvent emits an event with the registration reason of the Build, if the
+// registration status or reason changed compared to the previous status
+func (r *ReconcileBuild) recordStatusEvent(b *buildapi.Build, previousStatus *buildapi.BuildStatus) {
+	if b.Status.Registered == nil || b.Status.Reason == nil {
+		return
+	}
+
+	if previousStatus.Registered != nil && *previousStatus.Registered == *b.Status.Registered &&
+		previousStatus.Reason != nil && *previousStatus.Reason == *b.Status.Reason {
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if *b.Status.Registered != corev1.ConditionTrue {
+		eventType = corev1.EventTypeWarning
+	}
+
+	r.recorder.Eventf(b, nil, eventType, string(*b.Status.Reason), "Validate", "%s", ptr.Deref(b.Status.Message, ""))
+}
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	crc "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -30,6 +31,7 @@ import (
 var _ = Describe("Reconcile Build", func() {
 	var (
 		manager                      *fakes.FakeManager
+		recorder                     *events.FakeRecorder
 		reconciler                   reconcile.Reconciler
 		request                      reconcile.Request
 		buildSample                  *buildapi.Build
@@ -51,6 +53,7 @@ var _ = Describe("Reconcile Build", func() {
 
 		// Fake the manager and get a reconcile Request
 		manager = &fakes.FakeManager{}
+		recorder = events.NewFakeRecorder(10)
 		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildName, Namespace: namespace}}
 
 		// Fake the client GET calls when reconciling,
@@ -77,7 +80,7 @@ var _ = Describe("Reconcile Build", func() {
 		buildSample = ctl.BuildWithClusterBuildStrategy(buildName, namespace, buildStrategyName, registrySecret)
 		clusterBuildStrategySample = ctl.ClusterBuildStrategy(buildStrategyName)
 		// Reconcile
-		reconciler = buildController.NewReconciler(config.NewDefaultConfig(), manager, controllerutil.SetControllerReference)
+		reconciler = buildController.NewReconciler(config.NewDefaultConfig(), manager, recorder, controllerutil.SetControllerReference)
 	})
 
 	Describe("Reconcile", func() {
@@ -122,6 +125,35 @@ var _ = Describe("Reconcile Build", func() {
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
 				Expect(reconcile.Result{}).To(Equal(result))
 			})
+
+			It("fails when the secret contains incomplete GitHub App credentials", func() {
+				buildSample.Spec.Source.Git.CloneSecret = ptr.To("existing")
+				buildSample.Spec.Output.PushSecret = nil
+
+				client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+					switch object := object.(type) {
+					case *buildapi.Build:
+						buildSample.DeepCopyInto(object)
+					case *buildapi.ClusterBuildStrategy:
+						clusterBuildStrategySample.DeepCopyInto(object)
+					case *corev1.Secret:
+						secretSample = ctl.SecretWithoutAnnotation("existing", namespace)
+						secretSample.Data = map[string][]byte{
+							"github-app-id":          []byte("12345"),
+							"github-app-private-key": []byte("not a key"),
+						}
+						secretSample.DeepCopyInto(object)
+					}
+					return nil
+				})
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, buildapi.SpecSourceSecretNotValid, "referenced secret existing is not a valid GitHub App secret: the key github-app-installation-id is missing")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
 		})
 
 		Context("when spec output registry secret is specified", func() {
@@ -161,6 +193,47 @@ var _ = Describe("Reconcile Build", func() {
 			})
 		})
 
+		Context("when an image trigger pull secret is specified", func() {
+			It("fails when the secret does not exist", func() {
+				buildSample.Spec.Output.PushSecret = nil
+				buildSample.Spec.Trigger = &buildapi.Trigger{
+					When: []buildapi.TriggerWhen{{
+						Name:  "base-image",
+						Type:  buildapi.ImageTrigger,
+						Image: &buildapi.WhenImage{Names: []string{"ghcr.io/some/base-image:latest"}, PullSecret: ptr.To("non-existing-pull-secret")},
+					}},
+				}
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, buildapi.SpecTriggerSecretRefNotFound, "referenced secret non-existing-pull-secret not found")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when a trigger secret is specified", func() {
+			It("fails when the secret does not exist", func() {
+				buildSample.Spec.Output.PushSecret = nil
+				buildSample.Spec.Trigger = &buildapi.Trigger{
+					When: []buildapi.TriggerWhen{{
+						Name:   "push",
+						Type:   buildapi.GitHubWebHookTrigger,
+						GitHub: &buildapi.WhenGitHub{Events: []buildapi.GitHubEventName{buildapi.GitHubPushEvent}},
+					}},
+					TriggerSecret: ptr.To("non-existing-trigger-secret"),
+				}
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, buildapi.SpecTriggerSecretRefNotFound, "referenced secret non-existing-trigger-secret not found")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when source secret and output secret are specified", func() {
 			It("fails when both secrets do not exist", func() {
 				buildSample.Spec.Source.Git.CloneSecret = ptr.To("non-existing-source")
@@ -658,5 +731,75 @@ var _ = Describe("Reconcile Build", func() {
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
 			})
 		})
+
+		Context("when trusted certificate authorities are specified", func() {
+			It("fails when the ConfigMap does not exist", func() {
+				buildSample.Spec.TrustedCA = &buildapi.TrustedCA{ConfigMap: "internal-ca"}
+				buildSample.Spec.Output.PushSecret = nil
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, buildapi.SpecTrustedCAConfigMapNotFound, "referenced ConfigMap internal-ca with the trusted certificate authorities not found")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+
+			It("fails when the ConfigMap does not contain the key", func() {
+				buildSample.Spec.TrustedCA = &buildapi.TrustedCA{ConfigMap: "internal-ca", Key: ptr.To("internal-ca.pem")}
+				buildSample.Spec.Output.PushSecret = nil
+
+				client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+					switch object := object.(type) {
+					case *buildapi.Build:
+						buildSample.DeepCopyInto(object)
+					case *buildapi.ClusterBuildStrategy:
+						clusterBuildStrategySample.DeepCopyInto(object)
+					case *corev1.ConfigMap:
+						object.Data = map[string]string{"ca-bundle.crt": "certificates"}
+					default:
+						return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+					}
+					return nil
+				})
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, buildapi.SpecTrustedCAConfigMapNotFound, "referenced ConfigMap internal-ca does not contain the key internal-ca.pem with the trusted certificate authorities")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the registration status changes", func() {
+			JustBeforeEach(func() {
+				buildSample.Spec.Output.PushSecret = nil
+			})
+
+			It("emits a Normal event when the Build gets registered", func() {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(Receive(Equal("Normal Succeeded all validations succeeded")))
+			})
+
+			It("emits a Warning event with the validation reason when the Build fails to register", func() {
+				buildSample.Spec.Source.Git.CloneSecret = ptr.To("non-existing")
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(Receive(Equal("Warning SpecSourceSecretRefNotFound referenced secret non-existing not found")))
+			})
+
+			It("does not emit an event when the registration did not change", func() {
+				buildSample.Status.Registered = ptr.To(corev1.ConditionTrue)
+				buildSample.Status.Reason = ptr.To(buildapi.SucceedStatus)
+				buildSample.Status.Message = ptr.To(buildapi.AllValidationsSucceeded)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).ToNot(Receive())
+			})
+		})
 	})
 })
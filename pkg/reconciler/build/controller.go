@@ -7,11 +7,13 @@ package build
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -37,9 +39,9 @@ type setOwnerReferenceFunc func(owner, object metav1.Object, scheme *runtime.Sch
 
 // Add creates a new Build Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func Add(ctx context.Context, c *config.Config, mgr manager.Manager) error {
+func Add(ctx context.Context, c *config.Config, mgr manager.Manager, recorder events.EventRecorder) error {
 	ctx = ctxlog.NewContext(ctx, "build-controller")
-	return add(ctx, mgr, NewReconciler(c, mgr, controllerutil.SetControllerReference), c.Controllers.Build.MaxConcurrentReconciles)
+	return add(ctx, mgr, NewReconciler(c, mgr, recorder, controllerutil.SetControllerReference), c.Controllers.Build.MaxConcurrentReconciles)
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -123,7 +125,7 @@ func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler, maxCo
 		return err
 	}
 
-	return c.Watch(source.Kind(mgr.GetCache(), &corev1.Secret{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+	if err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Secret{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
 		buildList := &buildapi.BuildList{}
 
 		// List all builds in the namespace of the current secret
@@ -144,8 +146,10 @@ func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler, maxCo
 		reconcileList := []reconcile.Request{}
 
 		for _, build := range buildList.Items {
-			// Check if this specific Build references the Secret in source or output
+			// Check if this specific Build references the Secret in source, additional sources or output
 			if (build.GetSourceCredentials() != nil && *build.GetSourceCredentials() == secret.Name) ||
+				slices.Contains(build.GetAdditionalSourceCredentials(), secret.Name) ||
+				slices.Contains(build.GetSignatureVerificationSecrets(), secret.Name) ||
 				(build.Spec.Output.PushSecret != nil && *build.Spec.Output.PushSecret == secret.Name) {
 
 				reconcileList = append(reconcileList, reconcile.Request{
@@ -157,5 +161,31 @@ func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler, maxCo
 			}
 		}
 		return reconcileList
+	}))); err != nil {
+		return err
+	}
+
+	return c.Watch(source.Kind(mgr.GetCache(), &corev1.ConfigMap{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, configMap *corev1.ConfigMap) []reconcile.Request {
+		buildList := &buildapi.BuildList{}
+
+		// List all builds in the namespace of the current ConfigMap
+		if err := mgr.GetClient().List(ctx, buildList, &client.ListOptions{Namespace: configMap.Namespace}); err != nil {
+			ctxlog.Info(ctx, "unexpected error happened while listing builds", namespace, configMap.Namespace, "error", err)
+			return []reconcile.Request{}
+		}
+
+		// Only enter the Reconcile space for Builds that trust the certificate authorities of the ConfigMap
+		reconcileList := []reconcile.Request{}
+		for _, build := range buildList.Items {
+			if build.Spec.TrustedCA != nil && build.Spec.TrustedCA.ConfigMap == configMap.Name {
+				reconcileList = append(reconcileList, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      build.Name,
+						Namespace: build.Namespace,
+					},
+				})
+			}
+		}
+		return reconcileList
 	})))
 }
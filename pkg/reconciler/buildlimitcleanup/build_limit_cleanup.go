@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -20,18 +21,24 @@ import (
 	"github.com/shipwright-io/build/pkg/ctxlog"
 )
 
+// RetentionLimitReached is the reason of the event that is emitted when a BuildRun is
+// deleted because the retention limit of its Build was reached
+const RetentionLimitReached = "RetentionLimitReached"
+
 // ReconcileBuild reconciles a Build object
 type ReconcileBuild struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver */
-	config *config.Config
-	client client.Client
+	config   *config.Config
+	client   client.Client
+	recorder events.EventRecorder
 }
 
-func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+func NewReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder) reconcile.Reconciler {
 	return &ReconcileBuild{
-		config: c,
-		client: mgr.GetClient(),
+		config:   c,
+		client:   mgr.GetClient(),
+		recorder: recorder,
 	}
 }
 
@@ -118,7 +125,10 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 						return reconcile.Result{}, err
 					}
 					ctxlog.Debug(ctx, "Error deleting buildRun. It has already been deleted.", namespace, request.Namespace, name, &buildRunSucceeded[i].Name)
+					continue
 				}
+				r.recorder.Eventf(b, &buildRunSucceeded[i], corev1.EventTypeNormal, RetentionLimitReached, "Delete",
+					"deleted succeeded BuildRun %s as the limit of %d succeeded BuildRuns was reached", buildRunSucceeded[i].Name, *b.Spec.Retention.SucceededLimit)
 			}
 		}
 	}
@@ -141,7 +151,10 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 						return reconcile.Result{}, err
 					}
 					ctxlog.Debug(ctx, "Error deleting buildRun. It has already been deleted.", namespace, request.Namespace, name, &buildRunFailed[i].Name)
+					continue
 				}
+				r.recorder.Eventf(b, &buildRunFailed[i], corev1.EventTypeNormal, RetentionLimitReached, "Delete",
+					"deleted failed BuildRun %s as the limit of %d failed BuildRuns was reached", buildRunFailed[i].Name, *b.Spec.Retention.FailedLimit)
 			}
 		}
 	}
@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -28,8 +29,8 @@ const (
 
 // Add creates a new build_limit_cleanup Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started
-func Add(_ context.Context, c *config.Config, mgr manager.Manager) error {
-	return add(mgr, NewReconciler(c, mgr), c.Controllers.Build.MaxConcurrentReconciles)
+func Add(_ context.Context, c *config.Config, mgr manager.Manager, recorder events.EventRecorder) error {
+	return add(mgr, NewReconciler(c, mgr, recorder), c.Controllers.Build.MaxConcurrentReconciles)
 }
 func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
 	// Create the controller options
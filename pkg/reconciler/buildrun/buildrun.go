@@ -10,12 +10,14 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +36,10 @@ const (
 	namespace          string = "namespace"
 	name               string = "name"
 	generatedNameRegex        = "-[a-z0-9]{5,5}$"
+
+	// the ConfigMap with the feature flags of a Tekton installation with the default namespace
+	tektonFeatureFlagsName      = "feature-flags"
+	tektonFeatureFlagsNamespace = "tekton-pipelines"
 )
 
 // blank assignment to verify that ReconcileBuildRun implements reconcile.Reconciler
@@ -46,27 +52,34 @@ type ReconcileBuildRun struct {
 	config                *config.Config
 	client                client.Client
 	scheme                *runtime.Scheme
+	recorder              events.EventRecorder
 	setOwnerReferenceFunc setOwnerReferenceFunc
 	taskRunnerFactory     ImageBuildRunnerFactory
+	admissions            *namespaceAdmissions
 }
 
 // NewReconciler returns a new reconcile.Reconciler
-func NewReconciler(c *config.Config, mgr manager.Manager, ownerRef setOwnerReferenceFunc) reconcile.Reconciler {
+func NewReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder, ownerRef setOwnerReferenceFunc) reconcile.Reconciler {
 	return &ReconcileBuildRun{
 		config:                c,
 		client:                client.WithFieldOwner(mgr.GetClient(), "shipwright-buildrun-controller"),
 		scheme:                mgr.GetScheme(),
+		recorder:              recorder,
 		setOwnerReferenceFunc: ownerRef,
 		taskRunnerFactory:     RunnerFactories[c.BuildrunExecutor],
+		admissions:            newNamespaceAdmissions(),
 	}
 }
 
 // Reconcile reads that state of the cluster for a Build object and makes changes based on the state read
 // and what is in the Build.Spec
-func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Request) (_ reconcile.Result, reconcileErr error) {
 	var buildRun *buildapi.BuildRun
 	var build *buildapi.Build
 
+	// the Succeeded condition as it was read from the cluster, used to only emit events on transitions
+	var previousCondition *buildapi.Condition
+
 	updateBuildRunRequired := false
 
 	// Set the ctx to be Background, as the top-level context for incoming requests.
@@ -79,6 +92,16 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 	// so we can no longer assume that a build run event will not come in after the build run has a task run ref in its status
 	buildRun = &buildapi.BuildRun{}
 	getBuildRunErr := r.GetBuildRunObject(ctx, request.Name, request.Namespace, buildRun)
+	if getBuildRunErr == nil {
+		previousCondition = buildRun.Status.GetCondition(buildapi.Succeeded)
+	}
+
+	defer func() {
+		if reconcileErr == nil {
+			r.recordConditionEvent(buildRun, previousCondition)
+		}
+	}()
+
 	buildRunner, buildRunnerErr := r.taskRunnerFactory.GetImageBuildRunner(ctx, r.client, types.NamespacedName{Name: request.Name, Namespace: request.Namespace})
 
 	if getBuildRunErr != nil && buildRunnerErr != nil {
@@ -214,6 +237,12 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				return reconcile.Result{}, nil
 			}
 
+			// Wait for the backoff of the retry policy before the next attempt of a retried BuildRun
+			if remaining := resources.RemainingRetryBackoff(buildRun, time.Now()); remaining > 0 {
+				ctxlog.Info(ctx, "waiting for the backoff before the next attempt of the BuildRun", namespace, request.Namespace, name, request.Name, "remaining", remaining.String())
+				return reconcile.Result{RequeueAfter: remaining}, nil
+			}
+
 			// Set OwnerReference for Build and BuildRun only when build retention AtBuildDeletion is set to "true"
 			if build.Spec.Retention != nil && build.Spec.Retention.AtBuildDeletion != nil {
 				if *build.Spec.Retention.AtBuildDeletion && !resources.IsOwnedByBuild(build, buildRun.OwnerReferences) {
@@ -247,6 +276,15 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				ctxlog.Info(ctx, fmt.Sprintf("successfully updated BuildRun %s", buildRun.Name), namespace, request.Namespace, name, request.Name)
 			}
 
+			// Apply the Git source override of the BuildRun to the Build spec
+			if !resources.ApplyGitSourceOverride(build, buildRun) {
+				message := fmt.Sprintf("cannot use 'source.git' override as the Build %s does not have a Git source", build.Name)
+				if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, resources.BuildRunBuildFieldOverrideForbidden); updateErr != nil {
+					return reconcile.Result{}, updateErr
+				}
+				return reconcile.Result{}, nil
+			}
+
 			// Set the Build spec in the BuildRun status
 			buildRun.Status.BuildSpec = &build.Spec
 			ctxlog.Info(ctx, "updating BuildRun status", namespace, request.Namespace, name, request.Name)
@@ -341,7 +379,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			}
 			if len(mergedOutput.Platforms) > 0 {
 				mergedNodeSelector := resources.MergeMaps(build.Spec.NodeSelector, buildRun.Spec.NodeSelector)
-				valid, reason, message = validate.ValidateMultiArchPreflight(mergedOutput.Platforms, mergedNodeSelector, r.config.BuildrunExecutor)
+				valid, reason, message = validate.ValidateMultiArchPreflight(mergedOutput.Platforms, mergedNodeSelector, r.config.BuildrunExecutor, r.config.MultiPlatformStorageClass)
 				if !valid {
 					if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, reason); err != nil {
 						ctxlog.Error(ctx, err, "failed to update BuildRun status for multi-arch validation failure", namespace, request.Namespace, name, request.Name)
@@ -349,6 +387,19 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 					}
 					return reconcile.Result{}, nil
 				}
+				// the feature flags are only checked if Tekton is installed in its default namespace
+				featureFlags := &corev1.ConfigMap{}
+				if err := r.client.Get(ctx, types.NamespacedName{Name: tektonFeatureFlagsName, Namespace: tektonFeatureFlagsNamespace}, featureFlags); err != nil {
+					if !apierrors.IsNotFound(err) {
+						return reconcile.Result{}, err
+					}
+				} else if valid, reason, message = validate.ValidateAffinityAssistant(featureFlags.Data); !valid {
+					if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, reason); err != nil {
+						ctxlog.Error(ctx, err, "failed to update BuildRun status for multi-arch validation failure", namespace, request.Namespace, name, request.Name)
+						return reconcile.Result{}, err
+					}
+					return reconcile.Result{}, nil
+				}
 				nodeList := &corev1.NodeList{}
 				if err := r.client.List(ctx, nodeList); err != nil {
 					return reconcile.Result{}, err
@@ -363,6 +414,24 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				}
 			}
 
+			// Queue the BuildRun, or cancel earlier ones, based on the concurrency policy of the Build
+			proceed, err := r.applyConcurrencyPolicy(ctx, build, buildRun)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if !proceed {
+				return reconcile.Result{}, nil
+			}
+
+			// Queue the BuildRun while the namespace has reached its limit of concurrently executing BuildRuns
+			proceed, err = r.applyNamespaceConcurrencyLimit(ctx, buildRun)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if !proceed {
+				return reconcile.Result{}, nil
+			}
+
 			// Create the ImageBuildRunner (TaskRun or PipelineRun)
 			imageBuildRunner, err := r.taskRunnerFactory.CreateImageBuildRunner(ctx, r.client, r.config, svcAccount, strategy, build, buildRun, r.scheme, r.setOwnerReferenceFunc)
 			if err != nil {
@@ -394,6 +463,17 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				return reconcile.Result{}, err
 			}
 
+			// Report the time the BuildRun was queued
+			if resources.IsQueued(buildRun) {
+				buildmetrics.BuildRunQueueDurationObserve(
+					build.Spec.StrategyName(),
+					buildRun.Namespace,
+					buildRun.Spec.BuildName(),
+					buildRun.Name,
+					time.Since(resources.QueuedSince(buildRun)),
+				)
+			}
+
 			// Set the BuildExecutor in the BuildRun status
 			executorName := imageBuildRunner.GetName()
 			buildRun.Status.Executor = &buildapi.BuildExecutor{
@@ -448,9 +528,16 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				if err != nil && apierrors.IsNotFound(err) {
 					return reconcile.Result{}, nil
 				}
+				previousCondition = buildRun.Status.GetCondition(buildapi.Succeeded)
 			}
 		}
 
+		// The executor of an earlier attempt of a retried BuildRun does not change the BuildRun anymore
+		if resources.IsEarlierAttempt(buildRun, buildRunner.GetName()) {
+			ctxlog.Info(ctx, "ignoring the executor of an earlier attempt", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+
 		if buildRun.IsCanceled() && !buildRunner.IsCancelled() {
 			ctxlog.Info(ctx, "buildRun marked for cancellation, patching executor", namespace, request.Namespace, name, request.Name)
 			if err := buildRunner.Cancel(ctx, r.client); err != nil {
@@ -482,6 +569,13 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			}
 			executorStatus := executorCondition.Status
 
+			// Start another attempt if a step went out of memory and its memory can be escalated, or
+			// if the BuildRun failed for a reason that its retry policy lists
+			if executorStatus == corev1.ConditionFalse && !buildRun.IsCanceled() &&
+				(resources.EscalateStepMemory(buildRun, buildRunner.GetObject()) || resources.IsRetryable(buildRun)) {
+				return reconcile.Result{}, r.retryBuildRun(ctx, buildRun, buildRunner)
+			}
+
 			// check if we should delete the generated service account by checking the build run spec and that the executor is complete
 			if executorStatus == corev1.ConditionTrue || executorStatus == corev1.ConditionFalse {
 				if err := resources.DeleteServiceAccount(ctx, r.client, buildRun); err != nil {
@@ -603,7 +697,7 @@ func (r *ReconcileBuildRun) VerifyRequestName(ctx context.Context, request recon
 		if split := regxBuildRun.Split(request.Name, 2); len(split) > 0 {
 			// Update the related BuildRun
 			err := r.GetBuildRunObject(ctx, split[0], request.Namespace, buildRun)
-			if err == nil && buildRun.Status.CompletionTime == nil {
+			if err == nil && buildRun.Status.CompletionTime == nil && !resources.IsEarlierAttempt(buildRun, request.Name) {
 				// We ignore the errors from the following call, because the parent call of this function will always
 				// return back a reconcile.Result{}, nil. This is done to avoid infinite reconcile loops when a BuildRun
 				// does not longer exists
@@ -683,3 +777,24 @@ func (r *ReconcileBuildRun) validateExecutorVolumes(ctx context.Context, imageBu
 
 	return nil
 }
+
+// recordConditionEvent emits an event for the Succeeded condition of the BuildRun, if its
+// status or reason changed compared to the previous condition. Failures are reported as
+// Warning events, all other transitions as Normal events.
+func (r *ReconcileBuildRun) recordConditionEvent(buildRun *buildapi.BuildRun, previousCondition *buildapi.Condition) {
+	condition := buildRun.Status.GetCondition(buildapi.Succeeded)
+	if condition == nil || condition.Reason == "" {
+		return
+	}
+
+	if previousCondition != nil && previousCondition.Status == condition.Status && previousCondition.Reason == condition.Reason {
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if condition.Status == corev1.ConditionFalse {
+		eventType = corev1.EventTypeWarning
+	}
+
+	r.recorder.Eventf(buildRun, nil, eventType, condition.Reason, "Reconcile", "%s", condition.Message)
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	knativeapi "knative.dev/pkg/apis"
 	knativev1 "knative.dev/pkg/apis/duck/v1"
@@ -42,6 +43,7 @@ import (
 var _ = Describe("Reconcile BuildRun", func() {
 	var (
 		manager                                                *fakes.FakeManager
+		recorder                                               *events.FakeRecorder
 		reconciler                                             reconcile.Reconciler
 		taskRunRequest, buildRunRequest                        reconcile.Request
 		client                                                 *fakes.FakeClient
@@ -95,6 +97,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 		Expect(apis.AddToScheme(scheme.Scheme)).To(Succeed())
 		manager = &fakes.FakeManager{}
 		manager.GetSchemeReturns(scheme.Scheme)
+		recorder = events.NewFakeRecorder(10)
 
 		// initialize the fake client and let the
 		// client know on the stubs when get calls are executed
@@ -117,7 +120,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 	// this ensures that overrides on the BuildRun resource can happen under each
 	// Context() BeforeEach() block
 	JustBeforeEach(func() {
-		reconciler = buildrunctl.NewReconciler(config.NewDefaultConfig(), manager, controllerutil.SetControllerReference)
+		reconciler = buildrunctl.NewReconciler(config.NewDefaultConfig(), manager, recorder, controllerutil.SetControllerReference)
 	})
 
 	Describe("Reconciling", func() {
@@ -326,6 +329,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(reconcile.Result{}).To(Equal(result))
 				Expect(client.GetCallCount()).To(Equal(2))
 				Expect(client.StatusCallCount()).To(Equal(1))
+				Expect(recorder.Events).To(Receive(HavePrefix("Normal Pending ")))
 			})
 
 			It("updates the BuildRun status with a RUNNING reason", func() {
@@ -380,6 +384,21 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(reconcile.Result{}).To(Equal(result))
 				Expect(client.GetCallCount()).To(Equal(2))
 				Expect(client.StatusCallCount()).To(Equal(1))
+				Expect(recorder.Events).To(Receive(HavePrefix("Normal Succeeded ")))
+			})
+
+			It("does not emit an event when the BuildRun condition did not change", func() {
+				taskRunSample = ctl.DefaultTaskRunWithStatus(taskRunName, buildRunName, ns, corev1.ConditionUnknown, "Running")
+				buildRunSample.Status.SetCondition(&buildapi.Condition{
+					Type:   buildapi.Succeeded,
+					Reason: "Running",
+					Status: corev1.ConditionUnknown,
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.StatusCallCount()).To(Equal(1))
+				Expect(recorder.Events).ToNot(Receive())
 			})
 
 			It("should recognize the BuildRun is canceled", func() {
@@ -480,6 +499,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				result, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(reconcile.Result{}).To(Equal(result))
+				Expect(recorder.Events).To(Receive(HavePrefix("Warning ")))
 			})
 
 			It("does not break the reconcile when a taskrun pod initcontainers are not ready", func() {
@@ -563,6 +583,90 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(reconcile.Result{}).To(Equal(result))
 			})
+
+			Context("when the BuildRun has a retry policy", func() {
+				BeforeEach(func() {
+					buildRunSample = ctl.BuildRunWithBuildSnapshot(buildRunName, buildName)
+					buildRunSample.Spec.Retry = &buildapi.Retry{
+						MaxAttempts: 2,
+						Backoff:     &metav1.Duration{Duration: time.Minute},
+						Reasons:     []string{buildapi.BuildRunStatePodEvicted},
+					}
+					buildRunSample.Status.Executor = &buildapi.BuildExecutor{Name: taskRunName, Kind: "TaskRun"}
+
+					taskRunSample = ctl.DefaultTaskRunWithFalseStatus(taskRunName, buildRunName, ns)
+					taskRunSample.Status.PodName = "foobar"
+					taskRunSample.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+					taskRunSample.Status.Conditions[0].Reason = string(pipelineapi.TaskRunReasonPodEvicted)
+
+					client.GetCalls(ctl.StubBuildCRDsPodAndTaskRun(
+						buildSample,
+						buildRunSample,
+						ctl.DefaultServiceAccount("foobar"),
+						ctl.DefaultClusterBuildStrategy(),
+						ctl.DefaultNamespacedBuildStrategy(),
+						taskRunSample,
+						&corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: ns},
+							Status:     corev1.PodStatus{Reason: "Evicted", Message: "The node was low on resource: ephemeral-storage."},
+						},
+					))
+				})
+
+				It("records the failed attempt and removes the executor to start the next attempt", func() {
+					var updated *buildapi.BuildRun
+					statusWriter.UpdateCalls(func(_ context.Context, object crc.Object, _ ...crc.SubResourceUpdateOption) error {
+						updated = object.(*buildapi.BuildRun).DeepCopy()
+						return nil
+					})
+
+					result, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(Equal(reconcile.Result{}))
+
+					Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+					Expect(updated.Status.Executor).To(BeNil())
+					Expect(updated.Status.CompletionTime).To(BeNil())
+					Expect(updated.Status.Attempts).To(HaveLen(1))
+					Expect(updated.Status.Attempts[0].Executor.Name).To(Equal(taskRunName))
+					Expect(updated.Status.Attempts[0].Reason).To(Equal(buildapi.BuildRunStatePodEvicted))
+					Expect(updated.Status.GetCondition(buildapi.Succeeded).Reason).To(Equal(buildapi.BuildRunStateRetrying))
+					Expect(recorder.Events).To(Receive(HavePrefix("Normal Retrying")))
+				})
+
+				It("fails the BuildRun once the maximum number of attempts is reached", func() {
+					buildRunSample.Status.Attempts = []buildapi.BuildRunAttempt{{
+						Executor: buildapi.BuildExecutor{Name: "foobar-buildrun-first", Kind: "TaskRun"},
+						Reason:   buildapi.BuildRunStatePodEvicted,
+					}}
+
+					var updated *buildapi.BuildRun
+					statusWriter.UpdateCalls(func(_ context.Context, object crc.Object, _ ...crc.SubResourceUpdateOption) error {
+						updated = object.(*buildapi.BuildRun).DeepCopy()
+						return nil
+					})
+
+					_, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(updated.Status.CompletionTime).ToNot(BeNil())
+					Expect(updated.Status.Attempts).To(HaveLen(1))
+					Expect(updated.Status.GetCondition(buildapi.Succeeded).Status).To(Equal(corev1.ConditionFalse))
+					Expect(updated.Status.GetCondition(buildapi.Succeeded).Reason).To(Equal(buildapi.BuildRunStatePodEvicted))
+				})
+
+				It("ignores the executor of an earlier attempt", func() {
+					buildRunSample.Status.Executor = &buildapi.BuildExecutor{Name: "foobar-buildrun-second", Kind: "TaskRun"}
+					buildRunSample.Status.Attempts = []buildapi.BuildRunAttempt{{
+						Executor: buildapi.BuildExecutor{Name: taskRunName, Kind: "TaskRun"},
+						Reason:   buildapi.BuildRunStatePodEvicted,
+					}}
+
+					_, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+				})
+			})
 		})
 
 		Context("from an existing BuildRun resource", func() {
@@ -921,7 +1025,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				// We do not expect an error because all resources are in place
 				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
 				Expect(err).ToNot(HaveOccurred())
-				Expect(client.GetCallCount()).To(Equal(5))
+				Expect(client.GetCallCount()).To(Equal(6))
 				Expect(client.StatusCallCount()).To(Equal(2))
 			})
 
@@ -992,7 +1096,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				)
 				statusWriter.UpdateCalls(statusCall)
 
-				reconciler = buildrunctl.NewReconciler(config.NewDefaultConfig(), manager,
+				reconciler = buildrunctl.NewReconciler(config.NewDefaultConfig(), manager, recorder,
 					func(owner, object metav1.Object, scheme *runtime.Scheme, _ ...controllerutil.OwnerReferenceOption) error {
 						return fmt.Errorf("foobar error")
 					})
@@ -1032,6 +1136,33 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(client.CreateCallCount()).To(Equal(1))
 			})
 
+			It("waits for the backoff of the retry policy before it creates the TaskRun of the next attempt", func() {
+				buildRunSample.Spec.Retry = &buildapi.Retry{
+					MaxAttempts: 3,
+					Backoff:     &metav1.Duration{Duration: time.Minute},
+					Reasons:     []string{buildapi.BuildRunStatePodEvicted},
+				}
+				buildRunSample.Status.Attempts = []buildapi.BuildRunAttempt{{
+					Executor:       buildapi.BuildExecutor{Name: "foobar-buildrun-first", Kind: "TaskRun"},
+					Reason:         buildapi.BuildRunStatePodEvicted,
+					CompletionTime: &metav1.Time{Time: time.Now()},
+				}}
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					ctl.DefaultNamespacedBuildStrategy()),
+				)
+
+				result, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 50*time.Second))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+				Expect(client.CreateCallCount()).To(Equal(0))
+			})
+
 			It("succeeds creating a TaskRun from a cluster buildstrategy", func() {
 				// override the Build to use a cluster BuildStrategy
 				buildSample = ctl.DefaultBuild(buildName, strategyName, buildapi.ClusterBuildStrategyKind)
@@ -1098,10 +1229,70 @@ var _ = Describe("Reconcile BuildRun", func() {
 					Expect(client.ListCallCount()).To(Equal(0))
 				})
 
+				It("fails with MultiPlatformStorageClassMissing when no storage class is configured", func() {
+					cfg := config.NewDefaultConfig()
+					cfg.BuildrunExecutor = "PipelineRun"
+					reconciler = buildrunctl.NewReconciler(cfg, manager, recorder, controllerutil.SetControllerReference)
+					var sawStorageClassMissing bool
+					statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+						br, ok := o.(*buildapi.BuildRun)
+						if !ok {
+							return nil
+						}
+						if c := br.Status.GetCondition(buildapi.Succeeded); c != nil && c.Status == corev1.ConditionFalse &&
+							c.Reason == string(buildapi.MultiPlatformStorageClassMissing) {
+							sawStorageClassMissing = true
+						}
+						return nil
+					})
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sawStorageClassMissing).To(BeTrue())
+					Expect(client.ListCallCount()).To(Equal(0))
+				})
+
+				It("fails with MultiPlatformAffinityAssistantEnabled when Tekton coschedules the pods of the workspaces", func() {
+					cfg := config.NewDefaultConfig()
+					cfg.BuildrunExecutor = "PipelineRun"
+					cfg.MultiPlatformStorageClass = "nfs-client"
+					reconciler = buildrunctl.NewReconciler(cfg, manager, recorder, controllerutil.SetControllerReference)
+					getStub := ctl.StubBuildRunGetWithSAandStrategies(
+						buildSample,
+						buildRunSample,
+						ctl.DefaultServiceAccount(saName),
+						ctl.DefaultClusterBuildStrategy(),
+						ctl.DefaultNamespacedBuildStrategy(),
+					)
+					client.GetCalls(func(ctx context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+						if configMap, ok := object.(*corev1.ConfigMap); ok && nn.Namespace == "tekton-pipelines" && nn.Name == "feature-flags" {
+							configMap.Data = map[string]string{"coschedule": "workspaces"}
+							return nil
+						}
+						return getStub(ctx, nn, object, getOptions...)
+					})
+					var sawAffinityAssistantEnabled bool
+					statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+						br, ok := o.(*buildapi.BuildRun)
+						if !ok {
+							return nil
+						}
+						if c := br.Status.GetCondition(buildapi.Succeeded); c != nil && c.Status == corev1.ConditionFalse &&
+							c.Reason == string(buildapi.MultiPlatformAffinityAssistantEnabled) {
+							sawAffinityAssistantEnabled = true
+						}
+						return nil
+					})
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sawAffinityAssistantEnabled).To(BeTrue())
+					Expect(client.ListCallCount()).To(Equal(0))
+				})
+
 				It("returns error when listing Nodes fails (after preflight)", func() {
 					cfg := config.NewDefaultConfig()
 					cfg.BuildrunExecutor = "PipelineRun"
-					reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+					cfg.MultiPlatformStorageClass = "nfs-client"
+					reconciler = buildrunctl.NewReconciler(cfg, manager, recorder, controllerutil.SetControllerReference)
 					listErr := fmt.Errorf("apiserver unavailable")
 					client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
 						if _, ok := list.(*corev1.NodeList); ok {
@@ -1116,7 +1307,8 @@ var _ = Describe("Reconcile BuildRun", func() {
 				It("fails with NodePlatformNotFound when no schedulable node matches a platform", func() {
 					cfg := config.NewDefaultConfig()
 					cfg.BuildrunExecutor = "PipelineRun"
-					reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+					cfg.MultiPlatformStorageClass = "nfs-client"
+					reconciler = buildrunctl.NewReconciler(cfg, manager, recorder, controllerutil.SetControllerReference)
 					client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
 						nl, ok := list.(*corev1.NodeList)
 						if !ok {
@@ -1243,6 +1435,220 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(client.CreateCallCount()).To(Equal(1))
 			})
 
+			Context("when the Build has a concurrency policy", func() {
+				var earlierBuildRun *buildapi.BuildRun
+
+				BeforeEach(func() {
+					buildSample = ctl.DefaultBuild(buildName, strategyName, buildapi.NamespacedBuildStrategyKind)
+					buildRunSample = ctl.DefaultBuildRun(buildRunName, buildName)
+					buildRunSample.CreationTimestamp = metav1.Now()
+
+					earlierBuildRun = ctl.DefaultBuildRun("earlier-buildrun", buildName)
+					earlierBuildRun.CreationTimestamp = metav1.NewTime(buildRunSample.CreationTimestamp.Add(-time.Minute))
+					earlierBuildRun.Status.Executor = &buildapi.BuildExecutor{Name: "earlier-buildrun-xyz", Kind: "TaskRun"}
+
+					client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+						buildSample,
+						buildRunSample,
+						ctl.DefaultServiceAccount(saName),
+						ctl.DefaultClusterBuildStrategy(),
+						ctl.DefaultNamespacedBuildStrategy()),
+					)
+
+					client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+						if buildRunList, ok := list.(*buildapi.BuildRunList); ok {
+							buildRunList.Items = []buildapi.BuildRun{*earlierBuildRun, *buildRunSample}
+						}
+						return nil
+					})
+				})
+
+				It("does not look at other BuildRuns when concurrent BuildRuns are allowed", func() {
+					buildSample.Spec.ConcurrencyPolicy = buildapi.AllowConcurrent
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.ListCallCount()).To(Equal(0))
+					Expect(client.CreateCallCount()).To(Equal(1))
+				})
+
+				It("queues the BuildRun while an earlier BuildRun is running", func() {
+					buildSample.Spec.ConcurrencyPolicy = buildapi.ForbidConcurrent
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(0))
+
+					_, object, _ := statusWriter.UpdateArgsForCall(statusWriter.UpdateCallCount() - 1)
+					condition := object.(*buildapi.BuildRun).Status.GetCondition(buildapi.Succeeded)
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Status).To(Equal(corev1.ConditionUnknown))
+					Expect(condition.Reason).To(Equal(buildapi.BuildRunStatePending))
+					Expect(condition.Message).To(ContainSubstring("earlier-buildrun"))
+
+					Expect(recorder.Events).To(Receive(HavePrefix("Normal Pending")))
+				})
+
+				It("creates the TaskRun once the earlier BuildRun finished", func() {
+					buildSample.Spec.ConcurrencyPolicy = buildapi.ForbidConcurrent
+					earlierBuildRun.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+					earlierBuildRun.Status.SetCondition(&buildapi.Condition{
+						Type:   buildapi.Succeeded,
+						Status: corev1.ConditionTrue,
+						Reason: "Succeeded",
+					})
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(1))
+				})
+
+				It("cancels the earlier BuildRun and creates the TaskRun when replacing", func() {
+					buildSample.Spec.ConcurrencyPolicy = buildapi.ReplaceConcurrent
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(client.PatchCallCount()).To(Equal(1))
+					_, object, _, _ := client.PatchArgsForCall(0)
+					Expect(object.GetName()).To(Equal("earlier-buildrun"))
+					Expect(object.(*buildapi.BuildRun).IsCanceled()).To(BeTrue())
+
+					Expect(client.CreateCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the namespace limits the number of concurrently executing BuildRuns", func() {
+				var (
+					namespaceSample *corev1.Namespace
+					otherBuildRuns  []buildapi.BuildRun
+				)
+
+				newBuildRun := func(name string, age time.Duration, executing bool) buildapi.BuildRun {
+					buildRun := ctl.DefaultBuildRun(name, "other-build")
+					buildRun.CreationTimestamp = metav1.NewTime(buildRunSample.CreationTimestamp.Add(-age))
+					if executing {
+						buildRun.Status.Executor = &buildapi.BuildExecutor{Name: name + "-xyz", Kind: "TaskRun"}
+					}
+					return *buildRun
+				}
+
+				BeforeEach(func() {
+					buildSample = ctl.DefaultBuild(buildName, strategyName, buildapi.NamespacedBuildStrategyKind)
+					buildRunSample = ctl.DefaultBuildRun(buildRunName, buildName)
+					buildRunSample.CreationTimestamp = metav1.Now()
+
+					namespaceSample = &corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        ns,
+							Annotations: map[string]string{buildapi.AnnotationNamespaceConcurrencyLimit: "2"},
+						},
+					}
+					otherBuildRuns = nil
+
+					stubGetCalls := ctl.StubBuildRunGetWithSAandStrategies(
+						buildSample,
+						buildRunSample,
+						ctl.DefaultServiceAccount(saName),
+						ctl.DefaultClusterBuildStrategy(),
+						ctl.DefaultNamespacedBuildStrategy(),
+					)
+					client.GetCalls(func(ctx context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+						if namespaceObject, ok := object.(*corev1.Namespace); ok {
+							namespaceSample.DeepCopyInto(namespaceObject)
+							return nil
+						}
+						return stubGetCalls(ctx, nn, object, getOptions...)
+					})
+
+					client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+						if buildRunList, ok := list.(*buildapi.BuildRunList); ok {
+							buildRunList.Items = append([]buildapi.BuildRun{*buildRunSample}, otherBuildRuns...)
+						}
+						return nil
+					})
+				})
+
+				It("creates the TaskRun when a slot is free", func() {
+					otherBuildRuns = []buildapi.BuildRun{newBuildRun("executing", time.Minute, true)}
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(1))
+				})
+
+				It("queues the BuildRun when all slots are taken", func() {
+					otherBuildRuns = []buildapi.BuildRun{
+						newBuildRun("executing-a", time.Minute, true),
+						newBuildRun("executing-b", time.Minute, true),
+					}
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(0))
+
+					_, object, _ := statusWriter.UpdateArgsForCall(statusWriter.UpdateCallCount() - 1)
+					condition := object.(*buildapi.BuildRun).Status.GetCondition(buildapi.Succeeded)
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Status).To(Equal(corev1.ConditionUnknown))
+					Expect(condition.Reason).To(Equal(buildapi.BuildRunStateQueued))
+					Expect(condition.Message).To(ContainSubstring("allows 2 concurrently executing BuildRuns"))
+				})
+
+				It("queues the BuildRun behind earlier queued BuildRuns", func() {
+					otherBuildRuns = []buildapi.BuildRun{
+						newBuildRun("executing", time.Minute, true),
+						newBuildRun("queued-earlier", time.Second, false),
+					}
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(0))
+				})
+
+				It("does not wait for BuildRuns that were queued after it", func() {
+					otherBuildRuns = []buildapi.BuildRun{
+						newBuildRun("executing", time.Minute, true),
+						newBuildRun("queued-later", -time.Second, false),
+					}
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(1))
+				})
+
+				It("reserves the slot of an admitted BuildRun until the cache shows its executor", func() {
+					namespaceSample.Annotations[buildapi.AnnotationNamespaceConcurrencyLimit] = "1"
+					admittedBuildRun := buildRunSample.DeepCopy()
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(1))
+
+					// a BuildRun that is not queued behind the admitted one, while the cache does not show the executor of the admitted one yet
+					*buildRunSample = *ctl.DefaultBuildRun("other-buildrun", buildName)
+					buildRunSample.CreationTimestamp = metav1.NewTime(admittedBuildRun.CreationTimestamp.Add(-time.Minute))
+					otherBuildRuns = []buildapi.BuildRun{*admittedBuildRun}
+
+					_, err = reconciler.Reconcile(context.TODO(), newReconcileRequest("other-buildrun", ns))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(1))
+				})
+
+				It("uses the configured limit when the namespace annotation is invalid", func() {
+					namespaceSample.Annotations[buildapi.AnnotationNamespaceConcurrencyLimit] = "unlimited"
+					otherBuildRuns = []buildapi.BuildRun{newBuildRun("executing", time.Minute, true)}
+
+					cfg := config.NewDefaultConfig()
+					cfg.NamespaceBuildRunConcurrencyLimit = 1
+					reconciler = buildrunctl.NewReconciler(cfg, manager, recorder, controllerutil.SetControllerReference)
+
+					_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client.CreateCallCount()).To(Equal(0))
+				})
+			})
+
 			It("fails the BuildRun when it is already owned by another controller", func() {
 				fakeOwnerName := "fakeOwner"
 
@@ -1421,6 +1827,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(recorder.Events).To(Receive(HavePrefix("Warning BuildRunNameInvalid ")))
 			})
 
 			It("should mark buildrun succeeded false when BuildRun name contains illegal runes", func() {
@@ -1455,6 +1862,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
 				Expect(err).To(HaveOccurred())
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(recorder.Events).ToNot(Receive())
 			})
 		})
 
@@ -1876,6 +2284,30 @@ var _ = Describe("Reconcile BuildRun", func() {
 			})
 		})
 
+		Context("when a buildrun overrides the pull request with an invalid ref", func() {
+			BeforeEach(func() {
+				buildRunSample = ctl.DefaultBuildRun(buildRunName, buildName)
+				buildRunSample.Spec.Source = &buildapi.BuildRunSource{
+					Type: buildapi.GitType,
+					Git: &buildapi.BuildRunGitSource{
+						PullRequest: &buildapi.PullRequest{
+							Number: 7,
+							Ref:    ptr.To("--upload-pack=touch /tmp/pwned"),
+						},
+					},
+				}
+			})
+
+			It("should fail to register", func() {
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, buildapi.BuildReason(resources.BuildRunPullRequestInvalid), "pull request ref \"--upload-pack=touch /tmp/pwned\" must be a valid ref below refs/")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when a buildrun has a buildSpec defined and overrides Tolerations", func() {
 			BeforeEach(func() {
 				buildRunSample = ctl.BuildRunWithTolerationsOverride(buildRunName, buildName, []corev1.Toleration{{Key: "testkey", Value: "testvalue", Operator: corev1.TolerationOpEqual, Effect: corev1.TaintEffectNoSchedule}})
@@ -1931,6 +2363,21 @@ var _ = Describe("Reconcile BuildRun", func() {
 			})
 		})
 
+		Context("when a buildrun has a buildSpec defined and overrides the Git revision", func() {
+			BeforeEach(func() {
+				buildRunSample = ctl.BuildRunWithGitRevisionOverride(buildRunName, buildName, "feature")
+			})
+
+			It("should fail to register", func() {
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, buildapi.BuildReason(resources.BuildRunBuildFieldOverrideForbidden), "cannot use 'source.git' override and 'buildSpec' simultaneously")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when using PipelineRun executor", func() {
 			var (
 				pipelineRunName   string
@@ -1947,7 +2394,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				// Create a reconciler with PipelineRun executor
 				cfg := config.NewDefaultConfig()
 				cfg.BuildrunExecutor = "PipelineRun"
-				reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+				reconciler = buildrunctl.NewReconciler(cfg, manager, recorder, controllerutil.SetControllerReference)
 			})
 
 			Context("from an existing PipelineRun resource", func() {
@@ -0,0 +1,191 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package buildrun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	buildmetrics "github.com/shipwright-io/build/pkg/metrics"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+// applyConcurrencyPolicy handles the BuildRuns of the same Build that were created before the
+// given BuildRun and did not finish yet. It returns false if the BuildRun must not start yet.
+func (r *ReconcileBuildRun) applyConcurrencyPolicy(ctx context.Context, build *buildapi.Build, buildRun *buildapi.BuildRun) (bool, error) {
+	// embedded builds have no other BuildRuns that they could overlap with
+	if build.Name == "" {
+		return true, nil
+	}
+
+	policy := build.Spec.ConcurrencyPolicy
+	if policy != buildapi.ForbidConcurrent && policy != buildapi.ReplaceConcurrent {
+		return true, nil
+	}
+
+	unfinishedBuildRuns, err := resources.ListUnfinishedBuildRuns(ctx, r.client, buildRun.Namespace, map[string]string{buildapi.LabelBuild: build.Name})
+	if err != nil {
+		return false, err
+	}
+
+	var earlierBuildRuns []buildapi.BuildRun
+	for _, unfinishedBuildRun := range unfinishedBuildRuns {
+		if unfinishedBuildRun.Name != buildRun.Name && resources.IsCreatedBefore(&unfinishedBuildRun, buildRun) {
+			earlierBuildRuns = append(earlierBuildRuns, unfinishedBuildRun)
+		}
+	}
+
+	if len(earlierBuildRuns) == 0 {
+		return true, nil
+	}
+
+	switch policy {
+	case buildapi.ForbidConcurrent:
+		names := make([]string, 0, len(earlierBuildRuns))
+		for _, earlierBuildRun := range earlierBuildRuns {
+			names = append(names, earlierBuildRun.Name)
+		}
+
+		message := fmt.Sprintf("waiting for the BuildRuns of Build %s that were created before to finish: %s", build.Name, strings.Join(names, ", "))
+		ctxlog.Info(ctx, "queueing BuildRun because of the concurrency policy of its Build", namespace, buildRun.Namespace, name, buildRun.Name)
+		return false, resources.UpdateConditionWithUnknownStatus(ctx, r.client, buildRun, message, buildapi.BuildRunStatePending)
+
+	case buildapi.ReplaceConcurrent:
+		for i := range earlierBuildRuns {
+			earlierBuildRun := &earlierBuildRuns[i]
+			if earlierBuildRun.IsCanceled() {
+				continue
+			}
+
+			ctxlog.Info(ctx, "canceling BuildRun because of the concurrency policy of its Build", namespace, earlierBuildRun.Namespace, name, earlierBuildRun.Name, "replacedBy", buildRun.Name)
+			patch := client.MergeFrom(earlierBuildRun.DeepCopy())
+			earlierBuildRun.Spec.State = buildapi.BuildRunRequestedStatePtr(buildapi.BuildRunStateCancel)
+			if err := r.client.Patch(ctx, earlierBuildRun, patch); err != nil && !apierrors.IsNotFound(err) {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// namespaceAdmissions serializes the admission of BuildRuns and remembers the admitted BuildRuns of each
+// namespace until the cache shows their executor. Without the reservation, parallel reconciles or a
+// cache that lags behind the creation of the executors would admit more BuildRuns than the limit allows.
+type namespaceAdmissions struct {
+	mutex    sync.Mutex
+	admitted map[string]map[string]struct{}
+}
+
+func newNamespaceAdmissions() *namespaceAdmissions {
+	return &namespaceAdmissions{admitted: map[string]map[string]struct{}{}}
+}
+
+// applyNamespaceConcurrencyLimit queues the BuildRun while the maximum number of concurrently executing
+// BuildRuns in its namespace is reached. Queued BuildRuns start in the order in which they were created.
+// It returns false if the BuildRun must not start yet.
+func (r *ReconcileBuildRun) applyNamespaceConcurrencyLimit(ctx context.Context, buildRun *buildapi.BuildRun) (bool, error) {
+	limit, err := r.namespaceConcurrencyLimit(ctx, buildRun.Namespace)
+	if err != nil {
+		return false, err
+	}
+
+	if limit == 0 {
+		buildmetrics.BuildRunQueueDepthSet(buildRun.Namespace, 0)
+		return true, nil
+	}
+
+	r.admissions.mutex.Lock()
+	defer r.admissions.mutex.Unlock()
+
+	unfinishedBuildRuns, err := resources.ListUnfinishedBuildRuns(ctx, r.client, buildRun.Namespace, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// admitted BuildRuns that finished, or whose executor is in the cache, no longer need a reservation
+	admitted := r.admissions.admitted[buildRun.Namespace]
+	reserved := make(map[string]struct{}, len(admitted))
+	for i := range unfinishedBuildRuns {
+		if _, ok := admitted[unfinishedBuildRuns[i].Name]; ok && unfinishedBuildRuns[i].Status.Executor == nil {
+			reserved[unfinishedBuildRuns[i].Name] = struct{}{}
+		}
+	}
+
+	var executing, waiting, waitingBefore int
+	for i := range unfinishedBuildRuns {
+		unfinishedBuildRun := &unfinishedBuildRuns[i]
+		_, isReserved := reserved[unfinishedBuildRun.Name]
+
+		switch {
+		case unfinishedBuildRun.Name == buildRun.Name:
+			continue
+
+		case unfinishedBuildRun.Status.Executor != nil, isReserved:
+			executing++
+
+		default:
+			waiting++
+
+			// BuildRuns that wait for an earlier BuildRun of their Build do not wait for a slot in the namespace
+			condition := unfinishedBuildRun.Status.GetCondition(buildapi.Succeeded)
+			if (condition == nil || condition.Reason != buildapi.BuildRunStatePending) && resources.IsCreatedBefore(unfinishedBuildRun, buildRun) {
+				waitingBefore++
+			}
+		}
+	}
+
+	if executing+waitingBefore < limit {
+		reserved[buildRun.Name] = struct{}{}
+		r.admissions.admitted[buildRun.Namespace] = reserved
+
+		buildmetrics.BuildRunQueueDepthSet(buildRun.Namespace, waiting)
+		return true, nil
+	}
+
+	r.admissions.admitted[buildRun.Namespace] = reserved
+	buildmetrics.BuildRunQueueDepthSet(buildRun.Namespace, waiting+1)
+
+	message := fmt.Sprintf("the namespace %s allows %d concurrently executing BuildRuns, waiting for %d executing and %d earlier queued BuildRuns", buildRun.Namespace, limit, executing, waitingBefore)
+	ctxlog.Info(ctx, "queueing BuildRun because of the concurrency limit of its namespace", namespace, buildRun.Namespace, name, buildRun.Name)
+	return false, resources.UpdateConditionWithUnknownStatus(ctx, r.client, buildRun, message, buildapi.BuildRunStateQueued)
+}
+
+// namespaceConcurrencyLimit returns the maximum number of concurrently executing BuildRuns in a namespace,
+// which is the configured limit unless the namespace overrides it with an annotation
+func (r *ReconcileBuildRun) namespaceConcurrencyLimit(ctx context.Context, namespaceName string) (int, error) {
+	limit := r.config.NamespaceBuildRunConcurrencyLimit
+
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: namespaceName}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return limit, nil
+		}
+		return 0, err
+	}
+
+	value, ok := ns.GetAnnotations()[buildapi.AnnotationNamespaceConcurrencyLimit]
+	if !ok {
+		return limit, nil
+	}
+
+	namespaceLimit, err := strconv.Atoi(value)
+	if err != nil || namespaceLimit < 0 {
+		ctxlog.Info(ctx, "ignoring invalid BuildRun concurrency limit annotation", namespace, namespaceName, "annotation", buildapi.AnnotationNamespaceConcurrencyLimit, "value", value)
+		return limit, nil
+	}
+
+	return namespaceLimit, nil
+}
@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -25,14 +26,16 @@ import (
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 )
 
 type setOwnerReferenceFunc func(owner, object metav1.Object, scheme *runtime.Scheme, opts ...controllerutil.OwnerReferenceOption) error
 
 // Add creates a new BuildRun Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func Add(_ context.Context, c *config.Config, mgr manager.Manager) error {
-	return add(mgr, NewReconciler(c, mgr, controllerutil.SetControllerReference), c.Controllers.BuildRun.MaxConcurrentReconciles, c)
+func Add(_ context.Context, c *config.Config, mgr manager.Manager, recorder events.EventRecorder) error {
+	return add(mgr, NewReconciler(c, mgr, recorder, controllerutil.SetControllerReference), c.Controllers.BuildRun.MaxConcurrentReconciles, c)
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -63,6 +66,10 @@ func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles in
 			switch {
 			case !e.ObjectOld.IsCanceled() && e.ObjectNew.IsCanceled():
 				return true
+
+			// - a failed attempt is retried and needs a new executor
+			case e.ObjectOld.Status.Executor != nil && e.ObjectNew.Status.Executor == nil && e.ObjectNew.Status.CompletionTime == nil:
+				return true
 			}
 
 			return false
@@ -162,6 +169,76 @@ func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles in
 		return err
 	}
 
+	predFinishedBuildRun := predicate.TypedFuncs[*buildapi.BuildRun]{
+		CreateFunc: func(_ event.TypedCreateEvent[*buildapi.BuildRun]) bool {
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*buildapi.BuildRun]) bool {
+			// A BuildRun finished, BuildRuns that were queued behind it might now start
+			return !isFinished(e.ObjectOld) && isFinished(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*buildapi.BuildRun]) bool {
+			return !isFinished(e.Object)
+		},
+		GenericFunc: func(_ event.TypedGenericEvent[*buildapi.BuildRun]) bool {
+			return false
+		},
+	}
+
+	// Enqueues the queued BuildRuns of a namespace
+	mgrClient := mgr.GetClient()
+	enqueueQueuedBuildRuns := func(ctx context.Context, namespaceName string) []reconcile.Request {
+		unfinishedBuildRuns, err := resources.ListUnfinishedBuildRuns(ctx, mgrClient, namespaceName, nil)
+		if err != nil {
+			ctxlog.Error(ctx, err, "failed to list the BuildRuns of a namespace", namespace, namespaceName)
+			return []reconcile.Request{}
+		}
+
+		requests := []reconcile.Request{}
+		for i := range unfinishedBuildRuns {
+			if resources.IsQueued(&unfinishedBuildRuns[i]) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      unfinishedBuildRuns[i].Name,
+						Namespace: unfinishedBuildRuns[i].Namespace,
+					},
+				})
+			}
+		}
+
+		return requests
+	}
+
+	// Watch for BuildRuns that finish to start the BuildRuns that were queued because of the
+	// concurrency policy of their Build or the concurrency limit of their namespace
+	if err = c.Watch(source.Kind(mgr.GetCache(), &buildapi.BuildRun{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, buildRun *buildapi.BuildRun) []reconcile.Request {
+		return enqueueQueuedBuildRuns(ctx, buildRun.Namespace)
+	}), predFinishedBuildRun)); err != nil {
+		return err
+	}
+
+	predNamespace := predicate.TypedFuncs[*corev1.Namespace]{
+		CreateFunc: func(_ event.TypedCreateEvent[*corev1.Namespace]) bool {
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Namespace]) bool {
+			return e.ObjectOld.GetAnnotations()[buildapi.AnnotationNamespaceConcurrencyLimit] != e.ObjectNew.GetAnnotations()[buildapi.AnnotationNamespaceConcurrencyLimit]
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*corev1.Namespace]) bool {
+			return false
+		},
+		GenericFunc: func(_ event.TypedGenericEvent[*corev1.Namespace]) bool {
+			return false
+		},
+	}
+
+	// Watch for changes of the concurrency limit of a namespace to start BuildRuns that were queued
+	if err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Namespace{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, ns *corev1.Namespace) []reconcile.Request {
+		return enqueueQueuedBuildRuns(ctx, ns.Name)
+	}), predNamespace)); err != nil {
+		return err
+	}
+
 	// Common handler for executor events
 	enqueueExecutorHandler := func(name, namespace string) []reconcile.Request {
 		return []reconcile.Request{
@@ -209,3 +286,8 @@ func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles in
 
 	return nil
 }
+
+// isFinished returns true if the BuildRun does not block any other BuildRun of its Build anymore
+func isFinished(buildRun *buildapi.BuildRun) bool {
+	return buildRun.IsDone() || buildRun.Status.CompletionTime != nil
+}
@@ -0,0 +1,70 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// ListUnfinishedBuildRuns returns the BuildRuns of a namespace that match the given labels and
+// are not done yet, ordered by their creation time
+func ListUnfinishedBuildRuns(ctx context.Context, c client.Client, namespace string, matchingLabels map[string]string) ([]buildapi.BuildRun, error) {
+	buildRunList := &buildapi.BuildRunList{}
+	if err := c.List(ctx, buildRunList, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(matchingLabels),
+	}); err != nil {
+		return nil, err
+	}
+
+	var result []buildapi.BuildRun
+	for _, buildRun := range buildRunList.Items {
+		if !buildRun.IsDone() && buildRun.Status.CompletionTime == nil {
+			result = append(result, buildRun)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return IsCreatedBefore(&result[i], &result[j])
+	})
+
+	return result, nil
+}
+
+// IsCreatedBefore returns true if the first BuildRun was created before the second one. BuildRuns
+// with the same creation timestamp are ordered by their name to get a stable order.
+func IsCreatedBefore(first *buildapi.BuildRun, second *buildapi.BuildRun) bool {
+	if !first.CreationTimestamp.Equal(&second.CreationTimestamp) {
+		return first.CreationTimestamp.Before(&second.CreationTimestamp)
+	}
+
+	return first.Name < second.Name
+}
+
+// IsQueued returns true if the BuildRun waits for its executor to be created, either because of
+// the concurrency policy of its Build or because of the concurrency limit of its namespace
+func IsQueued(buildRun *buildapi.BuildRun) bool {
+	if buildRun.Status.Executor != nil {
+		return false
+	}
+
+	condition := buildRun.Status.GetCondition(buildapi.Succeeded)
+	return condition != nil && (condition.Reason == buildapi.BuildRunStatePending || condition.Reason == buildapi.BuildRunStateQueued)
+}
+
+// QueuedSince returns the time from which a queued BuildRun waits for its executor. BuildRuns are
+// queued when they are reconciled for the first time, and the reason of their condition changes
+// between Pending and Queued while they wait, which resets its last transition time. Therefore,
+// the creation time of the BuildRun is used.
+func QueuedSince(buildRun *buildapi.BuildRun) time.Time {
+	return buildRun.CreationTimestamp.Time
+}
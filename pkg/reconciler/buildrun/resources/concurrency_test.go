@@ -0,0 +1,97 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	test "github.com/shipwright-io/build/test/v1beta1_samples"
+)
+
+var _ = Describe("Concurrent BuildRuns", func() {
+	var (
+		client *fakes.FakeClient
+		ctl    test.Catalog
+		now    time.Time
+	)
+
+	newBuildRun := func(name string, age time.Duration) buildapi.BuildRun {
+		buildRun := ctl.DefaultBuildRun(name, "foobuild")
+		buildRun.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		return *buildRun
+	}
+
+	BeforeEach(func() {
+		client = &fakes.FakeClient{}
+		now = time.Now()
+	})
+
+	Context("ListUnfinishedBuildRuns", func() {
+		It("returns the unfinished BuildRuns ordered by their creation time", func() {
+			finished := newBuildRun("finished", 3*time.Minute)
+			finished.Status.CompletionTime = &metav1.Time{Time: now}
+			finished.Status.SetCondition(&buildapi.Condition{Type: buildapi.Succeeded, Status: corev1.ConditionFalse})
+
+			client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+				list.(*buildapi.BuildRunList).Items = []buildapi.BuildRun{
+					newBuildRun("newest", time.Second),
+					finished,
+					newBuildRun("oldest", 2*time.Minute),
+					newBuildRun("middle-b", time.Minute),
+					newBuildRun("middle-a", time.Minute),
+				}
+				return nil
+			})
+
+			buildRuns, err := resources.ListUnfinishedBuildRuns(context.TODO(), client, "default", map[string]string{buildapi.LabelBuild: "foobuild"})
+			Expect(err).ToNot(HaveOccurred())
+
+			var names []string
+			for _, buildRun := range buildRuns {
+				names = append(names, buildRun.Name)
+			}
+			Expect(names).To(Equal([]string{"oldest", "middle-a", "middle-b", "newest"}))
+		})
+	})
+
+	Context("IsQueued", func() {
+		It("is only true for queued BuildRuns without an executor", func() {
+			buildRun := newBuildRun("queued", time.Second)
+			Expect(resources.IsQueued(&buildRun)).To(BeFalse())
+
+			buildRun.Status.SetCondition(&buildapi.Condition{Type: buildapi.Succeeded, Status: corev1.ConditionUnknown, Reason: buildapi.BuildRunStatePending})
+			Expect(resources.IsQueued(&buildRun)).To(BeTrue())
+
+			buildRun.Status.SetCondition(&buildapi.Condition{Type: buildapi.Succeeded, Status: corev1.ConditionUnknown, Reason: buildapi.BuildRunStateQueued})
+			Expect(resources.IsQueued(&buildRun)).To(BeTrue())
+
+			buildRun.Status.Executor = &buildapi.BuildExecutor{Name: "queued-xyz", Kind: "TaskRun"}
+			Expect(resources.IsQueued(&buildRun)).To(BeFalse())
+		})
+	})
+
+	Context("QueuedSince", func() {
+		It("returns the creation time when the reason changes while the BuildRun waits", func() {
+			buildRun := newBuildRun("queued", 10*time.Minute)
+
+			// held by the concurrency policy of the Build first, and then by the limit of the namespace
+			buildRun.Status.SetCondition(&buildapi.Condition{Type: buildapi.Succeeded, Status: corev1.ConditionUnknown, Reason: buildapi.BuildRunStatePending, LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Minute))})
+			buildRun.Status.SetCondition(&buildapi.Condition{Type: buildapi.Succeeded, Status: corev1.ConditionUnknown, Reason: buildapi.BuildRunStateQueued, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))})
+
+			Expect(resources.IsQueued(&buildRun)).To(BeTrue())
+			Expect(resources.QueuedSince(&buildRun)).To(BeTemporally("==", now.Add(-10*time.Minute)))
+		})
+	})
+})
@@ -46,6 +46,7 @@ const (
 	BuildRunNoRefOrSpec                              string = "BuildRunNoRefOrSpec"
 	BuildRunAmbiguousBuild                           string = "BuildRunAmbiguousBuild"
 	BuildRunBuildFieldOverrideForbidden              string = "BuildRunBuildFieldOverrideForbidden"
+	BuildRunPullRequestInvalid                       string = "BuildRunPullRequestInvalid"
 )
 
 // UpdateBuildRunUsingTaskRunCondition updates the BuildRun Succeeded Condition
@@ -164,6 +165,12 @@ func UpdateBuildRunUsingTaskRunCondition(ctx context.Context, client client.Clie
 					pod.Name,
 				)
 			}
+
+			// a step that exceeded the timeout of its phase
+			if timedOut, ok := taskRunStepTimeout(taskRun); ok {
+				reason = "BuildRunTimeout"
+				message = timedOut.message(buildRun.Name)
+			}
 		}
 	}
 
@@ -284,6 +291,22 @@ func extractPipelineRunFailureDetails(ctx context.Context, client client.Client,
 			// Check if this TaskRun failed
 			condition := taskRun.Status.GetCondition(apis.ConditionSucceeded)
 			if condition != nil && condition.Status == corev1.ConditionFalse {
+				// the task of a phase that exceeded the timeout of the phase
+				if pipelineapi.TaskRunReason(condition.Reason) == pipelineapi.TaskRunReasonTimedOut {
+					if timedOut, ok := pipelineTaskTimeout(taskRun); ok {
+						message := timedOut.message(taskRun.Labels[buildapi.LabelBuildRun])
+						return &PipelineRunFailureDetails{
+							Reason:  "BuildRunTimeout",
+							Message: message,
+							FailureDetails: &buildapi.FailureDetails{
+								Reason:   timedOut.failureReason(),
+								Message:  message,
+								Location: &buildapi.Location{Pod: taskRun.Status.PodName},
+							},
+						}, nil
+					}
+				}
+
 				// Extract failure details from this TaskRun
 				pod, failedContainer, failedContainerStatus, err := extractFailedPodAndContainer(ctx, client, taskRun)
 				if err != nil {
@@ -393,6 +416,36 @@ func UpdateConditionWithFalseStatus(ctx context.Context, client client.Client, b
 	return nil
 }
 
+// UpdateConditionWithUnknownStatus sets the Succeeded condition to Status Unknown, for
+// BuildRuns that are queued before their executor is created. The LastTransitionTime is
+// kept as long as the reason does not change, and the object in the cluster is only
+// updated if the condition changed.
+func UpdateConditionWithUnknownStatus(ctx context.Context, client client.Client, buildRun *buildapi.BuildRun, message string, reason string) error {
+	lastTransitionTime := metav1.Now()
+	if condition := buildRun.Status.GetCondition(buildapi.Succeeded); condition != nil &&
+		condition.Status == corev1.ConditionUnknown &&
+		condition.Reason == reason {
+		if condition.Message == message {
+			return nil
+		}
+		lastTransitionTime = condition.LastTransitionTime
+	}
+
+	buildRun.Status.SetCondition(&buildapi.Condition{
+		LastTransitionTime: lastTransitionTime,
+		Type:               buildapi.Succeeded,
+		Status:             corev1.ConditionUnknown,
+		Reason:             reason,
+		Message:            message,
+	})
+	ctxlog.Debug(ctx, "updating buildRun status", namespace, buildRun.Namespace, name, buildRun.Name, "reason", reason)
+	if err := client.Status().Update(ctx, buildRun); err != nil {
+		return &ClientStatusUpdateError{err}
+	}
+
+	return nil
+}
+
 // UpdateImageBuildRunFromExecutor updates the BuildRun status based on the executor object type
 func UpdateImageBuildRunFromExecutor(ctx context.Context, client client.Client, buildRun *buildapi.BuildRun, executorObj client.Object, conditions *apis.Condition) error {
 	if taskRunObj, ok := executorObj.(*pipelineapi.TaskRun); ok {
@@ -6,6 +6,7 @@ package resources_test
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -408,6 +409,80 @@ var _ = Describe("Conditions", func() {
 			).To(Equal(buildapi.BuildRunStateStepOutOfMemory))
 		})
 
+		It("updates BuildRun condition and failure details when a step exceeds the timeout of its phase", func() {
+			timedOutPod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foobar-pod",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "step-source-default",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-source-default",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode: 1,
+							},
+						},
+					}},
+				},
+			}
+
+			client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+				switch object := object.(type) {
+				case *corev1.Pod:
+					timedOutPod.DeepCopyInto(object)
+					return nil
+				}
+				return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+			})
+
+			timedOutTaskRun := tr.DeepCopy()
+			timedOutTaskRun.Labels = map[string]string{buildapi.LabelBuildRun: br.Name}
+			timedOutTaskRun.Spec.TaskSpec = &pipelineapi.TaskSpec{
+				Steps: []pipelineapi.Step{{
+					Name:    "source-default",
+					Timeout: &metav1.Duration{Duration: time.Minute},
+				}},
+			}
+			timedOutTaskRun.Status.Steps = []pipelineapi.StepState{{
+				Name:              "source-default",
+				Container:         "step-source-default",
+				TerminationReason: "TimeoutExceeded",
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+				},
+			}}
+			timedOutTaskRun.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "Failed",
+			})
+
+			Expect(resources.UpdateBuildRunUsingTaskRunCondition(
+				context.TODO(),
+				client,
+				br,
+				timedOutTaskRun,
+				timedOutTaskRun.Status.GetCondition(apis.ConditionSucceeded),
+			)).To(BeNil())
+
+			condition := br.Status.GetCondition(buildapi.Succeeded)
+			Expect(condition.Reason).To(Equal("BuildRunTimeout"))
+			Expect(condition.Message).To(Equal("BuildRun foo failed to finish the source phase within 1m0s"))
+
+			resources.UpdateBuildRunUsingTaskFailures(context.TODO(), client, br, timedOutTaskRun)
+			Expect(br.Status.FailureDetails).ToNot(BeNil())
+			Expect(br.Status.FailureDetails.Reason).To(Equal(buildapi.SourcePhaseTimeout))
+			Expect(br.Status.FailureDetails.Location.Container).To(Equal("step-source-default"))
+		})
+
 		It("updates a BuildRun condition when the related TaskRun fails and pod containers are not available", func() {
 
 			taskRunGeneratedPod := corev1.Pod{
@@ -101,6 +101,10 @@ func extractFailureDetails(ctx context.Context, client client.Client, taskRun *p
 	failure = &buildapi.FailureDetails{}
 
 	failure.Reason, failure.Message = extractFailureReasonAndMessage(taskRun)
+	if timedOut, ok := taskRunStepTimeout(taskRun); ok && failure.Reason == "" {
+		failure.Reason = timedOut.failureReason()
+		failure.Message = timedOut.message(taskRun.Labels[buildapi.LabelBuildRun])
+	}
 
 	failure.Location = &buildapi.Location{Pod: taskRun.Status.PodName}
 	pod, container, _, _ := extractFailedPodAndContainer(ctx, client, taskRun)
@@ -7,7 +7,9 @@ package resources
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -16,6 +18,7 @@ import (
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/image"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
 )
 
@@ -98,10 +101,11 @@ func SetupOutputDirectory(taskSpec *pipelineapi.TaskSpec, taskRunParams *[]pipel
 }
 
 // BuildImageProcessingArgs builds the argument list for the image-processing step
-// based on output configuration (labels, annotations, vulnerability scanning, timestamps).
+// based on output configuration (labels, annotations, vulnerability scanning, timestamps, tags).
 func BuildImageProcessingArgs(
 	cfg *config.Config,
 	creationTimestamp time.Time,
+	buildRunName string,
 	buildOutput, buildRunOutput buildapi.Image,
 	hasOutputDirectory bool,
 	hasSourceTimestamp bool,
@@ -157,17 +161,143 @@ func BuildImageProcessingArgs(
 		}
 	}
 
+	tagArgs, err := buildImageTagArgs(creationTimestamp, buildRunName, buildOutput, buildRunOutput, hasOutputDirectory)
+	if err != nil {
+		return nil, err
+	}
+	stepArgs = append(stepArgs, tagArgs...)
+
 	if len(stepArgs) > 0 {
-		stepArgs = append(stepArgs, "--image", fmt.Sprintf("$(params.%s-%s)", prefixParamsResultsVolumes, paramOutputImage))
+		// the parameter of the output image is passed to the strategy without the placeholders
+		// in its tag, the image processing resolves them in the original output image
+		if outputImage, _ := outputImageTags(buildOutput, buildRunOutput); len(image.TagPlaceholders(outputImage)) > 0 {
+			stepArgs = append(stepArgs, "--image", outputImage)
+		} else {
+			stepArgs = append(stepArgs, "--image", fmt.Sprintf("$(params.%s-%s)", prefixParamsResultsVolumes, paramOutputImage))
+		}
 		stepArgs = append(stepArgs, fmt.Sprintf("--insecure=$(params.%s-%s)", prefixParamsResultsVolumes, paramOutputInsecure))
 		stepArgs = append(stepArgs, "--result-file-image-digest", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageDigestResult))
 		stepArgs = append(stepArgs, "--result-file-image-size", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageSizeResult))
 		stepArgs = append(stepArgs, "--result-file-image-vulnerabilities", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageVulnerabilities))
+		stepArgs = append(stepArgs, "--result-file-image-tags", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageTagsResult))
 	}
 
 	return stepArgs, nil
 }
 
+// buildImageTagArgs returns the arguments for the additional tags of the output image and
+// for the values of the placeholders that are used in the tag of the image and in the
+// additional tags
+func buildImageTagArgs(creationTimestamp time.Time, buildRunName string, buildOutput, buildRunOutput buildapi.Image, hasOutputDirectory bool) ([]string, error) {
+	outputImage, additionalTags := outputImageTags(buildOutput, buildRunOutput)
+
+	placeholders := image.TagPlaceholders(outputImage)
+	if len(placeholders) > 0 && !hasOutputDirectory {
+		return nil, fmt.Errorf("cannot use placeholders in the tag of the output image, because the build strategy pushes the image itself")
+	}
+
+	args := []string{}
+	for _, additionalTag := range additionalTags {
+		args = append(args, "--additional-tag", additionalTag)
+		placeholders = append(placeholders, image.TagPlaceholders(additionalTag)...)
+	}
+
+	if slices.Contains(placeholders, buildapi.OutputImageTagBuildRunName) {
+		args = append(args, "--buildrun-name", buildRunName)
+	}
+	if slices.Contains(placeholders, buildapi.OutputImageTagDate) {
+		args = append(args, "--build-timestamp", strconv.FormatInt(creationTimestamp.Unix(), 10))
+	}
+	if slices.Contains(placeholders, buildapi.OutputImageTagGitCommitSha) {
+		args = append(args, "--git-commit-sha-file", "$(results.shp-source-default-commit-sha.path)")
+	}
+	if slices.Contains(placeholders, buildapi.OutputImageTagGitDescribe) {
+		args = append(args, "--git-describe-file", "$(results.shp-source-default-describe.path)")
+	}
+
+	return args, nil
+}
+
+// outputImageTags returns the output image and the additional tags, the ones of the BuildRun
+// take precedence over the ones of the Build
+func outputImageTags(buildOutput, buildRunOutput buildapi.Image) (string, []string) {
+	outputImage := buildOutput.Image
+	if buildRunOutput.Image != "" {
+		outputImage = buildRunOutput.Image
+	}
+
+	additionalTags := buildOutput.AdditionalTags
+	if buildRunOutput.AdditionalTags != nil {
+		additionalTags = buildRunOutput.AdditionalTags
+	}
+
+	return outputImage, additionalTags
+}
+
+// usesOutputTagPlaceholder returns whether the tag of the output image or one of the
+// additional tags uses the placeholder
+func usesOutputTagPlaceholder(build *buildapi.Build, buildRun *buildapi.BuildRun, placeholder string) bool {
+	var buildRunOutput buildapi.Image
+	if buildRun.Spec.Output != nil {
+		buildRunOutput = *buildRun.Spec.Output
+	}
+
+	outputImage, additionalTags := outputImageTags(build.Spec.Output, buildRunOutput)
+	if slices.Contains(image.TagPlaceholders(outputImage), placeholder) {
+		return true
+	}
+
+	return slices.ContainsFunc(additionalTags, func(additionalTag string) bool {
+		return slices.Contains(image.TagPlaceholders(additionalTag), placeholder)
+	})
+}
+
+// appendPlatformDirectoryArgs replaces the push of the output directory with the
+// per-platform output directories that are assembled into an image index
+func appendPlatformDirectoryArgs(stepArgs []string, platforms []buildapi.ImagePlatform) []string {
+	result := []string{}
+	for i := 0; i < len(stepArgs); i++ {
+		if stepArgs[i] == "--push" {
+			i++
+			continue
+		}
+		result = append(result, stepArgs[i])
+	}
+
+	for _, platform := range platforms {
+		result = append(result, "--platform-directory", fmt.Sprintf("%s/%s=$(params.%s-%s)/%s", platform.OS, platform.Arch, prefixParamsResultsVolumes, paramOutputDirectory, platformDirectoryName(platform)))
+	}
+
+	return append(result, "--result-file-image-platforms", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imagePlatformsResult))
+}
+
+// replaceSourceResultFileArgs replaces the arguments that read a result of the source step
+// from a file with arguments that take its value from a parameter, because the source is
+// acquired in a different task of a PipelineRun. It returns the names of the results.
+func replaceSourceResultFileArgs(stepArgs []string) ([]string, []string) {
+	sourceResultFileArgs := map[string]string{
+		"--git-commit-sha-file": "--git-commit-sha",
+		"--git-describe-file":   "--git-describe",
+	}
+
+	result := []string{}
+	sourceResults := []string{}
+	for i := 0; i < len(stepArgs); i++ {
+		arg, ok := sourceResultFileArgs[stepArgs[i]]
+		if !ok || i+1 == len(stepArgs) {
+			result = append(result, stepArgs[i])
+			continue
+		}
+
+		i++
+		sourceResult := strings.TrimSuffix(strings.TrimPrefix(stepArgs[i], "$(results."), ".path)")
+		sourceResults = append(sourceResults, sourceResult)
+		result = append(result, arg, fmt.Sprintf("$(params.%s)", sourceResult))
+	}
+
+	return result, sourceResults
+}
+
 func CreateImageProcessingStep(
 	cfg *config.Config,
 	taskSpec *pipelineapi.TaskSpec,
@@ -239,7 +369,7 @@ func CreateImageProcessingStep(
 }
 
 // SetupImageProcessing configures image processing for TaskRun execution.
-func SetupImageProcessing(taskRun *pipelineapi.TaskRun, cfg *config.Config, creationTimestamp time.Time, buildOutput, buildRunOutput buildapi.Image) error {
+func SetupImageProcessing(taskRun *pipelineapi.TaskRun, cfg *config.Config, creationTimestamp time.Time, buildRunName string, buildOutput, buildRunOutput buildapi.Image) error {
 	params := []pipelineapi.Param(taskRun.Spec.Params)
 	hasOutputDirectory := SetupOutputDirectory(taskRun.Spec.TaskSpec, &params)
 	taskRun.Spec.Params = pipelineapi.Params(params)
@@ -248,6 +378,7 @@ func SetupImageProcessing(taskRun *pipelineapi.TaskRun, cfg *config.Config, crea
 	stepArgs, err := BuildImageProcessingArgs(
 		cfg,
 		creationTimestamp,
+		buildRunName,
 		buildOutput,
 		buildRunOutput,
 		hasOutputDirectory,
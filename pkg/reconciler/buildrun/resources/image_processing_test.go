@@ -45,6 +45,7 @@ var _ = Describe("Image Processing overrides", func() {
 					processedTaskRun,
 					config,
 					refTimestamp,
+					"sample-buildrun",
 					buildapi.Image{Image: "some-registry/some-namespace/some-image"},
 					buildapi.Image{},
 				)).To(Succeed())
@@ -63,6 +64,7 @@ var _ = Describe("Image Processing overrides", func() {
 					processedTaskRun,
 					config,
 					refTimestamp,
+					"sample-buildrun",
 					buildapi.Image{
 						Image: "some-registry/some-namespace/some-image",
 						Labels: map[string]string{
@@ -90,15 +92,56 @@ var _ = Describe("Image Processing overrides", func() {
 					"$(results.shp-image-size.path)",
 					"--result-file-image-vulnerabilities",
 					"$(results.shp-image-vulnerabilities.path)",
+					"--result-file-image-tags",
+					"$(results.shp-image-tags.path)",
 				}))
 				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].VolumeMounts).ToNot(utils.ContainNamedElement("shp-output-directory"))
 			})
 		})
 
+		Context("for a build with an additional tag in the output", func() {
+			BeforeEach(func() {
+				processedTaskRun = taskRun.DeepCopy()
+				Expect(resources.SetupImageProcessing(processedTaskRun, config, refTimestamp, "sample-buildrun", buildapi.Image{
+					Image:          "some-registry/some-namespace/some-image:v1.2.3",
+					AdditionalTags: []string{"latest"},
+				}, buildapi.Image{})).To(Succeed())
+			})
+
+			It("adds the image-processing step", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Steps).To(HaveLen(2))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Name).To(Equal("image-processing"))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Args).To(Equal([]string{
+					"--additional-tag",
+					"latest",
+					"--image",
+					"$(params.shp-output-image)",
+					"--insecure=$(params.shp-output-insecure)",
+					"--result-file-image-digest",
+					"$(results.shp-image-digest.path)",
+					"--result-file-image-size",
+					"$(results.shp-image-size.path)",
+					"--result-file-image-vulnerabilities",
+					"$(results.shp-image-vulnerabilities.path)",
+					"--result-file-image-tags",
+					"$(results.shp-image-tags.path)",
+				}))
+			})
+		})
+
+		Context("for a build with a placeholder in the tag of the output", func() {
+			It("fails because the build strategy pushes the image", func() {
+				processedTaskRun = taskRun.DeepCopy()
+				Expect(resources.SetupImageProcessing(processedTaskRun, config, refTimestamp, "sample-buildrun", buildapi.Image{
+					Image: "some-registry/some-namespace/some-image:$(buildrun.name)",
+				}, buildapi.Image{})).To(MatchError("cannot use placeholders in the tag of the output image, because the build strategy pushes the image itself"))
+			})
+		})
+
 		Context("for a build with a vulnerability scan options in the output", func() {
 			BeforeEach(func() {
 				processedTaskRun = taskRun.DeepCopy()
-				Expect(resources.SetupImageProcessing(processedTaskRun, config, refTimestamp, buildapi.Image{
+				Expect(resources.SetupImageProcessing(processedTaskRun, config, refTimestamp, "sample-buildrun", buildapi.Image{
 					Image: "some-registry/some-namespace/some-image",
 					VulnerabilityScan: &buildapi.VulnerabilityScanOptions{
 						Enabled: true,
@@ -125,6 +168,8 @@ var _ = Describe("Image Processing overrides", func() {
 					"$(results.shp-image-size.path)",
 					"--result-file-image-vulnerabilities",
 					"$(results.shp-image-vulnerabilities.path)",
+					"--result-file-image-tags",
+					"$(results.shp-image-tags.path)",
 				}))
 				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].VolumeMounts).ToNot(utils.ContainNamedElement("shp-output-directory"))
 			})
@@ -158,6 +203,7 @@ var _ = Describe("Image Processing overrides", func() {
 						processedTaskRun,
 						config,
 						refTimestamp,
+						"sample-buildrun",
 						buildapi.Image{
 							Image: "some-registry/some-namespace/some-image",
 							Labels: map[string]string{
@@ -202,6 +248,8 @@ var _ = Describe("Image Processing overrides", func() {
 						"$(results.shp-image-size.path)",
 						"--result-file-image-vulnerabilities",
 						"$(results.shp-image-vulnerabilities.path)",
+						"--result-file-image-tags",
+						"$(results.shp-image-tags.path)",
 					}))
 					Expect(processedTaskRun.Spec.TaskSpec.Steps[1].VolumeMounts).To(utils.ContainNamedElement("shp-output-directory"))
 				})
@@ -214,6 +262,7 @@ var _ = Describe("Image Processing overrides", func() {
 						processedTaskRun,
 						config,
 						refTimestamp,
+						"sample-buildrun",
 						buildapi.Image{Image: "some-registry/some-namespace/some-image"},
 						buildapi.Image{},
 					)).To(Succeed())
@@ -245,12 +294,64 @@ var _ = Describe("Image Processing overrides", func() {
 						"$(results.shp-image-size.path)",
 						"--result-file-image-vulnerabilities",
 						"$(results.shp-image-vulnerabilities.path)",
+						"--result-file-image-tags",
+						"$(results.shp-image-tags.path)",
 					}))
 					Expect(processedTaskRun.Spec.TaskSpec.Steps[1].VolumeMounts).To(utils.ContainNamedElement("shp-output-directory"))
 				})
 			})
 		})
 
+		Context("for a build with placeholders in the tags of the output", func() {
+			BeforeEach(func() {
+				processedTaskRun = taskRun.DeepCopy()
+				Expect(resources.SetupImageProcessing(
+					processedTaskRun,
+					config,
+					refTimestamp,
+					"sample-buildrun",
+					buildapi.Image{
+						Image:          "some-registry/some-namespace/some-image:$(source.git.commitSha)",
+						AdditionalTags: []string{"latest"},
+					},
+					buildapi.Image{
+						AdditionalTags: []string{"$(buildrun.name)", "$(source.git.describe)-$(date)"},
+					},
+				)).To(Succeed())
+			})
+
+			It("adds the additional tags of the BuildRun and the values of the placeholders", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Steps).To(HaveLen(2))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Args).To(Equal([]string{
+					"--push",
+					"$(params.shp-output-directory)",
+					"--additional-tag",
+					"$(buildrun.name)",
+					"--additional-tag",
+					"$(source.git.describe)-$(date)",
+					"--buildrun-name",
+					"sample-buildrun",
+					"--build-timestamp",
+					"1234567890",
+					"--git-commit-sha-file",
+					"$(results.shp-source-default-commit-sha.path)",
+					"--git-describe-file",
+					"$(results.shp-source-default-describe.path)",
+					"--image",
+					"some-registry/some-namespace/some-image:$(source.git.commitSha)",
+					"--insecure=$(params.shp-output-insecure)",
+					"--result-file-image-digest",
+					"$(results.shp-image-digest.path)",
+					"--result-file-image-size",
+					"$(results.shp-image-size.path)",
+					"--result-file-image-vulnerabilities",
+					"$(results.shp-image-vulnerabilities.path)",
+					"--result-file-image-tags",
+					"$(results.shp-image-tags.path)",
+				}))
+			})
+		})
+
 		Context("for a build with an output with a secret", func() {
 			BeforeEach(func() {
 				processedTaskRun = taskRun.DeepCopy()
@@ -259,6 +360,7 @@ var _ = Describe("Image Processing overrides", func() {
 					processedTaskRun,
 					config,
 					refTimestamp,
+					"sample-buildrun",
 					buildapi.Image{
 						Image:      "some-registry/some-namespace/some-image",
 						PushSecret: &someSecret,
@@ -297,6 +399,8 @@ var _ = Describe("Image Processing overrides", func() {
 					"$(results.shp-image-size.path)",
 					"--result-file-image-vulnerabilities",
 					"$(results.shp-image-vulnerabilities.path)",
+					"--result-file-image-tags",
+					"$(results.shp-image-tags.path)",
 					"--secret-path",
 					"/workspace/shp-push-secret",
 				}))
@@ -0,0 +1,93 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const (
+	trustedCAVolumeName = "shp-trusted-ca"
+	trustedCAMountPath  = "/workspace/shp-trusted-ca"
+	trustedCAFileName   = "ca-bundle.crt"
+)
+
+// applyTrustedCA mounts the ConfigMap with the certificate authorities of the Build into
+// the step and passes the CA bundle to it, the step must support the --ca-bundle argument
+func applyTrustedCA(taskSpec *pipelineapi.TaskSpec, step *pipelineapi.Step, trustedCA *buildapi.TrustedCA) {
+	if trustedCA == nil {
+		return
+	}
+
+	if !slices.ContainsFunc(taskSpec.Volumes, func(volume corev1.Volume) bool { return volume.Name == trustedCAVolumeName }) {
+		taskSpec.Volumes = append(taskSpec.Volumes, corev1.Volume{
+			Name: trustedCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: trustedCA.ConfigMap},
+					Items: []corev1.KeyToPath{{
+						Key:  trustedCA.GetKey(),
+						Path: trustedCAFileName,
+					}},
+				},
+			},
+		})
+	}
+
+	step.VolumeMounts = append(step.VolumeMounts, corev1.VolumeMount{
+		Name:      trustedCAVolumeName,
+		MountPath: trustedCAMountPath,
+		ReadOnly:  true,
+	})
+
+	step.Args = append(step.Args, "--ca-bundle", fmt.Sprintf("%s/%s", trustedCAMountPath, trustedCAFileName))
+}
+
+// applyProxy sets the proxy environment variables of the Build on the step, both in upper
+// and lower case, because the tools in the steps differ in the variables that they read
+func applyProxy(step *pipelineapi.Step, proxy *buildapi.Proxy) {
+	if proxy == nil {
+		return
+	}
+
+	// the environment variables of the step can be the ones of the container template in the configuration
+	step.Env = slices.Clone(step.Env)
+
+	for _, envVar := range []struct {
+		name  string
+		value *string
+	}{
+		{name: "HTTP_PROXY", value: proxy.HTTPProxy},
+		{name: "HTTPS_PROXY", value: proxy.HTTPSProxy},
+		{name: "NO_PROXY", value: proxy.NoProxy},
+	} {
+		if envVar.value == nil {
+			continue
+		}
+
+		for _, name := range []string{envVar.name, strings.ToLower(envVar.name)} {
+			step.Env = slices.DeleteFunc(step.Env, func(env corev1.EnvVar) bool { return env.Name == name })
+			step.Env = append(step.Env, corev1.EnvVar{Name: name, Value: *envVar.value})
+		}
+	}
+}
+
+// applyNetworkSettingsToImageProcessing applies the trusted certificate authorities and the
+// proxy of the Build to the image processing step of the TaskSpec, if there is one
+func applyNetworkSettingsToImageProcessing(taskSpec *pipelineapi.TaskSpec, build *buildapi.Build) {
+	for i := range taskSpec.Steps {
+		if taskSpec.Steps[i].Name == containerNameImageProcessing {
+			applyTrustedCA(taskSpec, &taskSpec.Steps[i], build.Spec.TrustedCA)
+			applyProxy(&taskSpec.Steps[i], build.Spec.Proxy)
+		}
+	}
+}
@@ -67,6 +67,21 @@ func IsSystemReservedParameter(param string) bool {
 	return systemReservedParamKeys[param] || strings.HasPrefix(param, "shp-")
 }
 
+// IsSystemParameter verifies if a parameter name is one of the parameters
+// that are provided by the system to every build strategy
+func IsSystemParameter(param string) bool {
+	switch param {
+	case fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramOutputImage),
+		fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramOutputInsecure),
+		fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramSourceRoot),
+		fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramSourceContext),
+		fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramOutputDirectory):
+		return true
+	default:
+		return false
+	}
+}
+
 // FindParameterByName returns the first entry in a Parameter array with a specified name, or nil
 func FindParameterByName(parameters []buildapi.Parameter, name string) *buildapi.Parameter {
 	for _, candidate := range parameters {
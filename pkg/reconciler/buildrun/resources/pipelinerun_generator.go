@@ -5,6 +5,7 @@
 package resources
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
@@ -23,8 +24,9 @@ import (
 //   - build-strategy
 //   - output-image
 //
-// Tasks communicate via shared workspace (PVC). This enables future extensions like
-// parallel multi-arch builds.
+// Tasks communicate via shared workspace (PVC). For multi-arch builds, the build-strategy
+// Task is replaced by one Task per platform, and the output-image Task assembles the
+// per-platform images into an image index.
 type PipelineRunGenerator struct {
 	cfg                *config.Config
 	build              *buildapi.Build
@@ -56,12 +58,22 @@ func NewPipelineRunGenerator(
 func (g *PipelineRunGenerator) InitializeExecutor() error {
 	pipelineSpec := createBasePipelineSpec()
 
+	// the build tasks of a multi-platform build run on nodes of different architectures,
+	// they can only share the source volume if it can be mounted on multiple nodes
+	var storageClassName string
+	if len(mergedPlatforms(g.build, g.buildRun)) > 0 {
+		if g.cfg.MultiPlatformStorageClass == "" {
+			return errors.New("building for multiple platforms requires a storage class that provides ReadWriteMany volumes, which is not configured in the controller")
+		}
+		storageClassName = g.cfg.MultiPlatformStorageClass
+	}
+
 	g.pipelineRun = &pipelineapi.PipelineRun{
 		ObjectMeta: generateTaskRunMetadata(g.build, g.buildRun),
 		Spec: pipelineapi.PipelineRunSpec{
 			PipelineSpec:    pipelineSpec,
 			TaskRunTemplate: generatePipelineTaskRunTemplate(g.serviceAccountName),
-			Workspaces:      generatePipelineWorkspaceBindings(),
+			Workspaces:      generatePipelineWorkspaceBindings(storageClassName),
 		},
 	}
 
@@ -74,6 +86,7 @@ func (g *PipelineRunGenerator) GenerateSourceAcquisitionPhase(_ *executionContex
 	g.applySecurityContextToTaskSpec(taskSpec)
 
 	pipelineTask := createSourceAcquisitionPipelineTask(taskSpec)
+	pipelineTask.Timeout = effectivePhaseTimeouts(g.build, g.buildRun).Source
 	g.pipelineTasks = append(g.pipelineTasks, pipelineTask)
 
 	return nil
@@ -121,8 +134,37 @@ func (g *PipelineRunGenerator) GenerateBuildStrategyPhase(execCtx *executionCont
 	addStrategyParametersToPipelineSpec(g.pipelineRun.Spec.PipelineSpec, g.strategy.GetParameters())
 	g.applySecurityContextToTaskSpec(taskSpec)
 
-	pipelineTask := createBuildStrategyPipelineTask(taskSpec, g.strategy)
-	g.pipelineTasks = append(g.pipelineTasks, pipelineTask)
+	platforms := mergedPlatforms(g.build, g.buildRun)
+	if len(platforms) == 0 {
+		pipelineTask := createBuildStrategyPipelineTask(taskSpec, g.strategy)
+		pipelineTask.Timeout = effectivePhaseTimeouts(g.build, g.buildRun).Build
+		g.pipelineTasks = append(g.pipelineTasks, pipelineTask)
+		return nil
+	}
+
+	// the per-platform images are stored in sub-directories of the output directory,
+	// which requires the strategy to store its image there instead of pushing it
+	if !execCtx.hasOutputDirectory {
+		return fmt.Errorf("the build strategy %q does not use the parameter %s-%s, which is required to build for multiple platforms", g.strategy.GetName(), prefixParamsResultsVolumes, paramOutputDirectory)
+	}
+
+	for _, platform := range platforms {
+		pipelineTask := createPlatformBuildStrategyPipelineTask(taskSpec.DeepCopy(), g.strategy, platform)
+		pipelineTask.Timeout = effectivePhaseTimeouts(g.build, g.buildRun).Build
+		g.pipelineTasks = append(g.pipelineTasks, pipelineTask)
+
+		// the node selector of a task replaces the one of the task run template, therefore
+		// it includes the node selector of the Build and BuildRun
+		nodeSelector := MergeMaps(map[string]string{}, g.build.Spec.NodeSelector)
+		nodeSelector = MergeMaps(nodeSelector, g.buildRun.Spec.NodeSelector)
+		nodeSelector[corev1.LabelOSStable] = platform.OS
+		nodeSelector[corev1.LabelArchStable] = platform.Arch
+
+		g.pipelineRun.Spec.TaskRunSpecs = append(g.pipelineRun.Spec.TaskRunSpecs, pipelineapi.PipelineTaskRunSpec{
+			PipelineTaskName: pipelineTask.Name,
+			PodTemplate:      &pod.PodTemplate{NodeSelector: nodeSelector},
+		})
+	}
 
 	return nil
 }
@@ -137,6 +179,7 @@ func (g *PipelineRunGenerator) GenerateOutputImagePhase(execCtx *executionContex
 	stepArgs, err := BuildImageProcessingArgs(
 		g.cfg,
 		g.buildRun.CreationTimestamp.Time,
+		g.buildRun.Name,
 		g.build.Spec.Output,
 		*buildRunOutput,
 		execCtx.hasOutputDirectory,
@@ -152,6 +195,19 @@ func (g *PipelineRunGenerator) GenerateOutputImagePhase(execCtx *executionContex
 
 	taskSpec := createBaseTaskSpec()
 
+	platforms := mergedPlatforms(g.build, g.buildRun)
+	if len(platforms) > 0 {
+		stepArgs = appendPlatformDirectoryArgs(stepArgs, platforms)
+	}
+
+	stepArgs, sourceResults := replaceSourceResultFileArgs(stepArgs)
+	for _, sourceResult := range sourceResults {
+		taskSpec.Params = append(taskSpec.Params, pipelineapi.ParamSpec{
+			Name: sourceResult,
+			Type: pipelineapi.ParamTypeString,
+		})
+	}
+
 	if execCtx.hasOutputDirectory {
 		prefixedOutputDirectory := fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramOutputDirectory)
 		taskSpec.Params = append(taskSpec.Params, pipelineapi.ParamSpec{
@@ -170,10 +226,31 @@ func (g *PipelineRunGenerator) GenerateOutputImagePhase(execCtx *executionContex
 	); err != nil {
 		return err
 	}
+	applyNetworkSettingsToImageProcessing(taskSpec, g.build)
+
+	if len(platforms) > 0 {
+		taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, imagePlatformsResult),
+			Description: "The manifest digest of each platform of the image index",
+		})
+	}
 
 	g.applySecurityContextToTaskSpec(taskSpec)
 
 	pipelineTask := createOutputImagePipelineTask(taskSpec, execCtx.hasOutputDirectory)
+	for _, sourceResult := range sourceResults {
+		pipelineTask.Params = append(pipelineTask.Params, pipelineapi.Param{
+			Name: sourceResult,
+			Value: pipelineapi.ParamValue{
+				Type:      pipelineapi.ParamTypeString,
+				StringVal: fmt.Sprintf("$(tasks.source-acquisition.results.%s)", sourceResult),
+			},
+		})
+	}
+	pipelineTask.Timeout = effectivePhaseTimeouts(g.build, g.buildRun).Output
+	if len(platforms) > 0 {
+		pipelineTask.RunAfter = platformBuildStrategyPipelineTaskNames(platforms)
+	}
 	g.pipelineTasks = append(g.pipelineTasks, pipelineTask)
 
 	return nil
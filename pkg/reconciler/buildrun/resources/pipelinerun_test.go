@@ -0,0 +1,259 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	test "github.com/shipwright-io/build/test/v1beta1_samples"
+)
+
+var _ = Describe("PipelineRun Unit Tests", func() {
+	var (
+		cfg      *config.Config
+		build    *buildapi.Build
+		buildRun *buildapi.BuildRun
+		strategy *buildapi.ClusterBuildStrategy
+		ctl      test.Catalog
+	)
+
+	pipelineTaskNames := func(pipelineRun *pipelineapi.PipelineRun) []string {
+		var names []string
+		for _, task := range pipelineRun.Spec.PipelineSpec.Tasks {
+			names = append(names, task.Name)
+		}
+		return names
+	}
+
+	findPipelineTask := func(pipelineRun *pipelineapi.PipelineRun, name string) *pipelineapi.PipelineTask {
+		for i := range pipelineRun.Spec.PipelineSpec.Tasks {
+			if pipelineRun.Spec.PipelineSpec.Tasks[i].Name == name {
+				return &pipelineRun.Spec.PipelineSpec.Tasks[i]
+			}
+		}
+		return nil
+	}
+
+	findParam := func(params pipelineapi.Params, name string) string {
+		for _, param := range params {
+			if param.Name == name {
+				return param.Value.StringVal
+			}
+		}
+		return ""
+	}
+
+	BeforeEach(func() {
+		cfg = config.NewDefaultConfig()
+
+		var err error
+		build, err = ctl.LoadBuildYAML([]byte(test.MinimalBuild))
+		Expect(err).ToNot(HaveOccurred())
+
+		buildRun, err = ctl.LoadBuildRunFromBytes([]byte(test.MinimalBuildRun))
+		Expect(err).ToNot(HaveOccurred())
+
+		strategy, err = ctl.LoadCBSWithName("crane-pull", []byte(test.ClusterBuildStrategyForVulnerabilityScanning))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("for a single platform build", func() {
+		It("creates one build-strategy task", func() {
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineTaskNames(pipelineRun)).To(Equal([]string{"source-acquisition", "build-strategy", "output-image"}))
+			Expect(pipelineRun.Spec.TaskRunSpecs).To(BeEmpty())
+			Expect(findPipelineTask(pipelineRun, "output-image").RunAfter).To(Equal([]string{"build-strategy"}))
+		})
+
+		It("binds the source workspace to a ReadWriteOnce volume", func() {
+			cfg.MultiPlatformStorageClass = "nfs-client"
+
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineRun.Spec.Workspaces[0].Name).To(Equal("source"))
+			Expect(pipelineRun.Spec.Workspaces[0].VolumeClaimTemplate.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}))
+			Expect(pipelineRun.Spec.Workspaces[0].VolumeClaimTemplate.Spec.StorageClassName).To(BeNil())
+		})
+	})
+
+	Context("with phase timeouts", func() {
+		It("sets the phase timeouts on the tasks of the phases", func() {
+			build.Spec.Timeouts = &buildapi.PhaseTimeouts{
+				Source: &metav1.Duration{Duration: time.Minute},
+				Build:  &metav1.Duration{Duration: 10 * time.Minute},
+				Output: &metav1.Duration{Duration: 2 * time.Minute},
+			}
+			buildRun.Spec.Timeouts = &buildapi.PhaseTimeouts{
+				Output: &metav1.Duration{Duration: 5 * time.Minute},
+			}
+
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(findPipelineTask(pipelineRun, "source-acquisition").Timeout.Duration).To(Equal(time.Minute))
+			Expect(findPipelineTask(pipelineRun, "build-strategy").Timeout.Duration).To(Equal(10 * time.Minute))
+			Expect(findPipelineTask(pipelineRun, "output-image").Timeout.Duration).To(Equal(5 * time.Minute))
+		})
+	})
+
+	Context("with placeholders in the tags", func() {
+		It("passes the results of the source-acquisition task to the output-image task", func() {
+			build.Spec.Source = &buildapi.Source{
+				Type: buildapi.GitType,
+				Git:  &buildapi.Git{URL: "https://github.com/shipwright-io/sample-go"},
+			}
+			build.Spec.Output.Image = "registry.example.com/org/app:$(source.git.commitSha)"
+			build.Spec.Output.AdditionalTags = []string{"$(source.git.describe)"}
+
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			outputTask := findPipelineTask(pipelineRun, "output-image")
+			Expect(outputTask.TaskSpec.Steps[0].Args).To(ContainElements(
+				"--additional-tag", "$(source.git.describe)",
+				"--git-commit-sha", "$(params.shp-source-default-commit-sha)",
+				"--git-describe", "$(params.shp-source-default-describe)",
+			))
+			Expect(outputTask.TaskSpec.Steps[0].Args).ToNot(ContainElement("--git-commit-sha-file"))
+			Expect(findParam(outputTask.Params, "shp-source-default-commit-sha")).To(Equal("$(tasks.source-acquisition.results.shp-source-default-commit-sha)"))
+			Expect(findParam(outputTask.Params, "shp-source-default-describe")).To(Equal("$(tasks.source-acquisition.results.shp-source-default-describe)"))
+
+			sourceTask := findPipelineTask(pipelineRun, "source-acquisition")
+			Expect(sourceTask.TaskSpec.Results).To(ContainElement(HaveField("Name", "shp-source-default-describe")))
+			Expect(sourceTask.TaskSpec.Steps[0].Args).To(ContainElements("--result-file-describe", "$(results.shp-source-default-describe.path)"))
+		})
+	})
+
+	Context("for a multi-platform build", func() {
+		BeforeEach(func() {
+			build.Spec.NodeSelector = map[string]string{"node-role": "builder"}
+			build.Spec.Output.Platforms = []buildapi.ImagePlatform{
+				{OS: "linux", Arch: "amd64"},
+				{OS: "linux", Arch: "arm64"},
+			}
+			cfg.MultiPlatformStorageClass = "nfs-client"
+		})
+
+		It("binds the source workspace of all platform tasks to a ReadWriteMany volume", func() {
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineRun.Spec.Workspaces).To(HaveLen(2))
+			source := pipelineRun.Spec.Workspaces[0]
+			Expect(source.Name).To(Equal("source"))
+			Expect(source.VolumeClaimTemplate.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}))
+			Expect(source.VolumeClaimTemplate.Spec.StorageClassName).To(Equal(ptr.To("nfs-client")))
+
+			for _, name := range []string{"build-strategy-linux-amd64", "build-strategy-linux-arm64"} {
+				Expect(findPipelineTask(pipelineRun, name).Workspaces).To(Equal([]pipelineapi.WorkspacePipelineTaskBinding{
+					{Name: "source", Workspace: "source"},
+					{Name: "cache", Workspace: "cache"},
+				}), name)
+			}
+		})
+
+		It("fails if no storage class for ReadWriteMany volumes is configured", func() {
+			cfg.MultiPlatformStorageClass = ""
+
+			_, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).To(MatchError(ContainSubstring("ReadWriteMany")))
+		})
+
+		It("creates one build-strategy task per platform", func() {
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineTaskNames(pipelineRun)).To(Equal([]string{
+				"source-acquisition",
+				"build-strategy-linux-amd64",
+				"build-strategy-linux-arm64",
+				"output-image",
+			}))
+
+			amd64Task := findPipelineTask(pipelineRun, "build-strategy-linux-amd64")
+			Expect(amd64Task.RunAfter).To(Equal([]string{"source-acquisition"}))
+			Expect(findParam(amd64Task.Params, "shp-output-directory")).To(Equal("$(params.shp-output-directory)/linux-amd64"))
+
+			arm64Task := findPipelineTask(pipelineRun, "build-strategy-linux-arm64")
+			Expect(findParam(arm64Task.Params, "shp-output-directory")).To(Equal("$(params.shp-output-directory)/linux-arm64"))
+		})
+
+		It("pins every platform task to matching nodes", func() {
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineRun.Spec.TaskRunSpecs).To(HaveLen(2))
+			Expect(pipelineRun.Spec.TaskRunSpecs[0].PipelineTaskName).To(Equal("build-strategy-linux-amd64"))
+			Expect(pipelineRun.Spec.TaskRunSpecs[0].PodTemplate.NodeSelector).To(Equal(map[string]string{
+				"node-role":          "builder",
+				"kubernetes.io/os":   "linux",
+				"kubernetes.io/arch": "amd64",
+			}))
+			Expect(pipelineRun.Spec.TaskRunSpecs[1].PipelineTaskName).To(Equal("build-strategy-linux-arm64"))
+			Expect(pipelineRun.Spec.TaskRunSpecs[1].PodTemplate.NodeSelector).To(Equal(map[string]string{
+				"node-role":          "builder",
+				"kubernetes.io/os":   "linux",
+				"kubernetes.io/arch": "arm64",
+			}))
+		})
+
+		It("assembles the image index in the output-image task", func() {
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			outputTask := findPipelineTask(pipelineRun, "output-image")
+			Expect(outputTask.RunAfter).To(Equal([]string{"build-strategy-linux-amd64", "build-strategy-linux-arm64"}))
+
+			steps := outputTask.TaskSpec.Steps
+			Expect(steps).To(HaveLen(1))
+			Expect(steps[0].Name).To(Equal("image-processing"))
+			Expect(steps[0].Args).ToNot(ContainElement("--push"))
+			Expect(steps[0].Args).To(ContainElements(
+				"--platform-directory", "linux/amd64=$(params.shp-output-directory)/linux-amd64",
+				"--platform-directory", "linux/arm64=$(params.shp-output-directory)/linux-arm64",
+				"--result-file-image-platforms", "$(results.shp-image-platforms.path)",
+			))
+
+			var resultNames []string
+			for _, result := range outputTask.TaskSpec.Results {
+				resultNames = append(resultNames, result.Name)
+			}
+			Expect(resultNames).To(ContainElement("shp-image-platforms"))
+		})
+
+		It("uses the platforms of the BuildRun over the ones of the Build", func() {
+			buildRun.Spec.Output = &buildapi.Image{
+				Platforms: []buildapi.ImagePlatform{{OS: "linux", Arch: "s390x"}},
+			}
+
+			pipelineRun, err := resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", strategy)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineTaskNames(pipelineRun)).To(Equal([]string{"source-acquisition", "build-strategy-linux-s390x", "output-image"}))
+		})
+
+		It("fails for a strategy that does not use the output directory", func() {
+			noopStrategy, err := ctl.LoadCBSWithName("noop", []byte(test.ClusterBuildStrategyNoOp))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resources.GeneratePipelineRun(cfg, build, buildRun, "test-sa", noopStrategy)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("shp-output-directory"))
+		})
+	})
+})
@@ -20,6 +20,7 @@ import (
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/env"
+	"github.com/shipwright-io/build/pkg/image"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/steps"
 	"github.com/shipwright-io/build/pkg/volumes"
 )
@@ -68,11 +69,11 @@ func generateBaseTaskParamReferences() []pipelineapi.Param {
 }
 
 func generateBaseParamValues(build *buildapi.Build, buildRun *buildapi.BuildRun) []pipelineapi.Param {
-	var image string
+	var outputImage string
 	if buildRun.Spec.Output != nil {
-		image = buildRun.Spec.Output.Image
+		outputImage = buildRun.Spec.Output.Image
 	} else {
-		image = build.Spec.Output.Image
+		outputImage = build.Spec.Output.Image
 	}
 
 	insecure := false
@@ -86,8 +87,9 @@ func generateBaseParamValues(build *buildapi.Build, buildRun *buildapi.BuildRun)
 		{
 			Name: fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramOutputImage),
 			Value: pipelineapi.ParamValue{
-				Type:      pipelineapi.ParamTypeString,
-				StringVal: image,
+				Type: pipelineapi.ParamTypeString,
+				// the strategy does not know the placeholders, they are resolved by the image processing
+				StringVal: image.WithoutTagPlaceholders(outputImage),
 			},
 		},
 		{
@@ -239,6 +241,8 @@ func applyBuildStrategySteps(
 // 1. BuildRun.Spec.StepResources (highest priority - for name reference builds)
 // 2. BuildRun.Spec.Build.Spec.Strategy.StepResources (for embedded spec builds)
 // 3. Build.Spec.Strategy.StepResources (Build-level overrides)
+// The step resources in the BuildRun status, that the memory escalation of the retry policy
+// increased for a step that went out of memory, take precedence over all of them.
 func buildStepResourceOverridesMap(build *buildapi.Build, buildRun *buildapi.BuildRun) map[string]corev1.ResourceRequirements {
 	overrides := make(map[string]corev1.ResourceRequirements)
 
@@ -261,6 +265,10 @@ func buildStepResourceOverridesMap(build *buildapi.Build, buildRun *buildapi.Bui
 		for _, sr := range buildRun.Spec.StepResources {
 			overrides[sr.Name] = sr.Resources
 		}
+
+		for _, sr := range buildRun.Status.StepResources {
+			overrides[sr.Name] = sr.Resources
+		}
 	}
 
 	return overrides
@@ -439,11 +447,11 @@ func applyAnnotationsAndLabels(taskRun *pipelineapi.TaskRun, strategy buildapi.B
 }
 
 func applyParameters(taskRun *pipelineapi.TaskRun, build *buildapi.Build, buildRun *buildapi.BuildRun, strategy buildapi.BuilderStrategy) error {
-	var image string
+	var outputImage string
 	if buildRun.Spec.Output != nil {
-		image = buildRun.Spec.Output.Image
+		outputImage = buildRun.Spec.Output.Image
 	} else {
-		image = build.Spec.Output.Image
+		outputImage = build.Spec.Output.Image
 	}
 
 	insecure := false
@@ -457,8 +465,9 @@ func applyParameters(taskRun *pipelineapi.TaskRun, build *buildapi.Build, buildR
 		{
 			Name: fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramOutputImage),
 			Value: pipelineapi.ParamValue{
-				Type:      pipelineapi.ParamTypeString,
-				StringVal: image,
+				Type: pipelineapi.ParamTypeString,
+				// the strategy does not know the placeholders, they are resolved by the image processing
+				StringVal: image.WithoutTagPlaceholders(outputImage),
 			},
 		},
 		{
@@ -575,13 +584,24 @@ func generatePipelineTaskRunTemplate(serviceAccountName string) pipelineapi.Pipe
 	}
 }
 
-func generatePipelineWorkspaceBindings() []pipelineapi.WorkspaceBinding {
+// generatePipelineWorkspaceBindings binds the source workspace to a ReadWriteOnce volume, unless
+// a storage class is provided, the volume then is ReadWriteMany so that it can be mounted by the
+// tasks of all platforms of a multi-platform build, which run on different nodes
+func generatePipelineWorkspaceBindings(storageClassName string) []pipelineapi.WorkspaceBinding {
+	accessMode := corev1.ReadWriteOnce
+	var storageClassNamePtr *string
+	if storageClassName != "" {
+		accessMode = corev1.ReadWriteMany
+		storageClassNamePtr = &storageClassName
+	}
+
 	return []pipelineapi.WorkspaceBinding{
 		{
 			Name: workspaceSource,
 			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
 				Spec: corev1.PersistentVolumeClaimSpec{
-					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					AccessModes:      []corev1.PersistentVolumeAccessMode{accessMode},
+					StorageClassName: storageClassNamePtr,
 					Resources: corev1.VolumeResourceRequirements{
 						Requests: corev1.ResourceList{
 							corev1.ResourceStorage: resource.MustParse("1Gi"),
@@ -673,6 +693,47 @@ func createBuildStrategyPipelineTask(taskSpec *pipelineapi.TaskSpec, strategy bu
 	return pipelineTask
 }
 
+// createPlatformBuildStrategyPipelineTask creates the build-strategy Task for one platform
+// of a multi-arch build, the image is stored in a platform-specific output directory
+func createPlatformBuildStrategyPipelineTask(taskSpec *pipelineapi.TaskSpec, strategy buildapi.BuilderStrategy, platform buildapi.ImagePlatform) pipelineapi.PipelineTask {
+	pipelineTask := createBuildStrategyPipelineTask(taskSpec, strategy)
+	pipelineTask.Name = platformBuildStrategyPipelineTaskName(platform)
+
+	prefixedOutputDirectory := fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramOutputDirectory)
+	for i := range pipelineTask.Params {
+		if pipelineTask.Params[i].Name == prefixedOutputDirectory {
+			pipelineTask.Params[i].Value.StringVal = fmt.Sprintf("$(params.%s)/%s", prefixedOutputDirectory, platformDirectoryName(platform))
+		}
+	}
+
+	return pipelineTask
+}
+
+func platformBuildStrategyPipelineTaskName(platform buildapi.ImagePlatform) string {
+	return fmt.Sprintf("build-strategy-%s", platformDirectoryName(platform))
+}
+
+func platformBuildStrategyPipelineTaskNames(platforms []buildapi.ImagePlatform) []string {
+	names := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		names = append(names, platformBuildStrategyPipelineTaskName(platform))
+	}
+	return names
+}
+
+func platformDirectoryName(platform buildapi.ImagePlatform) string {
+	return fmt.Sprintf("%s-%s", platform.OS, platform.Arch)
+}
+
+// mergedPlatforms returns the output platforms, the ones of the BuildRun take
+// precedence over the ones of the Build
+func mergedPlatforms(build *buildapi.Build, buildRun *buildapi.BuildRun) []buildapi.ImagePlatform {
+	if buildRun.Spec.Output != nil && len(buildRun.Spec.Output.Platforms) > 0 {
+		return buildRun.Spec.Output.Platforms
+	}
+	return build.Spec.Output.Platforms
+}
+
 func generateBuildStrategyTaskParams(strategy buildapi.BuilderStrategy) []pipelineapi.Param {
 	params := generateBaseTaskParamReferences()
 
@@ -21,6 +21,8 @@ const (
 	imageDigestResult    = "image-digest"
 	imageSizeResult      = "image-size"
 	imageVulnerabilities = "image-vulnerabilities"
+	imagePlatformsResult = "image-platforms"
+	imageTagsResult      = "image-tags"
 )
 
 // UpdateBuildRunUsingTaskResults surface the task results
@@ -56,6 +58,14 @@ func updateBuildRunStatusWithOutputResult(ctx context.Context, buildRun *buildap
 			}
 		case generateOutputResultName(imageVulnerabilities):
 			buildRun.Status.Output.Vulnerabilities = getImageVulnerabilitiesResult(result)
+
+		case generateOutputResultName(imagePlatformsResult):
+			buildRun.Status.Output.Platforms = getImagePlatformsResult(result)
+
+		case generateOutputResultName(imageTagsResult):
+			if len(result.Value.StringVal) > 0 {
+				buildRun.Status.Output.Tags = strings.Split(result.Value.StringVal, ",")
+			}
 		}
 	}
 }
@@ -78,6 +88,10 @@ func getTaskSpecResults() []pipelineapi.TaskResult {
 			Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, imageVulnerabilities),
 			Description: "List of vulnerabilities",
 		},
+		{
+			Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, imageTagsResult),
+			Description: "The tags that the image was pushed with",
+		},
 	}
 }
 
@@ -99,6 +113,24 @@ func getImageVulnerabilitiesResult(result pipelineapi.TaskRunResult) []buildapi.
 	return vulns
 }
 
+func getImagePlatformsResult(result pipelineapi.TaskRunResult) []buildapi.OutputPlatform {
+	var platforms []buildapi.OutputPlatform
+	if len(result.Value.StringVal) == 0 {
+		return platforms
+	}
+
+	for _, platformDigest := range strings.Split(result.Value.StringVal, ",") {
+		platform, digest, _ := strings.Cut(platformDigest, "=")
+		os, arch, _ := strings.Cut(platform, "/")
+		platforms = append(platforms, buildapi.OutputPlatform{
+			OS:     os,
+			Arch:   arch,
+			Digest: digest,
+		})
+	}
+	return platforms
+}
+
 func getSeverity(sev string) buildapi.VulnerabilitySeverity {
 	switch strings.ToUpper(sev) {
 	case "L":
@@ -70,6 +70,20 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 						Type:      pipelineapi.ParamTypeString,
 						StringVal: "foo bar",
 					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-message",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "prepare release 1.1.0",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-describe",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "v1.1.0",
+					},
 				})
 
 			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
@@ -77,6 +91,8 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Source).ToNot(BeNil())
 			Expect(br.Status.Source.Git.CommitSha).To(Equal(commitSha))
 			Expect(br.Status.Source.Git.CommitAuthor).To(Equal("foo bar"))
+			Expect(br.Status.Source.Git.CommitMessage).To(Equal("prepare release 1.1.0"))
+			Expect(br.Status.Source.Git.Describe).To(Equal("v1.1.0"))
 		})
 
 		It("should surface the TaskRun results emitting from default(bundle) source step", func() {
@@ -105,6 +121,46 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Source.OciArtifact.Digest).To(Equal(bundleImageDigest))
 		})
 
+		It("should surface the TaskRun results emitting from additional source steps", func() {
+			br.Status.BuildSpec = &buildapi.BuildSpec{
+				AdditionalSources: []buildapi.AdditionalSource{{
+					Name:      "scripts",
+					Type:      buildapi.GitType,
+					TargetDir: "scripts",
+					Git:       &buildapi.Git{URL: "https://github.com/shipwright-io/build-scripts"},
+				}, {
+					Name:        "protos",
+					Type:        buildapi.OCIArtifactType,
+					TargetDir:   "protos",
+					OCIArtifact: &buildapi.OCIArtifact{Image: "ghcr.io/shipwright-io/protos:latest"},
+				}},
+			}
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name:  "shp-source-scripts-commit-sha",
+					Value: pipelineapi.ParamValue{Type: pipelineapi.ParamTypeString, StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591"},
+				},
+				pipelineapi.TaskRunResult{
+					Name:  "shp-source-scripts-source-timestamp",
+					Value: pipelineapi.ParamValue{Type: pipelineapi.ParamTypeString, StringVal: "1691650396"},
+				},
+				pipelineapi.TaskRunResult{
+					Name:  "shp-source-protos-image-digest",
+					Value: pipelineapi.ParamValue{Type: pipelineapi.ParamTypeString, StringVal: "sha256:fe1b73cd25ac3f11dec752755e2"},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).To(BeNil())
+			Expect(br.Status.AdditionalSources).To(HaveLen(2))
+			Expect(br.Status.AdditionalSources[0].Name).To(Equal("scripts"))
+			Expect(br.Status.AdditionalSources[0].Git.CommitSha).To(Equal("0e0583421a5e4bf562ffe33f3651e16ba0c78591"))
+			Expect(br.Status.AdditionalSources[0].Timestamp.Unix()).To(Equal(int64(1691650396)))
+			Expect(br.Status.AdditionalSources[1].Name).To(Equal("protos"))
+			Expect(br.Status.AdditionalSources[1].OciArtifact.Digest).To(Equal("sha256:fe1b73cd25ac3f11dec752755e2"))
+		})
+
 		It("should surface the TaskRun results emitting from output step with image vulnerabilities", func() {
 			imageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
 			tr.Status.Results = append(tr.Status.Results,
@@ -171,6 +227,54 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Output.Vulnerabilities).To(HaveLen(0))
 		})
 
+		It("should surface the TaskRun results emitting from output step with image tags", func() {
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "sha256:fe1b73cd25ac3f11dec752755e2",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-tags",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591,latest",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Output.Tags).To(Equal([]string{"0e0583421a5e4bf562ffe33f3651e16ba0c78591", "latest"}))
+		})
+
+		It("should surface the TaskRun results emitting from output step of a multi-platform image", func() {
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "sha256:fe1b73cd25ac3f11dec752755e2",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-platforms",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "linux/amd64=sha256:0e0583421a5e4bf562ffe33f3651e16,linux/arm64=sha256:8f0a3ae2b5b4ac6dc8e2e7b0b3d5e01",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Output.Digest).To(Equal("sha256:fe1b73cd25ac3f11dec752755e2"))
+			Expect(br.Status.Output.Platforms).To(Equal([]buildapi.OutputPlatform{
+				{OS: "linux", Arch: "amd64", Digest: "sha256:0e0583421a5e4bf562ffe33f3651e16"},
+				{OS: "linux", Arch: "arm64", Digest: "sha256:8f0a3ae2b5b4ac6dc8e2e7b0b3d5e01"},
+			}))
+		})
+
 		It("should surface the TaskRun results emitting from source and output step", func() {
 			commitSha := "0e0583421a5e4bf562ffe33f3651e16ba0c78591"
 			imageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
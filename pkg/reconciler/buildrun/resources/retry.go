@@ -0,0 +1,262 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const (
+	// defaultMemoryEscalationFactor is the factor of the memory escalation if the retry policy does not define one
+	defaultMemoryEscalationFactor = 2.0
+
+	// maxRetryBackoff is the longest time to wait before the next attempt
+	maxRetryBackoff = time.Hour
+)
+
+// RetryPolicy returns the retry policy of the BuildRun, which overrides the retry policy of its Build
+func RetryPolicy(buildRun *buildapi.BuildRun) *buildapi.Retry {
+	if buildRun.Spec.Retry != nil {
+		return buildRun.Spec.Retry
+	}
+
+	if buildRun.Status.BuildSpec != nil {
+		return buildRun.Status.BuildSpec.Retry
+	}
+
+	return nil
+}
+
+// IsRetryable returns true if the BuildRun failed for a reason that its retry policy lists, and
+// the retry policy allows another attempt
+func IsRetryable(buildRun *buildapi.BuildRun) bool {
+	retry := RetryPolicy(buildRun)
+	if !hasAttemptsLeft(retry, buildRun) {
+		return false
+	}
+
+	condition := buildRun.Status.GetCondition(buildapi.Succeeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse {
+		return false
+	}
+
+	if slices.Contains(retry.Reasons, condition.Reason) {
+		return true
+	}
+
+	return buildRun.Status.FailureDetails != nil &&
+		buildRun.Status.FailureDetails.Reason != "" &&
+		slices.Contains(retry.Reasons, buildRun.Status.FailureDetails.Reason)
+}
+
+// EscalateStepMemory increases the memory limit of the step that went out of memory in the step
+// resources of the BuildRun status, which the executor of the next attempt uses. It returns false
+// if the retry policy does not escalate memory, or if the limit cannot be increased any further.
+func EscalateStepMemory(buildRun *buildapi.BuildRun, executor client.Object) bool {
+	retry := RetryPolicy(buildRun)
+	if !hasAttemptsLeft(retry, buildRun) || retry.MemoryEscalation == nil {
+		return false
+	}
+
+	condition := buildRun.Status.GetCondition(buildapi.Succeeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != buildapi.BuildRunStateStepOutOfMemory {
+		return false
+	}
+
+	failureDetails := buildRun.Status.FailureDetails
+	if failureDetails == nil || failureDetails.Location == nil || failureDetails.Location.Container == "" {
+		return false
+	}
+
+	// Tekton names the container of a step with the prefix step-
+	stepName := strings.TrimPrefix(failureDetails.Location.Container, "step-")
+	stepResources, found := executorStepResources(executor, stepName)
+	if !found {
+		return false
+	}
+
+	current, hasLimit := stepResources.Limits[corev1.ResourceMemory]
+	if !hasLimit {
+		if current, hasLimit = stepResources.Requests[corev1.ResourceMemory]; !hasLimit {
+			return false
+		}
+	}
+
+	factor := defaultMemoryEscalationFactor
+	if retry.MemoryEscalation.Factor != "" {
+		var err error
+		if factor, err = strconv.ParseFloat(retry.MemoryEscalation.Factor, 64); err != nil || factor <= 1 {
+			return false
+		}
+	}
+
+	limit := resource.NewQuantity(int64(math.Ceil(float64(current.Value())*factor)), current.Format)
+	if limit.Cmp(retry.MemoryEscalation.MaxLimit) > 0 {
+		limit = ptr.To(retry.MemoryEscalation.MaxLimit.DeepCopy())
+	}
+
+	if limit.Cmp(current) <= 0 {
+		return false
+	}
+
+	// an earlier escalation to the same limit had no effect on the step, for example
+	// because the step is not a step of the build strategy
+	index := slices.IndexFunc(buildRun.Status.StepResources, func(override buildapi.StepResourceOverride) bool {
+		return override.Name == stepName
+	})
+	if index >= 0 {
+		if escalated, ok := buildRun.Status.StepResources[index].Resources.Limits[corev1.ResourceMemory]; ok && escalated.Cmp(*limit) >= 0 {
+			return false
+		}
+	}
+
+	escalatedResources := *stepResources.DeepCopy()
+	if escalatedResources.Limits == nil {
+		escalatedResources.Limits = corev1.ResourceList{}
+	}
+	escalatedResources.Limits[corev1.ResourceMemory] = *limit
+
+	if index >= 0 {
+		buildRun.Status.StepResources[index].Resources = escalatedResources
+	} else {
+		buildRun.Status.StepResources = append(buildRun.Status.StepResources, buildapi.StepResourceOverride{
+			Name:      stepName,
+			Resources: escalatedResources,
+		})
+	}
+
+	return true
+}
+
+// RetryBackoff returns the time to wait before the next attempt after the given number of failed attempts
+func RetryBackoff(retry *buildapi.Retry, failedAttempts int) time.Duration {
+	if retry == nil || retry.Backoff == nil || failedAttempts < 1 {
+		return 0
+	}
+
+	// the backoff is doubled until it reaches the maximum, so that many attempts do not overflow it
+	backoff := retry.Backoff.Duration
+	for i := 1; i < failedAttempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRetryBackoff)
+}
+
+// RemainingRetryBackoff returns how long a retried BuildRun still has to wait before its next attempt starts
+func RemainingRetryBackoff(buildRun *buildapi.BuildRun, now time.Time) time.Duration {
+	if len(buildRun.Status.Attempts) == 0 {
+		return 0
+	}
+
+	lastAttempt := buildRun.Status.Attempts[len(buildRun.Status.Attempts)-1]
+	if lastAttempt.CompletionTime == nil {
+		return 0
+	}
+
+	remaining := lastAttempt.CompletionTime.Add(RetryBackoff(RetryPolicy(buildRun), len(buildRun.Status.Attempts))).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// RecordFailedAttempt adds the failed attempt of the current executor to the attempts of the BuildRun,
+// and resets the status of the BuildRun so that the next attempt creates a new executor
+func RecordFailedAttempt(buildRun *buildapi.BuildRun, completionTime *metav1.Time) {
+	condition := buildRun.Status.GetCondition(buildapi.Succeeded)
+
+	attempt := buildapi.BuildRunAttempt{
+		FailureDetails: buildRun.Status.FailureDetails,
+		CompletionTime: completionTime,
+	}
+	if buildRun.Status.Executor != nil {
+		attempt.Executor = *buildRun.Status.Executor
+	}
+	if condition != nil {
+		attempt.Reason = condition.Reason
+		attempt.Message = condition.Message
+	}
+	if attempt.CompletionTime == nil {
+		attempt.CompletionTime = ptr.To(metav1.Now())
+	}
+
+	buildRun.Status.Attempts = append(buildRun.Status.Attempts, attempt)
+
+	retry := RetryPolicy(buildRun)
+	buildRun.Status.SetCondition(&buildapi.Condition{
+		LastTransitionTime: metav1.Now(),
+		Type:               buildapi.Succeeded,
+		Status:             corev1.ConditionUnknown,
+		Reason:             buildapi.BuildRunStateRetrying,
+		Message: fmt.Sprintf("attempt %d of %d failed with reason %s, starting the next attempt in %s",
+			len(buildRun.Status.Attempts),
+			retry.MaxAttempts,
+			attempt.Reason,
+			RetryBackoff(retry, len(buildRun.Status.Attempts)),
+		),
+	})
+
+	buildRun.Status.Executor = nil
+	//nolint:staticcheck // Keep for backward compatibility for now
+	buildRun.Status.TaskRunName = nil
+	buildRun.Status.FailureDetails = nil
+}
+
+// IsEarlierAttempt returns true if the executor with the given name executed an earlier attempt of the BuildRun
+func IsEarlierAttempt(buildRun *buildapi.BuildRun, executorName string) bool {
+	return slices.ContainsFunc(buildRun.Status.Attempts, func(attempt buildapi.BuildRunAttempt) bool {
+		return attempt.Executor.Name == executorName
+	})
+}
+
+// hasAttemptsLeft returns true if the retry policy allows another attempt of the BuildRun
+func hasAttemptsLeft(retry *buildapi.Retry, buildRun *buildapi.BuildRun) bool {
+	return retry != nil && len(buildRun.Status.Attempts)+1 < retry.MaxAttempts
+}
+
+// executorStepResources returns the resources of a step in the task specification of a TaskRun or PipelineRun
+func executorStepResources(executor client.Object, stepName string) (corev1.ResourceRequirements, bool) {
+	var steps []pipelineapi.Step
+
+	switch executor := executor.(type) {
+	case *pipelineapi.TaskRun:
+		if executor.Spec.TaskSpec != nil {
+			steps = executor.Spec.TaskSpec.Steps
+		}
+
+	case *pipelineapi.PipelineRun:
+		if executor.Spec.PipelineSpec != nil {
+			for _, task := range executor.Spec.PipelineSpec.Tasks {
+				if task.TaskSpec != nil {
+					steps = append(steps, task.TaskSpec.Steps...)
+				}
+			}
+		}
+	}
+
+	for _, step := range steps {
+		if step.Name == stepName {
+			return step.ComputeResources, true
+		}
+	}
+
+	return corev1.ResourceRequirements{}, false
+}
@@ -0,0 +1,225 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	test "github.com/shipwright-io/build/test/v1beta1_samples"
+)
+
+var _ = Describe("Retried BuildRuns", func() {
+	var (
+		ctl      test.Catalog
+		buildRun *buildapi.BuildRun
+		retry    *buildapi.Retry
+	)
+
+	// fail sets the Succeeded condition of the BuildRun to False with the given reason
+	fail := func(reason string) {
+		buildRun.Status.SetCondition(&buildapi.Condition{
+			Type:    buildapi.Succeeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  reason,
+			Message: "the attempt failed",
+		})
+	}
+
+	BeforeEach(func() {
+		retry = &buildapi.Retry{
+			MaxAttempts: 3,
+			Backoff:     &metav1.Duration{Duration: 10 * time.Second},
+			Reasons:     []string{buildapi.BuildRunStatePodEvicted, "GitRemoteRepositoryNotFound"},
+		}
+
+		buildRun = ctl.DefaultBuildRun("foobar-buildrun", "foobar-build")
+		buildRun.Status.BuildSpec = &buildapi.BuildSpec{Retry: retry}
+		buildRun.Status.Executor = &buildapi.BuildExecutor{Name: "foobar-buildrun-abcde", Kind: "TaskRun"}
+	})
+
+	Context("RetryPolicy", func() {
+		It("uses the retry policy of the Build", func() {
+			Expect(resources.RetryPolicy(buildRun)).To(Equal(retry))
+		})
+
+		It("prefers the retry policy of the BuildRun", func() {
+			buildRun.Spec.Retry = &buildapi.Retry{MaxAttempts: 5, Reasons: []string{"Failed"}}
+			Expect(resources.RetryPolicy(buildRun)).To(Equal(buildRun.Spec.Retry))
+		})
+	})
+
+	Context("IsRetryable", func() {
+		It("retries a listed condition reason", func() {
+			fail(buildapi.BuildRunStatePodEvicted)
+			Expect(resources.IsRetryable(buildRun)).To(BeTrue())
+		})
+
+		It("retries a listed failure details reason", func() {
+			fail("Failed")
+			buildRun.Status.FailureDetails = &buildapi.FailureDetails{Reason: "GitRemoteRepositoryNotFound"}
+			Expect(resources.IsRetryable(buildRun)).To(BeTrue())
+		})
+
+		It("does not retry other reasons", func() {
+			fail(buildapi.BuildRunStateStepOutOfMemory)
+			Expect(resources.IsRetryable(buildRun)).To(BeFalse())
+		})
+
+		It("does not retry BuildRuns that did not fail", func() {
+			Expect(resources.IsRetryable(buildRun)).To(BeFalse())
+		})
+
+		It("does not retry BuildRuns without a retry policy", func() {
+			buildRun.Status.BuildSpec.Retry = nil
+			fail(buildapi.BuildRunStatePodEvicted)
+			Expect(resources.IsRetryable(buildRun)).To(BeFalse())
+		})
+
+		It("does not retry once the maximum number of attempts is reached", func() {
+			fail(buildapi.BuildRunStatePodEvicted)
+			buildRun.Status.Attempts = []buildapi.BuildRunAttempt{{}, {}}
+			Expect(resources.IsRetryable(buildRun)).To(BeFalse())
+		})
+	})
+
+	Context("EscalateStepMemory", func() {
+		var taskRun *pipelineapi.TaskRun
+
+		// outOfMemory fails the BuildRun because the build step used more memory than its limit
+		outOfMemory := func(limit string) {
+			fail(buildapi.BuildRunStateStepOutOfMemory)
+			buildRun.Status.FailureDetails = &buildapi.FailureDetails{
+				Location: &buildapi.Location{Pod: "foobar-buildrun-abcde-pod", Container: "step-build"},
+			}
+
+			taskRun = &pipelineapi.TaskRun{
+				Spec: pipelineapi.TaskRunSpec{
+					TaskSpec: &pipelineapi.TaskSpec{
+						Steps: []pipelineapi.Step{{
+							Name: "build",
+							ComputeResources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+								Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)},
+							},
+						}},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			retry.MemoryEscalation = &buildapi.MemoryEscalation{
+				Factor:   "1.5",
+				MaxLimit: resource.MustParse("2Gi"),
+			}
+		})
+
+		It("multiplies the memory limit of the step by the factor", func() {
+			outOfMemory("1Gi")
+
+			Expect(resources.EscalateStepMemory(buildRun, taskRun)).To(BeTrue())
+			Expect(buildRun.Status.StepResources).To(HaveLen(1))
+			Expect(buildRun.Status.StepResources[0].Name).To(Equal("build"))
+			Expect(buildRun.Status.StepResources[0].Resources.Limits.Memory().String()).To(Equal("1536Mi"))
+			Expect(buildRun.Status.StepResources[0].Resources.Requests.Memory().String()).To(Equal("512Mi"))
+		})
+
+		It("does not exceed the maximum limit", func() {
+			outOfMemory("1536Mi")
+
+			Expect(resources.EscalateStepMemory(buildRun, taskRun)).To(BeTrue())
+			Expect(buildRun.Status.StepResources[0].Resources.Limits.Memory().String()).To(Equal("2Gi"))
+		})
+
+		It("does not retry once the maximum limit is reached", func() {
+			outOfMemory("2Gi")
+
+			Expect(resources.EscalateStepMemory(buildRun, taskRun)).To(BeFalse())
+			Expect(buildRun.Status.StepResources).To(BeEmpty())
+		})
+
+		It("does not retry when the escalated limit had no effect on the step", func() {
+			outOfMemory("1Gi")
+			buildRun.Status.StepResources = []buildapi.StepResourceOverride{{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1536Mi")},
+				},
+			}}
+
+			Expect(resources.EscalateStepMemory(buildRun, taskRun)).To(BeFalse())
+		})
+
+		It("does not escalate memory without a memory escalation policy", func() {
+			retry.MemoryEscalation = nil
+			outOfMemory("1Gi")
+
+			Expect(resources.EscalateStepMemory(buildRun, taskRun)).To(BeFalse())
+		})
+
+		It("does not escalate memory for other failures", func() {
+			outOfMemory("1Gi")
+			fail(buildapi.BuildRunStatePodEvicted)
+
+			Expect(resources.EscalateStepMemory(buildRun, taskRun)).To(BeFalse())
+		})
+	})
+
+	Context("RetryBackoff", func() {
+		It("doubles the backoff for every attempt", func() {
+			Expect(resources.RetryBackoff(retry, 1)).To(Equal(10 * time.Second))
+			Expect(resources.RetryBackoff(retry, 2)).To(Equal(20 * time.Second))
+			Expect(resources.RetryBackoff(retry, 3)).To(Equal(40 * time.Second))
+		})
+
+		It("caps the backoff at one hour", func() {
+			Expect(resources.RetryBackoff(retry, 10)).To(Equal(time.Hour))
+			Expect(resources.RetryBackoff(retry, 100)).To(Equal(time.Hour))
+
+			retry.Backoff = &metav1.Duration{Duration: 2 * time.Hour}
+			Expect(resources.RetryBackoff(retry, 1)).To(Equal(time.Hour))
+		})
+
+		It("retries immediately without a backoff", func() {
+			retry.Backoff = nil
+			Expect(resources.RetryBackoff(retry, 1)).To(BeZero())
+		})
+	})
+
+	Context("RecordFailedAttempt", func() {
+		It("records the attempt and resets the BuildRun for the next attempt", func() {
+			now := time.Now()
+			fail(buildapi.BuildRunStatePodEvicted)
+			buildRun.Status.FailureDetails = &buildapi.FailureDetails{Reason: "Evicted"}
+
+			resources.RecordFailedAttempt(buildRun, &metav1.Time{Time: now})
+
+			Expect(buildRun.Status.Attempts).To(HaveLen(1))
+			Expect(buildRun.Status.Attempts[0].Executor.Name).To(Equal("foobar-buildrun-abcde"))
+			Expect(buildRun.Status.Attempts[0].Reason).To(Equal(buildapi.BuildRunStatePodEvicted))
+			Expect(buildRun.Status.Attempts[0].FailureDetails.Reason).To(Equal("Evicted"))
+			Expect(buildRun.Status.Executor).To(BeNil())
+			Expect(buildRun.Status.FailureDetails).To(BeNil())
+
+			condition := buildRun.Status.GetCondition(buildapi.Succeeded)
+			Expect(condition.Status).To(Equal(corev1.ConditionUnknown))
+			Expect(condition.Reason).To(Equal(buildapi.BuildRunStateRetrying))
+			Expect(condition.Message).To(Equal("attempt 1 of 3 failed with reason PodEvicted, starting the next attempt in 10s"))
+
+			Expect(resources.IsEarlierAttempt(buildRun, "foobar-buildrun-abcde")).To(BeTrue())
+			Expect(resources.RemainingRetryBackoff(buildRun, now.Add(4*time.Second))).To(Equal(6 * time.Second))
+			Expect(resources.RemainingRetryBackoff(buildRun, now.Add(time.Minute))).To(BeZero())
+		})
+	})
+})
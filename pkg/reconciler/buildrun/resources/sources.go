@@ -5,6 +5,8 @@
 package resources
 
 import (
+	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -38,6 +40,45 @@ func isLocalCopyBuildSource(
 	return nil
 }
 
+// ApplyGitSourceOverride applies the Git source details that a BuildRun overrides to the Build. It
+// returns false if the BuildRun overrides the Git source, but the Build does not have a Git source.
+func ApplyGitSourceOverride(build *buildapi.Build, buildRun *buildapi.BuildRun) bool {
+	if buildRun.Spec.Source == nil || buildRun.Spec.Source.Git == nil {
+		return true
+	}
+
+	if build.Spec.Source == nil || build.Spec.Source.Type != buildapi.GitType || build.Spec.Source.Git == nil {
+		return false
+	}
+
+	if buildRun.Spec.Source.Git.Revision != nil {
+		build.Spec.Source.Git.Revision = buildRun.Spec.Source.Git.Revision
+	}
+
+	if buildRun.Spec.Source.Git.PullRequest != nil {
+		build.Spec.Source.Git.PullRequest = buildRun.Spec.Source.Git.PullRequest
+	}
+
+	return true
+}
+
+// gitSourceWithSparseCheckoutOfContextDir returns the Git source, in case the sparse checkout
+// should be derived from the context directory, the context directory is added to its paths
+func gitSourceWithSparseCheckoutOfContextDir(source *buildapi.Source) buildapi.Git {
+	gitSource := *source.Git.DeepCopy()
+
+	if gitSource.SparseCheckout == nil || !gitSource.SparseCheckout.FromContextDir || source.ContextDir == nil {
+		return gitSource
+	}
+
+	contextDir := path.Clean(strings.TrimPrefix(*source.ContextDir, "/"))
+	if contextDir != "." && !slices.Contains(gitSource.SparseCheckout.Paths, contextDir) {
+		gitSource.SparseCheckout.Paths = append(gitSource.SparseCheckout.Paths, contextDir)
+	}
+
+	return gitSource
+}
+
 func appendSourceTimestampResult(taskSpec *pipelineapi.TaskSpec) {
 	taskSpec.Results = append(taskSpec.Results,
 		pipelineapi.TaskResult{
@@ -48,7 +89,8 @@ func appendSourceTimestampResult(taskSpec *pipelineapi.TaskSpec) {
 }
 
 // AmendTaskSpecWithSources adds the necessary steps to either wait for user upload ("LocalCopy"), or
-// alternatively, configures the Task steps to use bundle and "git clone".
+// alternatively, configures the Task steps to use bundle, HTTP archive, and "git clone". Additional
+// sources are fetched into their subdirectories afterwards.
 func AmendTaskSpecWithSources(
 	cfg *config.Config,
 	taskSpec *pipelineapi.TaskSpec,
@@ -59,25 +101,58 @@ func AmendTaskSpecWithSources(
 		sources.AppendLocalCopyStep(cfg, taskSpec, localCopy.Timeout)
 	} else if build.Spec.Source != nil {
 
-		// create the step for spec.source, either Git or Bundle
+		// create the step for spec.source, either Git, Bundle or HTTP archive
 		switch build.Spec.Source.Type {
 		case buildapi.OCIArtifactType:
 			if build.Spec.Source.OCIArtifact != nil {
 				appendSourceTimestampResult(taskSpec)
 				sources.AppendBundleStep(cfg, taskSpec, build.Spec.Source.OCIArtifact, defaultSourceName)
+				applyTrustedCA(taskSpec, lastStep(taskSpec), build.Spec.TrustedCA)
+				applyProxy(lastStep(taskSpec), build.Spec.Proxy)
+			}
+		case buildapi.HTTPType:
+			if build.Spec.Source.HTTPArchive != nil {
+				appendSourceTimestampResult(taskSpec)
+				sources.AppendHTTPArchiveStep(cfg, taskSpec, build.Spec.Source.HTTPArchive, defaultSourceName)
+				applyTrustedCA(taskSpec, lastStep(taskSpec), build.Spec.TrustedCA)
+				applyProxy(lastStep(taskSpec), build.Spec.Proxy)
 			}
 		case buildapi.GitType:
 			if build.Spec.Source.Git != nil {
 				appendSourceTimestampResult(taskSpec)
-				sources.AppendGitStep(cfg, taskSpec, *build.Spec.Source.Git, defaultSourceName)
+				gitSource := gitSourceWithSparseCheckoutOfContextDir(build.Spec.Source)
+				if usesOutputTagPlaceholder(build, buildRun, buildapi.OutputImageTagGitDescribe) {
+					gitSource.FetchTags = true
+				}
+
+				sources.AppendGitStep(cfg, taskSpec, gitSource, defaultSourceName)
+				applyTrustedCA(taskSpec, lastStep(taskSpec), build.Spec.TrustedCA)
+				applyProxy(lastStep(taskSpec), build.Spec.Proxy)
 			}
 		}
 	}
+
+	// create one step per entry of spec.additionalSources, after the step of spec.source so
+	// that their target directories are not overwritten
+	for _, additionalSource := range build.Spec.AdditionalSources {
+		sources.AppendAdditionalSourceStep(cfg, taskSpec, additionalSource)
+		applyTrustedCA(taskSpec, lastStep(taskSpec), build.Spec.TrustedCA)
+		applyProxy(lastStep(taskSpec), build.Spec.Proxy)
+	}
+}
+
+// lastStep returns the step that was added last to the TaskSpec
+func lastStep(taskSpec *pipelineapi.TaskSpec) *pipelineapi.Step {
+	return &taskSpec.Steps[len(taskSpec.Steps)-1]
 }
 
 func updateBuildRunStatusWithSourceResult(buildrun *buildapi.BuildRun, results []pipelineapi.TaskRunResult) {
 	buildSpec := buildrun.Status.BuildSpec
 
+	for _, additionalSource := range buildSpec.AdditionalSources {
+		sources.AppendAdditionalSourceResult(buildrun, additionalSource, results)
+	}
+
 	if buildSpec.Source == nil {
 		return
 	}
@@ -86,6 +161,9 @@ func updateBuildRunStatusWithSourceResult(buildrun *buildapi.BuildRun, results [
 	case buildSpec.Source.Type == buildapi.OCIArtifactType && buildSpec.Source.OCIArtifact != nil:
 		sources.AppendBundleResult(buildrun, defaultSourceName, results)
 
+	case buildSpec.Source.Type == buildapi.HTTPType && buildSpec.Source.HTTPArchive != nil:
+		sources.AppendHTTPArchiveResult(buildrun, defaultSourceName, results)
+
 	case buildSpec.Source.Type == buildapi.GitType && buildSpec.Source.Git != nil:
 		sources.AppendGitResult(buildrun, defaultSourceName, results)
 	}
@@ -0,0 +1,81 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+)
+
+const sourceTimestampResult = "source-timestamp"
+
+// AppendAdditionalSourceStep appends the step of an additional source to the TaskSpec, the
+// step fetches the source code into the target directory of the additional source
+func AppendAdditionalSourceStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, source buildapi.AdditionalSource) {
+	switch {
+	case source.Type == buildapi.GitType && source.Git != nil:
+		AppendGitStep(cfg, taskSpec, *source.Git, source.Name)
+
+	case source.Type == buildapi.OCIArtifactType && source.OCIArtifact != nil:
+		AppendBundleStep(cfg, taskSpec, source.OCIArtifact, source.Name)
+
+	default:
+		return
+	}
+
+	taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+		Name:        TaskResultName(source.Name, sourceTimestampResult),
+		Description: fmt.Sprintf("The timestamp of the additional source %s.", source.Name),
+	})
+
+	// point the target of the step to the subdirectory of the source root
+	step := &taskSpec.Steps[len(taskSpec.Steps)-1]
+	for i := 0; i < len(step.Args)-1; i++ {
+		if step.Args[i] == "--target" {
+			step.Args[i+1] = fmt.Sprintf("$(params.%s-%s)/%s", PrefixParamsResultsVolumes, paramSourceRoot, path.Clean(source.TargetDir))
+			break
+		}
+	}
+}
+
+// AppendAdditionalSourceResult appends the results of an additional source to the build run
+func AppendAdditionalSourceResult(buildRun *buildapi.BuildRun, source buildapi.AdditionalSource, results []pipelineapi.TaskRunResult) {
+	result := buildapi.AdditionalSourceResult{Name: source.Name}
+
+	switch source.Type {
+	case buildapi.GitType:
+		result.Git = gitSourceResult(source.Name, results)
+	case buildapi.OCIArtifactType:
+		result.OciArtifact = bundleSourceResult(source.Name, results)
+	}
+
+	if sourceTimestamp := FindResultValue(results, source.Name, sourceTimestampResult); strings.TrimSpace(sourceTimestamp) != "" {
+		if sec, err := strconv.ParseInt(sourceTimestamp, 10, 64); err == nil {
+			result.Timestamp = &metav1.Time{Time: time.Unix(sec, 0)}
+		}
+	}
+
+	if result.Git == nil && result.OciArtifact == nil && result.Timestamp == nil {
+		return
+	}
+
+	for i := range buildRun.Status.AdditionalSources {
+		if buildRun.Status.AdditionalSources[i].Name == source.Name {
+			buildRun.Status.AdditionalSources[i] = result
+			return
+		}
+	}
+
+	buildRun.Status.AdditionalSources = append(buildRun.Status.AdditionalSources, result)
+}
@@ -72,14 +72,23 @@ func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *b
 
 // AppendBundleResult append bundle source result to build run
 func AppendBundleResult(buildRun *buildapi.BuildRun, name string, results []pipelineapi.TaskRunResult) {
-	imageDigest := FindResultValue(results, name, "image-digest")
-
-	if strings.TrimSpace(imageDigest) != "" {
+	if bundleResult := bundleSourceResult(name, results); bundleResult != nil {
 		if buildRun.Status.Source == nil {
 			buildRun.Status.Source = &buildapi.SourceResult{}
 		}
-		buildRun.Status.Source.OciArtifact = &buildapi.OciArtifactSourceResult{
-			Digest: imageDigest,
-		}
+		buildRun.Status.Source.OciArtifact = bundleResult
+	}
+}
+
+// bundleSourceResult returns the results of the bundle source with the given name, or nil if it has none
+func bundleSourceResult(name string, results []pipelineapi.TaskRunResult) *buildapi.OciArtifactSourceResult {
+	imageDigest := FindResultValue(results, name, "image-digest")
+
+	if strings.TrimSpace(imageDigest) == "" {
+		return nil
+	}
+
+	return &buildapi.OciArtifactSourceResult{
+		Digest: imageDigest,
 	}
 }
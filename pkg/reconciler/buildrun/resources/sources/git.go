@@ -8,18 +8,31 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 )
 
 const (
-	commitSHAResult    = "commit-sha"
-	commitAuthorResult = "commit-author"
-	branchName         = "branch-name"
+	commitSHAResult       = "commit-sha"
+	commitAuthorResult    = "commit-author"
+	commitMessageResult   = "commit-message"
+	commitTimestampResult = "commit-timestamp"
+	branchName            = "branch-name"
+	tagsResult            = "tags"
+	describeResult        = "describe"
+	signerResult          = "signer"
+	lfsObjectsResult      = "lfs-objects"
+	lfsSizeResult         = "lfs-size"
+
+	pullRequestHeadSHAResult  = "pull-request-head-sha"
+	pullRequestBaseSHAResult  = "pull-request-base-sha"
+	pullRequestMergeSHAResult = "pull-request-merge-sha"
 )
 
 // AppendGitStep appends the Git step and results and volume if needed to the TaskSpec
@@ -43,6 +56,22 @@ func AppendGitStep(
 			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, branchName),
 			Description: "The name of the branch used of the cloned source.",
 		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, commitMessageResult),
+			Description: "The subject of the commit message of the last commit of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, commitTimestampResult),
+			Description: "The committer timestamp of the last commit of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, lfsObjectsResult),
+			Description: "The number of downloaded Git LFS objects of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, lfsSizeResult),
+			Description: "The total size in bytes of the downloaded Git LFS objects of the cloned source.",
+		},
 	)
 
 	// initialize the step from the template and the build-specific arguments
@@ -57,6 +86,10 @@ func AppendGitStep(
 			"--result-file-commit-sha", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitSHAResult),
 			"--result-file-commit-author", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitAuthorResult),
 			"--result-file-branch-name", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, branchName),
+			"--result-file-commit-message", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitMessageResult),
+			"--result-file-commit-timestamp", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitTimestampResult),
+			"--result-file-lfs-objects", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, lfsObjectsResult),
+			"--result-file-lfs-size", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, lfsSizeResult),
 			"--result-file-error-message", fmt.Sprintf("$(results.%s-error-message.path)", PrefixParamsResultsVolumes),
 			"--result-file-error-reason", fmt.Sprintf("$(results.%s-error-reason.path)", PrefixParamsResultsVolumes),
 			"--result-file-source-timestamp", fmt.Sprintf("$(results.%s-source-%s-source-timestamp.path)", PrefixParamsResultsVolumes, name),
@@ -67,6 +100,26 @@ func AppendGitStep(
 		WorkingDir:       cfg.GitContainerTemplate.WorkingDir,
 	}
 
+	// Check if the tags of the commit are requested, they are fetched with an additional request
+	if source.FetchTags {
+		taskSpec.Results = append(taskSpec.Results,
+			pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, tagsResult),
+				Description: "The tags that point to the last commit of the cloned source, one per line.",
+			},
+			pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, describeResult),
+				Description: "The version of the last commit of the cloned source as described by git describe --tags.",
+			},
+		)
+
+		gitStep.Args = append(
+			gitStep.Args,
+			"--result-file-tags", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, tagsResult),
+			"--result-file-describe", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, describeResult),
+		)
+	}
+
 	// Check if a revision is defined
 	if source.Revision != nil {
 		// append the argument
@@ -86,6 +139,74 @@ func AppendGitStep(
 		)
 	}
 
+	// Check if a sparse checkout is requested
+	if source.SparseCheckout != nil {
+		for _, sparseCheckoutPath := range source.SparseCheckout.Paths {
+			gitStep.Args = append(
+				gitStep.Args,
+				"--sparse-checkout",
+				sparseCheckoutPath,
+			)
+		}
+	}
+
+	// Check which Git LFS files should be downloaded
+	switch {
+	case !source.LFS.IsEnabled():
+		gitStep.Args = append(gitStep.Args, "--skip-lfs")
+
+	case source.LFS != nil:
+		for _, pattern := range source.LFS.Include {
+			gitStep.Args = append(gitStep.Args, "--lfs-include", pattern)
+		}
+
+		for _, pattern := range source.LFS.Exclude {
+			gitStep.Args = append(gitStep.Args, "--lfs-exclude", pattern)
+		}
+
+		if source.LFS.SkipSmudge {
+			gitStep.Args = append(gitStep.Args, "--lfs-skip-smudge")
+		}
+	}
+
+	// Check if a pull request is requested
+	if source.PullRequest != nil {
+		taskSpec.Results = append(taskSpec.Results,
+			pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, pullRequestHeadSHAResult),
+				Description: "The commit SHA of the head of the pull request.",
+			},
+			pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, pullRequestBaseSHAResult),
+				Description: "The commit SHA of the base branch of the pull request.",
+			},
+		)
+
+		gitStep.Args = append(
+			gitStep.Args,
+			"--pull-request-ref",
+			source.PullRequest.HeadRef(),
+			"--result-file-pull-request-head-sha",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, pullRequestHeadSHAResult),
+			"--result-file-pull-request-base-sha",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, pullRequestBaseSHAResult),
+		)
+
+		if source.PullRequest.Merge {
+			taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, pullRequestMergeSHAResult),
+				Description: "The commit SHA of the merge of the pull request into the base branch.",
+			})
+
+			gitStep.Args = append(
+				gitStep.Args,
+				"--pull-request-merge",
+				"--result-file-pull-request-merge-sha",
+				fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, pullRequestMergeSHAResult),
+			)
+		}
+	}
+
 	// If configure, use Git URL rewrite flag
 	if cfg.GitRewriteRule {
 		gitStep.Args = append(gitStep.Args, "--git-url-rewrite")
@@ -112,6 +233,33 @@ func AppendGitStep(
 		)
 	}
 
+	if source.SignatureVerificationSecret != nil {
+		taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, signerResult),
+			Description: "The signer of the commit or tag of the cloned source.",
+		})
+
+		AppendSecretVolume(taskSpec, *source.SignatureVerificationSecret)
+
+		secretMountPath := fmt.Sprintf("/workspace/%s-signature-verification-secret", PrefixParamsResultsVolumes)
+
+		// define the volume mount on the container
+		gitStep.VolumeMounts = append(gitStep.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(*source.SignatureVerificationSecret),
+			MountPath: secretMountPath,
+			ReadOnly:  true,
+		})
+
+		// append the arguments
+		gitStep.Args = append(
+			gitStep.Args,
+			"--signature-verification-path",
+			secretMountPath,
+			"--result-file-signer",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, signerResult),
+		)
+	}
+
 	SetupHomeAndTmpVolumes(taskSpec, &gitStep)
 	// append the git step
 	taskSpec.Steps = append(taskSpec.Steps, gitStep)
@@ -119,18 +267,60 @@ func AppendGitStep(
 
 // AppendGitResult append git source result to build run
 func AppendGitResult(buildRun *buildapi.BuildRun, name string, results []pipelineapi.TaskRunResult) {
+	if gitResult := gitSourceResult(name, results); gitResult != nil {
+		if buildRun.Status.Source == nil {
+			buildRun.Status.Source = &buildapi.SourceResult{}
+		}
+		buildRun.Status.Source.Git = gitResult
+	}
+}
+
+// gitSourceResult returns the results of the git source with the given name, or nil if it has none
+func gitSourceResult(name string, results []pipelineapi.TaskRunResult) *buildapi.GitSourceResult {
 	commitAuthor := FindResultValue(results, name, commitAuthorResult)
 	commitSha := FindResultValue(results, name, commitSHAResult)
 	branchName := FindResultValue(results, name, branchName)
+	signer := FindResultValue(results, name, signerResult)
 
-	if strings.TrimSpace(commitAuthor) != "" || strings.TrimSpace(commitSha) != "" || strings.TrimSpace(branchName) != "" {
-		if buildRun.Status.Source == nil {
-			buildRun.Status.Source = &buildapi.SourceResult{}
+	if strings.TrimSpace(commitAuthor) == "" && strings.TrimSpace(commitSha) == "" && strings.TrimSpace(branchName) == "" && strings.TrimSpace(signer) == "" {
+		return nil
+	}
+
+	gitResult := &buildapi.GitSourceResult{
+		CommitAuthor:  commitAuthor,
+		CommitMessage: FindResultValue(results, name, commitMessageResult),
+		CommitSha:     commitSha,
+		BranchName:    branchName,
+		Describe:      strings.TrimSpace(FindResultValue(results, name, describeResult)),
+		Signer:        signer,
+	}
+
+	if commitTimestamp, err := strconv.ParseInt(strings.TrimSpace(FindResultValue(results, name, commitTimestampResult)), 10, 64); err == nil {
+		gitResult.CommitTimestamp = &metav1.Time{Time: time.Unix(commitTimestamp, 0)}
+	}
+
+	for _, tag := range strings.Split(FindResultValue(results, name, tagsResult), "\n") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			gitResult.Tags = append(gitResult.Tags, tag)
 		}
-		buildRun.Status.Source.Git = &buildapi.GitSourceResult{
-			CommitAuthor: commitAuthor,
-			CommitSha:    commitSha,
-			BranchName:   branchName,
+	}
+
+	if lfsObjects, err := strconv.ParseInt(FindResultValue(results, name, lfsObjectsResult), 10, 64); err == nil && lfsObjects > 0 {
+		lfsSize, _ := strconv.ParseInt(FindResultValue(results, name, lfsSizeResult), 10, 64)
+		gitResult.LFS = &buildapi.GitLFSResult{
+			Objects: lfsObjects,
+			Size:    lfsSize,
 		}
 	}
+
+	pullRequestHeadSha := FindResultValue(results, name, pullRequestHeadSHAResult)
+	if strings.TrimSpace(pullRequestHeadSha) != "" {
+		gitResult.PullRequest = &buildapi.GitPullRequestResult{
+			HeadSha:  pullRequestHeadSha,
+			BaseSha:  FindResultValue(results, name, pullRequestBaseSHAResult),
+			MergeSha: FindResultValue(results, name, pullRequestMergeSHAResult),
+		}
+	}
+
+	return gitResult
 }
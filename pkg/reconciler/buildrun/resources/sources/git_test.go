@@ -5,9 +5,12 @@
 package sources_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
@@ -32,11 +35,15 @@ var _ = Describe("Git", func() {
 			}, "default")
 		})
 
-		It("adds results for the commit sha, commit author and branch name", func() {
-			Expect(len(taskSpec.Results)).To(Equal(3))
+		It("adds results for the commit sha, commit author, branch name, commit details and LFS objects", func() {
+			Expect(len(taskSpec.Results)).To(Equal(7))
 			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-commit-sha"))
 			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-commit-author"))
 			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-default-branch-name"))
+			Expect(taskSpec.Results[3].Name).To(Equal("shp-source-default-commit-message"))
+			Expect(taskSpec.Results[4].Name).To(Equal("shp-source-default-commit-timestamp"))
+			Expect(taskSpec.Results[5].Name).To(Equal("shp-source-default-lfs-objects"))
+			Expect(taskSpec.Results[6].Name).To(Equal("shp-source-default-lfs-size"))
 		})
 
 		It("adds a step", func() {
@@ -49,6 +56,10 @@ var _ = Describe("Git", func() {
 				"--result-file-commit-sha", "$(results.shp-source-default-commit-sha.path)",
 				"--result-file-commit-author", "$(results.shp-source-default-commit-author.path)",
 				"--result-file-branch-name", "$(results.shp-source-default-branch-name.path)",
+				"--result-file-commit-message", "$(results.shp-source-default-commit-message.path)",
+				"--result-file-commit-timestamp", "$(results.shp-source-default-commit-timestamp.path)",
+				"--result-file-lfs-objects", "$(results.shp-source-default-lfs-objects.path)",
+				"--result-file-lfs-size", "$(results.shp-source-default-lfs-size.path)",
 				"--result-file-error-message", "$(results.shp-error-message.path)",
 				"--result-file-error-reason", "$(results.shp-error-reason.path)",
 				"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
@@ -56,6 +67,31 @@ var _ = Describe("Git", func() {
 		})
 	})
 
+	Context("when adding a Git source that fetches the tags", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		JustBeforeEach(func() {
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL:       "https://github.com/shipwright-io/build",
+				FetchTags: true,
+			}, "default")
+		})
+
+		It("adds results and arguments for the tags and the description of the commit", func() {
+			Expect(len(taskSpec.Results)).To(Equal(9))
+			Expect(taskSpec.Results[7].Name).To(Equal("shp-source-default-tags"))
+			Expect(taskSpec.Results[8].Name).To(Equal("shp-source-default-describe"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements(
+				"--result-file-tags", "$(results.shp-source-default-tags.path)",
+				"--result-file-describe", "$(results.shp-source-default-describe.path)",
+			))
+		})
+	})
+
 	Context("when adding a private Git source", func() {
 		var taskSpec *pipelineapi.TaskSpec
 
@@ -70,11 +106,15 @@ var _ = Describe("Git", func() {
 			}, "default")
 		})
 
-		It("adds results for the commit sha, commit author and branch name", func() {
-			Expect(len(taskSpec.Results)).To(Equal(3))
+		It("adds results for the commit sha, commit author, branch name, commit details and LFS objects", func() {
+			Expect(len(taskSpec.Results)).To(Equal(7))
 			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-commit-sha"))
 			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-commit-author"))
 			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-default-branch-name"))
+			Expect(taskSpec.Results[3].Name).To(Equal("shp-source-default-commit-message"))
+			Expect(taskSpec.Results[4].Name).To(Equal("shp-source-default-commit-timestamp"))
+			Expect(taskSpec.Results[5].Name).To(Equal("shp-source-default-lfs-objects"))
+			Expect(taskSpec.Results[6].Name).To(Equal("shp-source-default-lfs-size"))
 		})
 
 		It("adds a volume for the secret", func() {
@@ -94,6 +134,10 @@ var _ = Describe("Git", func() {
 				"--result-file-commit-sha", "$(results.shp-source-default-commit-sha.path)",
 				"--result-file-commit-author", "$(results.shp-source-default-commit-author.path)",
 				"--result-file-branch-name", "$(results.shp-source-default-branch-name.path)",
+				"--result-file-commit-message", "$(results.shp-source-default-commit-message.path)",
+				"--result-file-commit-timestamp", "$(results.shp-source-default-commit-timestamp.path)",
+				"--result-file-lfs-objects", "$(results.shp-source-default-lfs-objects.path)",
+				"--result-file-lfs-size", "$(results.shp-source-default-lfs-size.path)",
 				"--result-file-error-message", "$(results.shp-error-message.path)",
 				"--result-file-error-reason", "$(results.shp-error-reason.path)",
 				"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
@@ -199,4 +243,190 @@ var _ = Describe("Git", func() {
 			Expect(taskSpec.Steps[0].VolumeMounts).To(ContainElement(HaveField("Name", "shp-another-secret")))
 		})
 	})
+
+	Context("when a sparse checkout is configured", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		It("adds a --sparse-checkout argument per path", func() {
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL:            "https://github.com/shipwright-io/build",
+				SparseCheckout: &buildapi.SparseCheckout{Paths: []string{"cmd/git", "pkg/git"}},
+			}, "default")
+
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements(
+				"--sparse-checkout", "cmd/git",
+				"--sparse-checkout", "pkg/git",
+			))
+		})
+
+		It("does not add a --sparse-checkout argument without paths", func() {
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL:            "https://github.com/shipwright-io/build",
+				SparseCheckout: &buildapi.SparseCheckout{FromContextDir: true},
+			}, "default")
+
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Args).NotTo(ContainElement("--sparse-checkout"))
+		})
+	})
+
+	Context("when a signature verification secret is configured", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL:                         "https://github.com/shipwright-io/build",
+				SignatureVerificationSecret: ptr.To("trusted-keys"),
+			}, "default")
+		})
+
+		It("adds the signer result", func() {
+			Expect(taskSpec.Results).To(ContainElement(HaveField("Name", "shp-source-default-signer")))
+		})
+
+		It("mounts the secret and passes it to the step", func() {
+			Expect(taskSpec.Volumes).To(ContainElement(HaveField("Name", "shp-trusted-keys")))
+			Expect(taskSpec.Steps[0].VolumeMounts).To(ContainElement(HaveField("Name", "shp-trusted-keys")))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements(
+				"--signature-verification-path", "/workspace/shp-signature-verification-secret",
+				"--result-file-signer", "$(results.shp-source-default-signer.path)",
+			))
+		})
+	})
+
+	Context("when a pull request is configured", func() {
+		It("fetches the head of the pull request", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL:         "https://github.com/shipwright-io/build",
+				PullRequest: &buildapi.PullRequest{Number: 42},
+			}, "default")
+
+			Expect(taskSpec.Results).To(ContainElements(
+				HaveField("Name", "shp-source-default-pull-request-head-sha"),
+				HaveField("Name", "shp-source-default-pull-request-base-sha"),
+			))
+			Expect(taskSpec.Results).ToNot(ContainElement(HaveField("Name", "shp-source-default-pull-request-merge-sha")))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--pull-request-ref", "refs/pull/42/head"))
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--pull-request-merge"))
+		})
+
+		It("merges the pull request from a custom ref", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL:         "https://gitlab.com/shipwright-io/build",
+				PullRequest: &buildapi.PullRequest{Number: 42, Ref: ptr.To("refs/merge-requests/42/head"), Merge: true},
+			}, "default")
+
+			Expect(taskSpec.Results).To(ContainElement(HaveField("Name", "shp-source-default-pull-request-merge-sha")))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements(
+				"--pull-request-ref", "refs/merge-requests/42/head",
+				"--pull-request-merge",
+				"--result-file-pull-request-merge-sha", "$(results.shp-source-default-pull-request-merge-sha.path)",
+			))
+		})
+
+		It("records the commits of the pull request in the BuildRun status", func() {
+			buildRun := &buildapi.BuildRun{}
+			sources.AppendGitResult(buildRun, "default", []pipelineapi.TaskRunResult{
+				{Name: "shp-source-default-commit-sha", Value: *pipelineapi.NewStructuredValues("c")},
+				{Name: "shp-source-default-pull-request-head-sha", Value: *pipelineapi.NewStructuredValues("a")},
+				{Name: "shp-source-default-pull-request-base-sha", Value: *pipelineapi.NewStructuredValues("b")},
+				{Name: "shp-source-default-pull-request-merge-sha", Value: *pipelineapi.NewStructuredValues("c")},
+			})
+
+			Expect(buildRun.Status.Source.Git.PullRequest).To(Equal(&buildapi.GitPullRequestResult{
+				HeadSha:  "a",
+				BaseSha:  "b",
+				MergeSha: "c",
+			}))
+		})
+	})
+
+	Context("when recording the results of the Git source", func() {
+		It("records the commit message, timestamp, tags and description in the BuildRun status", func() {
+			buildRun := &buildapi.BuildRun{}
+			sources.AppendGitResult(buildRun, "default", []pipelineapi.TaskRunResult{
+				{Name: "shp-source-default-commit-sha", Value: *pipelineapi.NewStructuredValues("a")},
+				{Name: "shp-source-default-commit-message", Value: *pipelineapi.NewStructuredValues("prepare release 1.1.0")},
+				{Name: "shp-source-default-commit-timestamp", Value: *pipelineapi.NewStructuredValues("1691650396")},
+				{Name: "shp-source-default-tags", Value: *pipelineapi.NewStructuredValues("latest\nv1.1.0")},
+				{Name: "shp-source-default-describe", Value: *pipelineapi.NewStructuredValues("v1.1.0")},
+			})
+
+			Expect(buildRun.Status.Source.Git.CommitMessage).To(Equal("prepare release 1.1.0"))
+			Expect(buildRun.Status.Source.Git.CommitTimestamp).To(Equal(&metav1.Time{Time: time.Unix(1691650396, 0)}))
+			Expect(buildRun.Status.Source.Git.Tags).To(Equal([]string{"latest", "v1.1.0"}))
+			Expect(buildRun.Status.Source.Git.Describe).To(Equal("v1.1.0"))
+		})
+
+		It("does not record tags, a timestamp or a description if there are none", func() {
+			buildRun := &buildapi.BuildRun{}
+			sources.AppendGitResult(buildRun, "default", []pipelineapi.TaskRunResult{
+				{Name: "shp-source-default-commit-sha", Value: *pipelineapi.NewStructuredValues("a")},
+				{Name: "shp-source-default-tags", Value: *pipelineapi.NewStructuredValues("")},
+			})
+
+			Expect(buildRun.Status.Source.Git.CommitTimestamp).To(BeNil())
+			Expect(buildRun.Status.Source.Git.Tags).To(BeEmpty())
+			Expect(buildRun.Status.Source.Git.Describe).To(BeEmpty())
+		})
+	})
+
+	Context("when LFS options are configured", func() {
+		It("skips the LFS files if LFS is disabled", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL: "https://github.com/shipwright-io/sample-lfs",
+				LFS: &buildapi.LFS{Enabled: ptr.To(false), Include: []string{"assets/*"}},
+			}, "default")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElement("--skip-lfs"))
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--lfs-include"))
+		})
+
+		It("passes the patterns and the skip smudge option", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildapi.Git{
+				URL: "https://github.com/shipwright-io/sample-lfs",
+				LFS: &buildapi.LFS{Include: []string{"assets/*", "*.png"}, Exclude: []string{"docs/*"}, SkipSmudge: true},
+			}, "default")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements(
+				"--lfs-include", "assets/*",
+				"--lfs-include", "*.png",
+				"--lfs-exclude", "docs/*",
+				"--lfs-skip-smudge",
+			))
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--skip-lfs"))
+		})
+
+		It("records the downloaded LFS objects in the BuildRun status", func() {
+			buildRun := &buildapi.BuildRun{}
+			sources.AppendGitResult(buildRun, "default", []pipelineapi.TaskRunResult{
+				{Name: "shp-source-default-commit-sha", Value: *pipelineapi.NewStructuredValues("a")},
+				{Name: "shp-source-default-lfs-objects", Value: *pipelineapi.NewStructuredValues("3")},
+				{Name: "shp-source-default-lfs-size", Value: *pipelineapi.NewStructuredValues("4096")},
+			})
+
+			Expect(buildRun.Status.Source.Git.LFS).To(Equal(&buildapi.GitLFSResult{Objects: 3, Size: 4096}))
+		})
+
+		It("does not record LFS objects if none were downloaded", func() {
+			buildRun := &buildapi.BuildRun{}
+			sources.AppendGitResult(buildRun, "default", []pipelineapi.TaskRunResult{
+				{Name: "shp-source-default-commit-sha", Value: *pipelineapi.NewStructuredValues("a")},
+				{Name: "shp-source-default-lfs-objects", Value: *pipelineapi.NewStructuredValues("0")},
+				{Name: "shp-source-default-lfs-size", Value: *pipelineapi.NewStructuredValues("0")},
+			})
+
+			Expect(buildRun.Status.Source.Git.LFS).To(BeNil())
+		})
+	})
 })
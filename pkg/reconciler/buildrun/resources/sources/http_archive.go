@@ -0,0 +1,92 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+)
+
+const archiveDigestResult = "archive-digest"
+
+// AppendHTTPArchiveStep appends the HTTP archive step to the TaskSpec
+func AppendHTTPArchiveStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, archive *buildapi.HTTPArchive, name string) {
+	// append the result
+	taskSpec.Results = append(taskSpec.Results,
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, archiveDigestResult),
+			Description: "The digest of the downloaded archive.",
+		},
+	)
+
+	// initialize the step from the template and the build-specific arguments
+	archiveStep := pipelineapi.Step{
+		Name:            fmt.Sprintf("source-%s", name),
+		Image:           cfg.HTTPArchiveContainerTemplate.Image,
+		ImagePullPolicy: cfg.HTTPArchiveContainerTemplate.ImagePullPolicy,
+		Command:         cfg.HTTPArchiveContainerTemplate.Command,
+		Args: []string{
+			"--url", archive.URL,
+			"--target", fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot),
+			"--result-file-archive-digest", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, archiveDigestResult),
+			"--result-file-source-timestamp", fmt.Sprintf("$(results.%s-source-%s-source-timestamp.path)", PrefixParamsResultsVolumes, name),
+		},
+		Env:              cfg.HTTPArchiveContainerTemplate.Env,
+		ComputeResources: cfg.HTTPArchiveContainerTemplate.Resources,
+		SecurityContext:  cfg.HTTPArchiveContainerTemplate.SecurityContext,
+		WorkingDir:       cfg.HTTPArchiveContainerTemplate.WorkingDir,
+	}
+
+	if archive.SHA256 != nil {
+		archiveStep.Args = append(archiveStep.Args, "--sha256", *archive.SHA256)
+	}
+
+	if archive.StripComponents != nil && *archive.StripComponents > 0 {
+		archiveStep.Args = append(archiveStep.Args, "--strip-components", strconv.Itoa(*archive.StripComponents))
+	}
+
+	// add credentials mount, if provided
+	if archive.AuthSecret != nil {
+		AppendSecretVolume(taskSpec, *archive.AuthSecret)
+
+		secretMountPath := fmt.Sprintf("/workspace/%s-source-secret", PrefixParamsResultsVolumes)
+
+		// define the volume mount on the container
+		archiveStep.VolumeMounts = append(archiveStep.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(*archive.AuthSecret),
+			MountPath: secretMountPath,
+			ReadOnly:  true,
+		})
+
+		// append the argument
+		archiveStep.Args = append(archiveStep.Args,
+			"--secret-path", secretMountPath,
+		)
+	}
+
+	SetupHomeAndTmpVolumes(taskSpec, &archiveStep)
+	taskSpec.Steps = append(taskSpec.Steps, archiveStep)
+}
+
+// AppendHTTPArchiveResult append HTTP archive source result to build run
+func AppendHTTPArchiveResult(buildRun *buildapi.BuildRun, name string, results []pipelineapi.TaskRunResult) {
+	archiveDigest := FindResultValue(results, name, archiveDigestResult)
+
+	if strings.TrimSpace(archiveDigest) != "" {
+		if buildRun.Status.Source == nil {
+			buildRun.Status.Source = &buildapi.SourceResult{}
+		}
+		buildRun.Status.Source.HTTPArchive = &buildapi.HTTPArchiveSourceResult{
+			Digest: archiveDigest,
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+var _ = Describe("Sources", func() {
+	Context("applying the Git source override of a BuildRun", func() {
+		var build *buildapi.Build
+		var buildRun *buildapi.BuildRun
+
+		BeforeEach(func() {
+			build = &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type: buildapi.GitType,
+						Git: &buildapi.Git{
+							URL:      "https://github.com/shipwright-io/sample-go",
+							Revision: ptr.To("main"),
+						},
+					},
+				},
+			}
+
+			buildRun = &buildapi.BuildRun{
+				Spec: buildapi.BuildRunSpec{
+					Source: &buildapi.BuildRunSource{
+						Type: buildapi.GitType,
+						Git: &buildapi.BuildRunGitSource{
+							Revision: ptr.To("0c1e6ee1fa6e2a8d1a6f5d3d2d9c4b4bba0e34a1"),
+						},
+					},
+				},
+			}
+		})
+
+		It("keeps the revision of the Build when the BuildRun has no override", func() {
+			buildRun.Spec.Source = nil
+
+			Expect(resources.ApplyGitSourceOverride(build, buildRun)).To(BeTrue())
+			Expect(build.Spec.Source.Git.Revision).To(Equal(ptr.To("main")))
+		})
+
+		It("uses the revision of the BuildRun", func() {
+			Expect(resources.ApplyGitSourceOverride(build, buildRun)).To(BeTrue())
+			Expect(build.Spec.Source.Git.Revision).To(Equal(ptr.To("0c1e6ee1fa6e2a8d1a6f5d3d2d9c4b4bba0e34a1")))
+		})
+
+		It("uses the pull request of the BuildRun", func() {
+			buildRun.Spec.Source.Git.PullRequest = &buildapi.PullRequest{Number: 7, Merge: true}
+
+			Expect(resources.ApplyGitSourceOverride(build, buildRun)).To(BeTrue())
+			Expect(build.Spec.Source.Git.PullRequest).To(Equal(&buildapi.PullRequest{Number: 7, Merge: true}))
+			Expect(build.Spec.Source.Git.Revision).To(Equal(ptr.To("0c1e6ee1fa6e2a8d1a6f5d3d2d9c4b4bba0e34a1")))
+		})
+
+		It("fails when the Build has no Git source", func() {
+			build.Spec.Source = &buildapi.Source{
+				Type:        buildapi.OCIArtifactType,
+				OCIArtifact: &buildapi.OCIArtifact{Image: "ghcr.io/shipwright-io/sample-go-source"},
+			}
+
+			Expect(resources.ApplyGitSourceOverride(build, buildRun)).To(BeFalse())
+		})
+	})
+})
@@ -62,13 +62,16 @@ func (g *TaskRunGenerator) InitializeExecutor() error {
 }
 
 func (g *TaskRunGenerator) GenerateSourceAcquisitionPhase(_ *executionContext) error {
+	firstStep := len(g.taskRun.Spec.TaskSpec.Steps)
 	applySourcesToTaskSpec(g.cfg, g.taskRun.Spec.TaskSpec, g.build, g.buildRun)
+	applyStepTimeouts(g.taskRun.Spec.TaskSpec.Steps[firstStep:], effectivePhaseTimeouts(g.build, g.buildRun).Source)
 	return nil
 }
 
 func (g *TaskRunGenerator) GenerateBuildStrategyPhase(execCtx *executionContext) error {
 	addStrategyParametersToTaskSpec(g.taskRun.Spec.TaskSpec, g.strategy.GetParameters())
 
+	firstStep := len(g.taskRun.Spec.TaskSpec.Steps)
 	volumeMounts, err := applyBuildStrategySteps(
 		g.taskRun.Spec.TaskSpec,
 		g.build,
@@ -82,6 +85,7 @@ func (g *TaskRunGenerator) GenerateBuildStrategyPhase(execCtx *executionContext)
 	}
 
 	execCtx.volumeMounts = volumeMounts
+	applyStepTimeouts(g.taskRun.Spec.TaskSpec.Steps[firstStep:], effectivePhaseTimeouts(g.build, g.buildRun).Build)
 
 	return generateTaskSpecVolumes(
 		g.taskRun.Spec.TaskSpec,
@@ -98,7 +102,14 @@ func (g *TaskRunGenerator) GenerateOutputImagePhase(_ *executionContext) error {
 		buildRunOutput = &buildapi.Image{}
 	}
 
-	return SetupImageProcessing(g.taskRun, g.cfg, g.buildRun.CreationTimestamp.Time, g.build.Spec.Output, *buildRunOutput)
+	firstStep := len(g.taskRun.Spec.TaskSpec.Steps)
+	if err := SetupImageProcessing(g.taskRun, g.cfg, g.buildRun.CreationTimestamp.Time, g.buildRun.Name, g.build.Spec.Output, *buildRunOutput); err != nil {
+		return err
+	}
+	applyNetworkSettingsToImageProcessing(g.taskRun.Spec.TaskSpec, g.build)
+
+	applyStepTimeouts(g.taskRun.Spec.TaskSpec.Steps[firstStep:], effectivePhaseTimeouts(g.build, g.buildRun).Output)
+	return nil
 }
 
 func (g *TaskRunGenerator) ApplyInfrastructureConfiguration() error {
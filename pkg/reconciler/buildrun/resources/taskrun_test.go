@@ -5,7 +5,9 @@
 package resources_test
 
 import (
+	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
@@ -195,6 +198,34 @@ var _ = Describe("TaskRun Unit Tests", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(taskRun.Spec.Timeout).To(BeNil())
 			})
+
+			It("should set the phase timeouts on the steps of the phases", func() {
+				build.Spec.Source = &buildapi.Source{
+					Type: buildapi.GitType,
+					Git:  &buildapi.Git{URL: "https://github.com/shipwright-io/sample-go"},
+				}
+				build.Spec.Output.Labels = map[string]string{"maintainer": "team@my-company.com"}
+				build.Spec.Timeouts = &buildapi.PhaseTimeouts{
+					Source: &metav1.Duration{Duration: time.Minute},
+					Build:  &metav1.Duration{Duration: 10 * time.Minute},
+				}
+				buildRun.Spec.Timeouts = &buildapi.PhaseTimeouts{
+					Output: &metav1.Duration{Duration: 2 * time.Minute},
+				}
+
+				taskRun, err := resources.GenerateTaskRun(cfg, build, buildRun, serviceAccountName, buildStrategy)
+				Expect(err).ToNot(HaveOccurred())
+
+				steps := taskRun.Spec.TaskSpec.Steps
+				Expect(len(steps)).To(BeNumerically(">", 2))
+				Expect(steps[0].Name).To(Equal("source-default"))
+				Expect(steps[0].Timeout.Duration).To(Equal(time.Minute))
+				for _, step := range steps[1 : len(steps)-1] {
+					Expect(step.Timeout.Duration).To(Equal(10 * time.Minute))
+				}
+				Expect(steps[len(steps)-1].Name).To(Equal("image-processing"))
+				Expect(steps[len(steps)-1].Timeout.Duration).To(Equal(2 * time.Minute))
+			})
 		})
 
 		Context("with node selectors", func() {
@@ -547,6 +578,190 @@ var _ = Describe("TaskRun Unit Tests", func() {
 			})
 		})
 
+		Context("with a sparse checkout", func() {
+			It("should add the context dir to the sparse checkout paths when requested", func() {
+				build.Spec.Source = &buildapi.Source{
+					Type:       buildapi.GitType,
+					ContextDir: ptr.To("/docker-build/"),
+					Git: &buildapi.Git{
+						URL: "https://github.com/shipwright-io/sample-go",
+						SparseCheckout: &buildapi.SparseCheckout{
+							Paths:          []string{"scripts"},
+							FromContextDir: true,
+						},
+					},
+				}
+
+				taskRun, err := resources.GenerateTaskRun(cfg, build, buildRun, serviceAccountName, buildStrategy)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(taskRun.Spec.TaskSpec.Steps[0].Name).To(Equal("source-default"))
+				Expect(taskRun.Spec.TaskSpec.Steps[0].Args).To(ContainElements(
+					"--sparse-checkout", "scripts",
+					"--sparse-checkout", "docker-build",
+				))
+				Expect(build.Spec.Source.Git.SparseCheckout.Paths).To(Equal([]string{"scripts"}))
+			})
+		})
+
+		Context("with additional sources", func() {
+			It("should add a step per additional source that fetches it into its target directory", func() {
+				build.Spec.Source = &buildapi.Source{
+					Type: buildapi.GitType,
+					Git:  &buildapi.Git{URL: "https://github.com/shipwright-io/sample-go"},
+				}
+				build.Spec.AdditionalSources = []buildapi.AdditionalSource{{
+					Name:      "scripts",
+					Type:      buildapi.GitType,
+					TargetDir: "build/scripts",
+					Git: &buildapi.Git{
+						URL:         "https://github.com/shipwright-io/build-scripts",
+						CloneSecret: ptr.To("scripts-secret"),
+					},
+				}, {
+					Name:        "protos",
+					Type:        buildapi.OCIArtifactType,
+					TargetDir:   "protos",
+					OCIArtifact: &buildapi.OCIArtifact{Image: "ghcr.io/shipwright-io/protos:latest"},
+				}}
+
+				taskRun, err := resources.GenerateTaskRun(cfg, build, buildRun, serviceAccountName, buildStrategy)
+				Expect(err).ToNot(HaveOccurred())
+
+				steps := taskRun.Spec.TaskSpec.Steps
+				Expect(steps[0].Name).To(Equal("source-default"))
+				Expect(steps[0].Args).To(ContainElements("--target", "$(params.shp-source-root)"))
+
+				Expect(steps[1].Name).To(Equal("source-scripts"))
+				Expect(steps[1].Image).To(Equal(cfg.GitContainerTemplate.Image))
+				Expect(steps[1].Args).To(ContainElements("--target", "$(params.shp-source-root)/build/scripts"))
+				Expect(steps[1].Args).To(ContainElements("--url", "https://github.com/shipwright-io/build-scripts"))
+				Expect(steps[1].Args).To(ContainElement("--secret-path"))
+
+				Expect(steps[2].Name).To(Equal("source-protos"))
+				Expect(steps[2].Image).To(Equal(cfg.BundleContainerTemplate.Image))
+				Expect(steps[2].Args).To(ContainElements("--target", "$(params.shp-source-root)/protos"))
+
+				var resultNames []string
+				for _, result := range taskRun.Spec.TaskSpec.Results {
+					resultNames = append(resultNames, result.Name)
+				}
+				Expect(resultNames).To(ContainElements(
+					"shp-source-scripts-commit-sha",
+					"shp-source-scripts-source-timestamp",
+					"shp-source-protos-image-digest",
+					"shp-source-protos-source-timestamp",
+				))
+			})
+		})
+
+		Context("with a trusted CA and a proxy", func() {
+			It("should pass the CA bundle and the proxy to the source and image processing steps", func() {
+				build.Spec.Source = &buildapi.Source{
+					Type: buildapi.GitType,
+					Git:  &buildapi.Git{URL: "https://git.example.com/shipwright-io/sample-go"},
+				}
+				build.Spec.TrustedCA = &buildapi.TrustedCA{ConfigMap: "internal-ca"}
+				build.Spec.Proxy = &buildapi.Proxy{
+					HTTPSProxy: ptr.To("http://proxy.example.com:3128"),
+					NoProxy:    ptr.To(".cluster.local"),
+				}
+				buildRun.Spec.Output = &buildapi.Image{
+					Image:  "registry.example.com/test:latest",
+					Labels: map[string]string{"version": "1.0.0"},
+				}
+
+				taskRun, err := resources.GenerateTaskRun(cfg, build, buildRun, serviceAccountName, buildStrategy)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(taskRun.Spec.TaskSpec.Volumes).To(ContainElement(And(
+					HaveField("Name", "shp-trusted-ca"),
+					HaveField("VolumeSource.ConfigMap.Name", "internal-ca"),
+					HaveField("VolumeSource.ConfigMap.Items", []corev1.KeyToPath{{Key: "ca-bundle.crt", Path: "ca-bundle.crt"}}),
+				)))
+
+				for _, stepName := range []string{"source-default", "image-processing"} {
+					var step *pipelineapi.Step
+					for i := range taskRun.Spec.TaskSpec.Steps {
+						if taskRun.Spec.TaskSpec.Steps[i].Name == stepName {
+							step = &taskRun.Spec.TaskSpec.Steps[i]
+						}
+					}
+					Expect(step).ToNot(BeNil(), stepName)
+
+					Expect(step.Args).To(ContainElements("--ca-bundle", "/workspace/shp-trusted-ca/ca-bundle.crt"), stepName)
+					Expect(step.VolumeMounts).To(ContainElement(HaveField("Name", "shp-trusted-ca")), stepName)
+					Expect(step.Env).To(ContainElements(
+						corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+						corev1.EnvVar{Name: "https_proxy", Value: "http://proxy.example.com:3128"},
+						corev1.EnvVar{Name: "NO_PROXY", Value: ".cluster.local"},
+						corev1.EnvVar{Name: "no_proxy", Value: ".cluster.local"},
+					), stepName)
+					Expect(step.Env).ToNot(ContainElement(HaveField("Name", "HTTP_PROXY")), stepName)
+				}
+
+				Expect(cfg.GitContainerTemplate.Env).ToNot(ContainElement(HaveField("Name", "HTTPS_PROXY")))
+			})
+
+			It("should pass the CA bundle to the HTTP archive step", func() {
+				build.Spec.Source = &buildapi.Source{
+					Type:        buildapi.HTTPType,
+					HTTPArchive: &buildapi.HTTPArchive{URL: "https://files.example.com/sources/app-1.0.tar.gz"},
+				}
+				build.Spec.TrustedCA = &buildapi.TrustedCA{ConfigMap: "internal-ca"}
+
+				taskRun, err := resources.GenerateTaskRun(cfg, build, buildRun, serviceAccountName, buildStrategy)
+				Expect(err).ToNot(HaveOccurred())
+
+				step := taskRun.Spec.TaskSpec.Steps[0]
+				Expect(step.Name).To(Equal("source-default"))
+				Expect(step.Args).To(ContainElements("--ca-bundle", "/workspace/shp-trusted-ca/ca-bundle.crt"))
+				Expect(step.VolumeMounts).To(ContainElement(HaveField("Name", "shp-trusted-ca")))
+			})
+		})
+
+		Context("with placeholders in the tag of the output image", func() {
+			It("should pass the output image without the tag to the buildah strategy and resolve the tag in the image processing", func() {
+				data, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "samples", "v1beta1", "buildstrategy", "buildah", "buildstrategy_buildah_shipwright_managed_push_cr.yaml"))
+				Expect(err).ToNot(HaveOccurred())
+				buildah, err := ctl.LoadCBSWithName("buildah-shipwright-managed-push", data)
+				Expect(err).ToNot(HaveOccurred())
+
+				build.Spec.Source = &buildapi.Source{
+					Type: buildapi.GitType,
+					Git:  &buildapi.Git{URL: "https://github.com/shipwright-io/sample-go"},
+				}
+				build.Spec.Output.Image = "registry.example.com/org/app:$(source.git.commitSha)"
+				buildRun.Spec.Output = nil
+
+				taskRun, err := resources.GenerateTaskRun(cfg, build, buildRun, serviceAccountName, buildah)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(taskRun.Spec.Params).To(ContainElement(And(
+					HaveField("Name", "shp-output-image"),
+					HaveField("Value.StringVal", "registry.example.com/org/app"),
+				)))
+
+				var buildStep, imageProcessingStep *pipelineapi.Step
+				for i := range taskRun.Spec.TaskSpec.Steps {
+					switch taskRun.Spec.TaskSpec.Steps[i].Name {
+					case "build":
+						buildStep = &taskRun.Spec.TaskSpec.Steps[i]
+					case "image-processing":
+						imageProcessingStep = &taskRun.Spec.TaskSpec.Steps[i]
+					}
+				}
+				Expect(buildStep).ToNot(BeNil())
+				Expect(imageProcessingStep).ToNot(BeNil())
+
+				Expect(buildStep.Args).To(ContainElements("--image", "$(params.shp-output-image)"))
+				Expect(imageProcessingStep.Args).To(ContainElements(
+					"--image", "registry.example.com/org/app:$(source.git.commitSha)",
+					"--git-commit-sha-file", "$(results.shp-source-default-commit-sha.path)",
+				))
+			})
+		})
+
 		Context("with environment variables", func() {
 			It("should handle environment variables from Build", func() {
 				build.Spec.Env = []corev1.EnvVar{
@@ -1814,6 +2029,43 @@ var _ = Describe("TaskRun Unit Tests", func() {
 				Expect(buildStep.ComputeResources.Limits.Cpu().String()).To(Equal("4"))
 				Expect(buildStep.ComputeResources.Limits.Memory().String()).To(Equal("4Gi"))
 			})
+
+			It("should use the escalated step resources of the BuildRun status over all other overrides", func() {
+				buildRun.Spec.StepResources = []buildapi.StepResourceOverride{
+					{
+						Name: "build",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				}
+				buildRun.Status.StepResources = []buildapi.StepResourceOverride{
+					{
+						Name: "build",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+						},
+					},
+				}
+
+				taskRun, err := resources.GenerateTaskRun(cfg, build, buildRun, serviceAccountName, strategyWithResources)
+				Expect(err).ToNot(HaveOccurred())
+
+				var buildStep *pipelineapi.Step
+				for i := range taskRun.Spec.TaskSpec.Steps {
+					if taskRun.Spec.TaskSpec.Steps[i].Name == "build" {
+						buildStep = &taskRun.Spec.TaskSpec.Steps[i]
+						break
+					}
+				}
+
+				Expect(buildStep).ToNot(BeNil())
+				Expect(buildStep.ComputeResources.Limits.Memory().String()).To(Equal("2Gi"))
+			})
 		})
 
 		Context("with forbidden env vars in BuildStrategy steps", func() {
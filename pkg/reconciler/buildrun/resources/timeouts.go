@@ -0,0 +1,152 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const (
+	phaseSource = "source"
+	phaseBuild  = "build"
+	phaseOutput = "output"
+
+	// stepTimeoutExceeded is the termination reason that Tekton sets on a step that did not finish within its timeout
+	stepTimeoutExceeded = "TimeoutExceeded"
+
+	// labelPipelineTask is the label that Tekton sets on the TaskRuns of a PipelineRun with the name of their pipeline task
+	labelPipelineTask = "tekton.dev/pipelineTask"
+)
+
+// phaseTimeout describes a phase of a BuildRun that did not finish within its timeout
+type phaseTimeout struct {
+	phase   string
+	timeout *metav1.Duration
+}
+
+// failureReason returns the failure reason of the phase timeout
+func (p phaseTimeout) failureReason() string {
+	switch p.phase {
+	case phaseSource:
+		return buildapi.SourcePhaseTimeout
+	case phaseOutput:
+		return buildapi.OutputPhaseTimeout
+	default:
+		return buildapi.BuildPhaseTimeout
+	}
+}
+
+// message returns the message of the phase timeout
+func (p phaseTimeout) message(buildRunName string) string {
+	if p.timeout == nil {
+		return fmt.Sprintf("BuildRun %s failed to finish the %s phase within its timeout", buildRunName, p.phase)
+	}
+
+	return fmt.Sprintf("BuildRun %s failed to finish the %s phase within %s", buildRunName, p.phase, p.timeout.Duration)
+}
+
+// effectivePhaseTimeouts returns the phase timeouts of the BuildRun, every phase timeout of
+// the BuildRun takes precedence over the one of the Build
+func effectivePhaseTimeouts(build *buildapi.Build, buildRun *buildapi.BuildRun) buildapi.PhaseTimeouts {
+	var timeouts buildapi.PhaseTimeouts
+	if build.Spec.Timeouts != nil {
+		timeouts = *build.Spec.Timeouts.DeepCopy()
+	}
+
+	if buildRun.Spec.Timeouts != nil {
+		if buildRun.Spec.Timeouts.Source != nil {
+			timeouts.Source = buildRun.Spec.Timeouts.Source
+		}
+		if buildRun.Spec.Timeouts.Build != nil {
+			timeouts.Build = buildRun.Spec.Timeouts.Build
+		}
+		if buildRun.Spec.Timeouts.Output != nil {
+			timeouts.Output = buildRun.Spec.Timeouts.Output
+		}
+	}
+
+	return timeouts
+}
+
+// applyStepTimeouts sets the timeout of a phase on its steps, steps that already have
+// their own timeout keep it
+func applyStepTimeouts(steps []pipelineapi.Step, timeout *metav1.Duration) {
+	if timeout == nil {
+		return
+	}
+
+	for i := range steps {
+		if steps[i].Timeout == nil {
+			steps[i].Timeout = timeout.DeepCopy()
+		}
+	}
+}
+
+// stepPhase returns the phase of a step of a TaskRun, the source steps and the image
+// processing step are added by the build controller, all other steps are the ones of
+// the build strategy
+func stepPhase(stepName string) string {
+	switch {
+	case strings.HasPrefix(stepName, "source-"):
+		return phaseSource
+	case stepName == containerNameImageProcessing:
+		return phaseOutput
+	default:
+		return phaseBuild
+	}
+}
+
+// pipelineTaskPhase returns the phase of a pipeline task of a PipelineRun
+func pipelineTaskPhase(pipelineTaskName string) string {
+	switch {
+	case pipelineTaskName == "source-acquisition":
+		return phaseSource
+	case pipelineTaskName == "output-image":
+		return phaseOutput
+	default:
+		return phaseBuild
+	}
+}
+
+// taskRunStepTimeout returns the phase of the step of a TaskRun that did not finish within its timeout
+func taskRunStepTimeout(taskRun *pipelineapi.TaskRun) (phaseTimeout, bool) {
+	for _, step := range taskRun.Status.Steps {
+		if step.TerminationReason != stepTimeoutExceeded {
+			continue
+		}
+
+		result := phaseTimeout{phase: stepPhase(step.Name)}
+		if taskRun.Spec.TaskSpec != nil {
+			for _, specStep := range taskRun.Spec.TaskSpec.Steps {
+				if specStep.Name == step.Name {
+					result.timeout = specStep.Timeout
+				}
+			}
+		}
+
+		return result, true
+	}
+
+	return phaseTimeout{}, false
+}
+
+// pipelineTaskTimeout returns the phase of a TaskRun of a PipelineRun that did not finish within its timeout
+func pipelineTaskTimeout(taskRun *pipelineapi.TaskRun) (phaseTimeout, bool) {
+	pipelineTaskName, found := taskRun.Labels[labelPipelineTask]
+	if !found {
+		return phaseTimeout{}, false
+	}
+
+	return phaseTimeout{
+		phase:   pipelineTaskPhase(pipelineTaskName),
+		timeout: taskRun.Spec.Timeout,
+	}, true
+}
@@ -0,0 +1,71 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+// maxBuildRunNameLength is the maximum length of a BuildRun name, which must be a valid label value
+const maxBuildRunNameLength = 63
+
+// TriggeredBuildRunName returns the name of a BuildRun that a trigger creates for a Build, the
+// Build name is shortened so that the name including the suffix is a valid label value
+func TriggeredBuildRunName(buildName string, suffix string) string {
+	if len(buildName)+len(suffix)+1 > maxBuildRunNameLength {
+		buildName = buildName[:maxBuildRunNameLength-len(suffix)-1]
+	}
+
+	return buildName + "-" + suffix
+}
+
+// CreateTriggeredBuildRun creates a BuildRun for a Build on behalf of one of its triggers. The name
+// of the BuildRun is derived from the Build name and the given suffix. If a BuildRun with that name
+// already exists, it is not created again, so that a trigger event never leads to more than one BuildRun.
+// The optional source overrides the source of the Build, for example to pin the Git revision of an event.
+func CreateTriggeredBuildRun(ctx context.Context, c client.Client, build *buildapi.Build, triggerName string, nameSuffix string, annotations map[string]string, source *buildapi.BuildRunSource) (*buildapi.BuildRun, error) {
+	buildRun := &buildapi.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TriggeredBuildRunName(build.Name, nameSuffix),
+			Namespace: build.Namespace,
+			Labels: map[string]string{
+				buildapi.LabelBuild:           build.Name,
+				buildapi.LabelBuildGeneration: strconv.FormatInt(build.Generation, 10),
+			},
+			Annotations: map[string]string{
+				buildapi.AnnotationBuildRunTrigger: triggerName,
+			},
+		},
+		Spec: buildapi.BuildRunSpec{
+			Build: buildapi.ReferencedBuild{
+				Name: &build.Name,
+			},
+			Source: source,
+		},
+	}
+
+	for key, value := range annotations {
+		buildRun.Annotations[key] = value
+	}
+
+	if err := c.Create(ctx, buildRun); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		ctxlog.Debug(ctx, "BuildRun for the trigger event already exists", namespace, build.Namespace, name, buildRun.Name, "trigger", triggerName)
+		return buildRun, nil
+	}
+
+	ctxlog.Info(ctx, "created BuildRun for trigger", namespace, build.Namespace, name, buildRun.Name, "trigger", triggerName)
+	return buildRun, nil
+}
@@ -0,0 +1,82 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+var _ = Describe("Triggered BuildRuns", func() {
+	var (
+		client *fakes.FakeClient
+		build  *buildapi.Build
+	)
+
+	BeforeEach(func() {
+		client = &fakes.FakeClient{}
+		build = &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobuild", Namespace: "foo", Generation: 3},
+		}
+	})
+
+	It("shortens the Build name so that the BuildRun name is a valid label value", func() {
+		Expect(resources.TriggeredBuildRunName("foobuild", "123")).To(Equal("foobuild-123"))
+
+		buildRunName := resources.TriggeredBuildRunName(strings.Repeat("a", 63), "123")
+		Expect(buildRunName).To(HaveLen(63))
+		Expect(buildRunName).To(HaveSuffix("a-123"))
+	})
+
+	It("creates a BuildRun that references the Build", func() {
+		buildRun, err := resources.CreateTriggeredBuildRun(context.TODO(), client, build, "nightly", "123", map[string]string{"foo": "bar"}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.CreateCallCount()).To(Equal(1))
+
+		Expect(buildRun.Name).To(Equal("foobuild-123"))
+		Expect(buildRun.Namespace).To(Equal("foo"))
+		Expect(buildRun.Spec.Build.Name).To(Equal(ptr.To("foobuild")))
+		Expect(buildRun.Labels).To(HaveKeyWithValue(buildapi.LabelBuild, "foobuild"))
+		Expect(buildRun.Labels).To(HaveKeyWithValue(buildapi.LabelBuildGeneration, "3"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTrigger, "nightly"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue("foo", "bar"))
+	})
+
+	It("creates a BuildRun that overrides the source of the Build", func() {
+		buildRun, err := resources.CreateTriggeredBuildRun(context.TODO(), client, build, "push", "123", nil, &buildapi.BuildRunSource{
+			Type: buildapi.GitType,
+			Git:  &buildapi.BuildRunGitSource{Revision: ptr.To("0c1e6ee1fa6e2a8d1a6f5d3d2d9c4b4bba0e34a1")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRun.Spec.Source).ToNot(BeNil())
+		Expect(buildRun.Spec.Source.Git.Revision).To(Equal(ptr.To("0c1e6ee1fa6e2a8d1a6f5d3d2d9c4b4bba0e34a1")))
+	})
+
+	It("accepts an already existing BuildRun", func() {
+		client.CreateReturns(errors.NewAlreadyExists(schema.GroupResource{}, "foobuild-123"))
+
+		buildRun, err := resources.CreateTriggeredBuildRun(context.TODO(), client, build, "nightly", "123", nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRun.Name).To(Equal("foobuild-123"))
+	})
+
+	It("returns other errors", func() {
+		client.CreateReturns(errors.NewForbidden(schema.GroupResource{}, "foobuild-123", nil))
+
+		_, err := resources.CreateTriggeredBuildRun(context.TODO(), client, build, "nightly", "123", nil, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,27 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package buildrun
+
+import (
+	"context"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+// retryBuildRun records the failed attempt of the executor in the BuildRun status and removes the
+// executor reference. The update of the BuildRun triggers a reconciliation that creates the executor
+// of the next attempt once the backoff of the retry policy passed.
+func (r *ReconcileBuildRun) retryBuildRun(ctx context.Context, buildRun *buildapi.BuildRun, buildRunner ImageBuildRunner) error {
+	buildRun.Status.Executor = &buildapi.BuildExecutor{
+		Name: buildRunner.GetName(),
+		Kind: buildRunner.GetExecutorKind(),
+	}
+	resources.RecordFailedAttempt(buildRun, buildRunner.GetCompletionTime())
+
+	ctxlog.Info(ctx, "retrying the failed attempt of the BuildRun", namespace, buildRun.Namespace, name, buildRun.Name, "attempts", len(buildRun.Status.Attempts))
+	return r.client.Status().Update(ctx, buildRun)
+}
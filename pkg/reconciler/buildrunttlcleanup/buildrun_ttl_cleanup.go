@@ -12,6 +12,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -21,18 +22,24 @@ import (
 	"github.com/shipwright-io/build/pkg/ctxlog"
 )
 
+// TTLExpired is the reason of the event that is emitted when a BuildRun is deleted
+// because its time to live after completion has passed
+const TTLExpired = "TTLExpired"
+
 // ReconcileBuildRun reconciles a BuildRun object
 type ReconcileBuildRun struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	config *config.Config
-	client client.Client
+	config   *config.Config
+	client   client.Client
+	recorder events.EventRecorder
 }
 
-func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+func NewReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder) reconcile.Reconciler {
 	return &ReconcileBuildRun{
-		config: c,
-		client: client.WithFieldOwner(mgr.GetClient(), "shipwright-buildrun-ttl-cleanup-controller"),
+		config:   c,
+		client:   client.WithFieldOwner(mgr.GetClient(), "shipwright-buildrun-ttl-cleanup-controller"),
+		recorder: recorder,
 	}
 }
 
@@ -90,6 +97,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			ctxlog.Debug(ctx, "Error deleting buildRun. It has already been deleted.", namespace, request.Namespace, name, br.Name)
 			return reconcile.Result{}, nil
 		}
+		r.recorder.Eventf(br, nil, corev1.EventTypeNormal, TTLExpired, "Delete", "deleted BuildRun %s as its time to live of %s has passed", br.Name, ttl.Duration)
 	} else {
 		timeLeft := time.Until(br.Status.CompletionTime.Add(ttl.Duration))
 		return reconcile.Result{RequeueAfter: timeLeft}, nil
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -27,8 +28,8 @@ const (
 
 // Add creates a new BuildRun_ttl_cleanup Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func Add(_ context.Context, c *config.Config, mgr manager.Manager) error {
-	return add(mgr, NewReconciler(c, mgr), c.Controllers.BuildRun.MaxConcurrentReconciles)
+func Add(_ context.Context, c *config.Config, mgr manager.Manager, recorder events.EventRecorder) error {
+	return add(mgr, NewReconciler(c, mgr, recorder), c.Controllers.BuildRun.MaxConcurrentReconciles)
 }
 
 // reconcileCompletedBuildRun returns true if the object has the required TTL parameters
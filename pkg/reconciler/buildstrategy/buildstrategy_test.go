@@ -9,9 +9,15 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/controller/fakes"
 	"github.com/shipwright-io/build/pkg/reconciler/buildstrategy"
@@ -23,6 +29,9 @@ var _ = Describe("Reconcile BuildStrategy", func() {
 		reconciler                   reconcile.Reconciler
 		request                      reconcile.Request
 		namespace, buildStrategyName string
+		client                       *fakes.FakeClient
+		statusWriter                 *fakes.FakeStatusWriter
+		buildStrategySample          *buildapi.BuildStrategy
 	)
 
 	BeforeEach(func() {
@@ -32,6 +41,29 @@ var _ = Describe("Reconcile BuildStrategy", func() {
 		// Fake the manager and get a reconcile Request
 		manager = &fakes.FakeManager{}
 		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildStrategyName, Namespace: namespace}}
+
+		buildStrategySample = &buildapi.BuildStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: buildStrategyName, Namespace: namespace},
+			Spec: buildapi.BuildStrategySpec{
+				Steps: []buildapi.Step{{Name: "build", Image: "alpine", Command: []string{"true"}}},
+			},
+		}
+
+		// Fake the client GET calls when reconciling,
+		// in order to get our BuildStrategy instance
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *buildapi.BuildStrategy:
+				buildStrategySample.DeepCopyInto(object)
+			default:
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			}
+			return nil
+		})
+		statusWriter = &fakes.FakeStatusWriter{}
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+		manager.GetClientReturns(client)
 	})
 
 	JustBeforeEach(func() {
@@ -46,6 +78,57 @@ var _ = Describe("Reconcile BuildStrategy", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(reconcile.Result{}).To(Equal(result))
 			})
+
+			It("sets the Ready condition to true for a valid BuildStrategy", func() {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildapi.BuildStrategy).Status.GetCondition(buildapi.Ready)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(string(buildapi.BuildStrategySucceeded)))
+			})
+
+			It("sets the Ready condition to false for an invalid BuildStrategy", func() {
+				buildStrategySample.Spec.Steps[0].Args = []string{"$(params.undefined)"}
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildapi.BuildStrategy).Status.GetCondition(buildapi.Ready)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(string(buildapi.BuildStrategyUndefinedParameter)))
+				Expect(condition.Message).To(ContainSubstring("undefined"))
+			})
+
+			It("does not update the status if the Ready condition did not change", func() {
+				buildStrategySample.Status.SetCondition(&buildapi.Condition{
+					Type:    buildapi.Ready,
+					Status:  corev1.ConditionTrue,
+					Reason:  string(buildapi.BuildStrategySucceeded),
+					Message: buildapi.AllStrategyValidationsSucceeded,
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the BuildStrategy does not exist", func() {
+			It("succeed without updating the status", func() {
+				client.GetReturns(errors.NewNotFound(schema.GroupResource{}, buildStrategyName))
+				client.GetCalls(nil)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+			})
 		})
 	})
 })
@@ -7,13 +7,18 @@ package clusterbuildstrategy
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/validate"
 )
 
 // blank assignment to verify that ReconcileClusterBuildStrategy implements reconcile.Reconciler
@@ -46,5 +51,48 @@ func (r *ReconcileClusterBuildStrategy) Reconcile(ctx context.Context, request r
 	defer cancel()
 
 	ctxlog.Info(ctx, "reconciling ClusterBuildStrategy", "name", request.Name)
+
+	clusterBuildStrategy := &buildapi.ClusterBuildStrategy{}
+	if err := r.client.Get(ctx, request.NamespacedName, clusterBuildStrategy); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling ClusterBuildStrategy, it was not found", "name", request.Name)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	valid, reason, message := validate.BuildStrategySpec(clusterBuildStrategy)
+
+	status := corev1.ConditionTrue
+	if !valid {
+		status = corev1.ConditionFalse
+		ctxlog.Info(ctx, "ClusterBuildStrategy is not valid", "name", request.Name, "reason", reason, "message", message)
+	}
+
+	// only update the status if the outcome of the validation changed
+	lastTransitionTime := metav1.Now()
+	if condition := clusterBuildStrategy.Status.GetCondition(buildapi.Ready); condition != nil {
+		if condition.Status == status && condition.Reason == string(reason) && condition.Message == message {
+			return reconcile.Result{}, nil
+		}
+
+		if condition.Status == status {
+			lastTransitionTime = condition.LastTransitionTime
+		}
+	}
+
+	clusterBuildStrategy.Status.SetCondition(&buildapi.Condition{
+		Type:               buildapi.Ready,
+		Status:             status,
+		LastTransitionTime: lastTransitionTime,
+		Reason:             string(reason),
+		Message:            message,
+	})
+
+	if err := r.client.Status().Update(ctx, clusterBuildStrategy); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
@@ -9,20 +9,30 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/controller/fakes"
 	"github.com/shipwright-io/build/pkg/reconciler/clusterbuildstrategy"
+	test "github.com/shipwright-io/build/test/v1beta1_samples"
 )
 
 var _ = Describe("Reconcile ClusterBuildStrategy", func() {
 	var (
-		manager           *fakes.FakeManager
-		reconciler        reconcile.Reconciler
-		request           reconcile.Request
-		buildStrategyName string
+		manager                    *fakes.FakeManager
+		reconciler                 reconcile.Reconciler
+		request                    reconcile.Request
+		buildStrategyName          string
+		client                     *fakes.FakeClient
+		statusWriter               *fakes.FakeStatusWriter
+		clusterBuildStrategySample *buildapi.ClusterBuildStrategy
+		ctl                        test.Catalog
 	)
 
 	BeforeEach(func() {
@@ -31,6 +41,24 @@ var _ = Describe("Reconcile ClusterBuildStrategy", func() {
 		// Fake the manager and get a reconcile Request
 		manager = &fakes.FakeManager{}
 		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildStrategyName}}
+
+		clusterBuildStrategySample = ctl.ClusterBuildStrategy(buildStrategyName)
+
+		// Fake the client GET calls when reconciling,
+		// in order to get our ClusterBuildStrategy instance
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *buildapi.ClusterBuildStrategy:
+				clusterBuildStrategySample.DeepCopyInto(object)
+			default:
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			}
+			return nil
+		})
+		statusWriter = &fakes.FakeStatusWriter{}
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+		manager.GetClientReturns(client)
 	})
 
 	JustBeforeEach(func() {
@@ -45,6 +73,57 @@ var _ = Describe("Reconcile ClusterBuildStrategy", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(reconcile.Result{}).To(Equal(result))
 			})
+
+			It("sets the Ready condition to true for a valid ClusterBuildStrategy", func() {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildapi.ClusterBuildStrategy).Status.GetCondition(buildapi.Ready)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(string(buildapi.BuildStrategySucceeded)))
+			})
+
+			It("sets the Ready condition to false for an invalid ClusterBuildStrategy", func() {
+				clusterBuildStrategySample.Spec.Steps[0].Args = []string{"$(params.undefined)"}
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildapi.ClusterBuildStrategy).Status.GetCondition(buildapi.Ready)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(string(buildapi.BuildStrategyUndefinedParameter)))
+				Expect(condition.Message).To(ContainSubstring("undefined"))
+			})
+
+			It("does not update the status if the Ready condition did not change", func() {
+				clusterBuildStrategySample.Status.SetCondition(&buildapi.Condition{
+					Type:    buildapi.Ready,
+					Status:  corev1.ConditionTrue,
+					Reason:  string(buildapi.BuildStrategySucceeded),
+					Message: buildapi.AllStrategyValidationsSucceeded,
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the ClusterBuildStrategy does not exist", func() {
+			It("succeed without updating the status", func() {
+				client.GetReturns(errors.NewNotFound(schema.GroupResource{}, buildStrategyName))
+				client.GetCalls(nil)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+			})
 		})
 	})
 })
@@ -0,0 +1,227 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package imagetrigger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/image"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+const (
+	// ImageChanged is the reason of the event that is emitted when a BuildRun is created because
+	// the digest of an image of an Image trigger changed
+	ImageChanged = "ImageChanged"
+
+	// ImageLookupFailed is the reason of the event that is emitted when the digest of an image of
+	// an Image trigger cannot be looked up
+	ImageLookupFailed = "ImageLookupFailed"
+
+	userAgent = "Shipwright Build"
+)
+
+// ReconcileBuild reconciles the Image triggers of a Build object
+type ReconcileBuild struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config   *config.Config
+	client   client.Client
+	recorder events.EventRecorder
+}
+
+func NewReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder) reconcile.Reconciler {
+	return &ReconcileBuild{
+		config:   c,
+		client:   client.WithFieldOwner(mgr.GetClient(), "shipwright-image-trigger-controller"),
+		recorder: recorder,
+	}
+}
+
+// Reconcile looks up the digests of the images of the Image triggers of a Build, and creates a
+// BuildRun when a digest changed since the last lookup. The Build is requeued for the next lookup.
+func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "Start reconciling image triggers", namespace, request.Namespace, name, request.Name)
+
+	b := &buildapi.Build{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, b); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "Finish reconciling image triggers. Build was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// only a valid Build with an Image trigger is watched, the Build is reconciled again once it becomes valid,
+	// or once an Image trigger is added
+	if !hasImageTrigger(b) || b.Status.Registered == nil || *b.Status.Registered != corev1.ConditionTrue {
+		return reconcile.Result{}, nil
+	}
+
+	statusChanged := false
+	for _, when := range b.Spec.Trigger.When {
+		if when.Type != buildapi.ImageTrigger || when.Image == nil {
+			continue
+		}
+
+		var previousImages []buildapi.TriggerImageStatus
+		var imageGeneration int64
+		if triggerStatus := b.Status.GetTriggerStatus(when.Name); triggerStatus != nil {
+			previousImages = triggerStatus.Images
+			imageGeneration = triggerStatus.ImageGeneration
+		}
+
+		images, changedImages := r.lookupImages(ctx, b, when, previousImages)
+
+		if len(changedImages) > 0 {
+			imageGeneration++
+
+			// the name is derived from the changed digests and the generation so that a change never creates more
+			// than one BuildRun, while a digest that changes back to an earlier value creates a new one
+			buildRun, err := resources.CreateTriggeredBuildRun(ctx, r.client, b, when.Name, changeSuffix(when.Name, imageGeneration, changedImages), map[string]string{
+				buildapi.AnnotationBuildRunTriggerImage: strings.Join(changedImages, ","),
+			}, nil)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
+			r.recorder.Eventf(b, buildRun, corev1.EventTypeNormal, ImageChanged, "Trigger", "created BuildRun %s for trigger %s as the digest of %s changed", buildRun.Name, when.Name, strings.Join(changedImages, ", "))
+		}
+
+		if !reflect.DeepEqual(images, previousImages) {
+			b.Status.SetTriggerStatus(buildapi.TriggerStatus{
+				Name:            when.Name,
+				Images:          images,
+				ImageGeneration: imageGeneration,
+			})
+			statusChanged = true
+		}
+	}
+
+	if statusChanged {
+		if err := r.client.Status().Update(ctx, b); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	ctxlog.Debug(ctx, "Finishing reconciling image triggers", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{RequeueAfter: r.config.ImageTriggerPollInterval}, nil
+}
+
+// lookupImages looks up the digests of the images of an Image trigger. It returns the digests to
+// record in the status, and the images whose digest changed in the format name@digest. An image
+// that is seen for the first time is only recorded.
+func (r *ReconcileBuild) lookupImages(ctx context.Context, b *buildapi.Build, when buildapi.TriggerWhen, previousImages []buildapi.TriggerImageStatus) ([]buildapi.TriggerImageStatus, []string) {
+	previousDigests := map[string]string{}
+	for _, previousImage := range previousImages {
+		previousDigests[previousImage.Name] = previousImage.Digest
+	}
+
+	dockerConfigDirectory, cleanup, err := r.dockerConfigDirectory(ctx, b.Namespace, when.Image.PullSecret)
+	if err != nil {
+		ctxlog.Error(ctx, err, "failed to read the pull secret", namespace, b.Namespace, name, b.Name, "trigger", when.Name)
+		r.recorder.Eventf(b, nil, corev1.EventTypeWarning, ImageLookupFailed, "Trigger", "failed to read the pull secret of trigger %s: %v", when.Name, err)
+		return previousImages, nil
+	}
+	defer cleanup()
+
+	var images []buildapi.TriggerImageStatus
+	var changedImages []string
+	for _, imageName := range when.Image.Names {
+		digest, err := lookupDigest(ctx, imageName, ptr.Deref(when.Image.Insecure, false), dockerConfigDirectory)
+		if err != nil {
+			ctxlog.Error(ctx, err, "failed to look up image digest", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "image", imageName)
+			r.recorder.Eventf(b, nil, corev1.EventTypeWarning, ImageLookupFailed, "Trigger", "failed to look up the digest of image %s of trigger %s: %v", imageName, when.Name, err)
+
+			// keep the last seen digest so that a temporary failure does not cause a BuildRun
+			if previousDigest, found := previousDigests[imageName]; found {
+				images = append(images, buildapi.TriggerImageStatus{Name: imageName, Digest: previousDigest})
+			}
+			continue
+		}
+
+		images = append(images, buildapi.TriggerImageStatus{Name: imageName, Digest: digest})
+		if previousDigest, found := previousDigests[imageName]; found && previousDigest != digest {
+			changedImages = append(changedImages, fmt.Sprintf("%s@%s", imageName, digest))
+		}
+	}
+
+	return images, changedImages
+}
+
+// dockerConfigDirectory writes the credentials of a pull secret into a temporary directory in which
+// image.GetOptions finds them, the returned function removes the directory again
+func (r *ReconcileBuild) dockerConfigDirectory(ctx context.Context, namespace string, pullSecret *string) (string, func(), error) {
+	if pullSecret == nil || *pullSecret == "" {
+		return "", func() {}, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: *pullSecret, Namespace: namespace}, secret); err != nil {
+		return "", nil, err
+	}
+
+	dockerConfigJSON, found := secret.Data[corev1.DockerConfigJsonKey]
+	if !found {
+		return "", nil, fmt.Errorf("the secret %s does not contain the key %s", *pullSecret, corev1.DockerConfigJsonKey)
+	}
+
+	directory, err := os.MkdirTemp("", "image-trigger-")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := os.WriteFile(filepath.Join(directory, corev1.DockerConfigJsonKey), dockerConfigJSON, 0o600); err != nil {
+		_ = os.RemoveAll(directory)
+		return "", nil, err
+	}
+
+	return directory, func() { _ = os.RemoveAll(directory) }, nil
+}
+
+// lookupDigest returns the digest of an image in its container registry
+func lookupDigest(ctx context.Context, imageName string, insecure bool, dockerConfigDirectory string) (string, error) {
+	ref, err := image.ParseReference(imageName, insecure)
+	if err != nil {
+		return "", err
+	}
+
+	options, _, err := image.GetOptions(ctx, ref, insecure, "", dockerConfigDirectory, userAgent)
+	if err != nil {
+		return "", err
+	}
+
+	return image.GetDigestFromRegistry(ref, options)
+}
+
+// changeSuffix returns a short hash of the generation and the changed images of a trigger to be used in the BuildRun name
+func changeSuffix(triggerName string, imageGeneration int64, changedImages []string) string {
+	hash := sha256.Sum256([]byte(triggerName + "\n" + strconv.FormatInt(imageGeneration, 10) + "\n" + strings.Join(changedImages, "\n")))
+	return hex.EncodeToString(hash[:])[:10]
+}
@@ -0,0 +1,17 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package imagetrigger_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestImageTrigger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ImageTrigger Suite")
+}
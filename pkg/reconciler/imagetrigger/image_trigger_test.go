@@ -0,0 +1,260 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package imagetrigger_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/imagetrigger"
+)
+
+var _ = Describe("Reconcile image triggers", func() {
+	var (
+		manager      *fakes.FakeManager
+		client       *fakes.FakeClient
+		statusWriter *fakes.FakeStatusWriter
+		recorder     *events.FakeRecorder
+		reconciler   reconcile.Reconciler
+		request      reconcile.Request
+		build        *buildapi.Build
+		imageName    string
+	)
+
+	// pushRandomImage pushes a new image to the image name and returns its digest
+	pushRandomImage := func() string {
+		img, err := random.Image(512, 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		ref, err := name.ParseReference(imageName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remote.Write(ref, img)).To(Succeed())
+
+		digest, err := img.Digest()
+		Expect(err).ToNot(HaveOccurred())
+		return digest.String()
+	}
+
+	// updatedTriggerStatus returns the trigger status of the last status update
+	updatedTriggerStatus := func() *buildapi.TriggerStatus {
+		Expect(statusWriter.UpdateCallCount()).To(BeNumerically(">", 0))
+		_, object, _ := statusWriter.UpdateArgsForCall(statusWriter.UpdateCallCount() - 1)
+		updatedBuild, ok := object.(*buildapi.Build)
+		Expect(ok).To(BeTrue())
+		return updatedBuild.Status.GetTriggerStatus("base-image")
+	}
+
+	BeforeEach(func() {
+		server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		DeferCleanup(server.Close)
+		imageName = fmt.Sprintf("%s/shipwright-io/base-image:latest", strings.TrimPrefix(server.URL, "http://"))
+
+		build = &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "app",
+				Namespace:  "build-examples",
+				Generation: 1,
+			},
+			Spec: buildapi.BuildSpec{
+				Trigger: &buildapi.Trigger{
+					When: []buildapi.TriggerWhen{{
+						Name: "base-image",
+						Type: buildapi.ImageTrigger,
+						Image: &buildapi.WhenImage{
+							Names:    []string{imageName},
+							Insecure: ptr.To(true),
+						},
+					}},
+				},
+			},
+			Status: buildapi.BuildStatus{
+				Registered: ptr.To(corev1.ConditionTrue),
+			},
+		}
+
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: build.Name, Namespace: build.Namespace}}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *buildapi.Build:
+				build.DeepCopyInto(object)
+				return nil
+			default:
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			}
+		})
+		statusWriter = &fakes.FakeStatusWriter{}
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+
+		manager = &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+
+		recorder = events.NewFakeRecorder(10)
+	})
+
+	JustBeforeEach(func() {
+		c := config.NewDefaultConfig()
+		c.ImageTriggerPollInterval = time.Minute
+		reconciler = imagetrigger.NewReconciler(c, manager, recorder)
+	})
+
+	It("records the digest of an image that is seen for the first time", func() {
+		digest := pushRandomImage()
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Minute}))
+
+		Expect(client.CreateCallCount()).To(Equal(0))
+		Expect(updatedTriggerStatus().Images).To(Equal([]buildapi.TriggerImageStatus{{Name: imageName, Digest: digest}}))
+	})
+
+	It("does nothing when the digest did not change", func() {
+		digest := pushRandomImage()
+		build.Status.Triggers = []buildapi.TriggerStatus{{
+			Name:   "base-image",
+			Images: []buildapi.TriggerImageStatus{{Name: imageName, Digest: digest}},
+		}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.CreateCallCount()).To(Equal(0))
+		Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+	})
+
+	It("creates a BuildRun when the digest changed", func() {
+		build.Status.Triggers = []buildapi.TriggerStatus{{
+			Name:   "base-image",
+			Images: []buildapi.TriggerImageStatus{{Name: imageName, Digest: pushRandomImage()}},
+		}}
+		digest := pushRandomImage()
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.CreateCallCount()).To(Equal(1))
+		_, object, _ := client.CreateArgsForCall(0)
+		buildRun, ok := object.(*buildapi.BuildRun)
+		Expect(ok).To(BeTrue())
+		Expect(buildRun.Name).To(HavePrefix("app-"))
+		Expect(buildRun.Spec.Build.Name).To(Equal(ptr.To("app")))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTrigger, "base-image"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTriggerImage, imageName+"@"+digest))
+
+		Expect(updatedTriggerStatus().Images).To(Equal([]buildapi.TriggerImageStatus{{Name: imageName, Digest: digest}}))
+		Expect(updatedTriggerStatus().ImageGeneration).To(Equal(int64(1)))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal ImageChanged")))
+	})
+
+	It("creates a new BuildRun when the digest changes to the same value again", func() {
+		previousDigest := pushRandomImage()
+		build.Status.Triggers = []buildapi.TriggerStatus{{
+			Name:   "base-image",
+			Images: []buildapi.TriggerImageStatus{{Name: imageName, Digest: previousDigest}},
+		}}
+		pushRandomImage()
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		// the digest changed back to the previous one in the meantime, and now changes again
+		build.Status.Triggers = []buildapi.TriggerStatus{{
+			Name:            "base-image",
+			Images:          []buildapi.TriggerImageStatus{{Name: imageName, Digest: previousDigest}},
+			ImageGeneration: 2,
+		}}
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.CreateCallCount()).To(Equal(2))
+		_, first, _ := client.CreateArgsForCall(0)
+		_, second, _ := client.CreateArgsForCall(1)
+		Expect(first.(*buildapi.BuildRun).Annotations).To(Equal(second.(*buildapi.BuildRun).Annotations))
+		Expect(first.GetName()).ToNot(Equal(second.GetName()))
+	})
+
+	It("stops the lookups when the Image trigger was removed from the Build", func() {
+		build.Spec.Trigger.When = []buildapi.TriggerWhen{{
+			Name: "schedule",
+			Type: buildapi.ScheduleTrigger,
+			Schedule: &buildapi.WhenSchedule{
+				Cron: "0 * * * *",
+			},
+		}}
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+	})
+
+	It("keeps the last seen digest when the image cannot be looked up", func() {
+		build.Status.Triggers = []buildapi.TriggerStatus{{
+			Name:   "base-image",
+			Images: []buildapi.TriggerImageStatus{{Name: imageName, Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000000"}},
+		}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.CreateCallCount()).To(Equal(0))
+		Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning ImageLookupFailed")))
+	})
+
+	It("uses the credentials of the pull secret", func() {
+		build.Spec.Trigger.When[0].Image.PullSecret = ptr.To("registry-credentials")
+		registryHost := strings.Split(imageName, "/")[0]
+
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *buildapi.Build:
+				build.DeepCopyInto(object)
+				return nil
+			case *corev1.Secret:
+				Expect(nn.Name).To(Equal("registry-credentials"))
+				(&corev1.Secret{
+					Type: corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: fmt.Appendf(nil, `{"auths":{%q:{"username":"user","password":"secret"}}}`, registryHost),
+					},
+				}).DeepCopyInto(object)
+				return nil
+			default:
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			}
+		})
+		digest := pushRandomImage()
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedTriggerStatus().Images).To(Equal([]buildapi.TriggerImageStatus{{Name: imageName, Digest: digest}}))
+	})
+})
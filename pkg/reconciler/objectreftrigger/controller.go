@@ -0,0 +1,94 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objectreftrigger
+
+import (
+	"context"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates the BuildRun and PipelineRun trigger Controllers and adds them to the Manager. The Manager will set fields on the Controllers
+// and Start them when the Manager is Started.
+func Add(_ context.Context, c *config.Config, mgr manager.Manager, recorder events.EventRecorder) error {
+	if err := addBuildRunController(mgr, NewBuildRunReconciler(c, mgr, recorder), c.Controllers.BuildRun.MaxConcurrentReconciles); err != nil {
+		return err
+	}
+
+	return addPipelineRunController(mgr, NewPipelineRunReconciler(c, mgr, recorder), c.Controllers.BuildRun.MaxConcurrentReconciles)
+}
+
+func newController(mgr manager.Manager, controllerName string, r reconcile.Reconciler, maxConcurrentReconciles int) (controller.Controller, error) {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	return controller.New(controllerName, mgr, options)
+}
+
+// completionPredicate only passes updates of objects that just completed. Objects that are created
+// or deleted are ignored, so that a restart of the controller does not trigger Builds again.
+func completionPredicate[T client.Object](isDone func(T) bool) predicate.TypedFuncs[T] {
+	return predicate.TypedFuncs[T]{
+		CreateFunc: func(_ event.TypedCreateEvent[T]) bool {
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+			return !isDone(e.ObjectOld) && isDone(e.ObjectNew)
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[T]) bool {
+			return false
+		},
+		GenericFunc: func(_ event.TypedGenericEvent[T]) bool {
+			return false
+		},
+	}
+}
+
+func addBuildRunController(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	c, err := newController(mgr, "buildrun-trigger-controller", r, maxConcurrentReconciles)
+	if err != nil {
+		return err
+	}
+
+	// Watch for BuildRuns that complete
+	return c.Watch(source.Kind(mgr.GetCache(), &buildapi.BuildRun{}, &handler.TypedEnqueueRequestForObject[*buildapi.BuildRun]{}, completionPredicate(func(br *buildapi.BuildRun) bool {
+		return br.IsDone()
+	})))
+}
+
+func addPipelineRunController(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	c, err := newController(mgr, "pipelinerun-trigger-controller", r, maxConcurrentReconciles)
+	if err != nil {
+		return err
+	}
+
+	// Watch for PipelineRuns that complete
+	return c.Watch(source.Kind(mgr.GetCache(), &pipelineapi.PipelineRun{}, &handler.TypedEnqueueRequestForObject[*pipelineapi.PipelineRun]{}, completionPredicate(func(pr *pipelineapi.PipelineRun) bool {
+		return pr.IsDone()
+	})))
+}
@@ -0,0 +1,210 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objectreftrigger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+// ObjectCompleted is the reason of the event that is emitted when a BuildRun is created because
+// a BuildRun or PipelineRun that a trigger references completed
+const ObjectCompleted = "ObjectCompleted"
+
+// completedObject is a BuildRun or PipelineRun that completed and can trigger Builds
+type completedObject struct {
+	kind string
+	name string
+	uid  types.UID
+	// refName is the name of the Build of a BuildRun, or of the Pipeline of a PipelineRun
+	refName string
+	labels  map[string]string
+	// reason is the reason of the Succeeded condition
+	reason string
+}
+
+// reconciler creates BuildRuns for the Builds whose triggers of one type match a completed object
+type reconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config      *config.Config
+	client      client.Client
+	recorder    events.EventRecorder
+	triggerType buildapi.TriggerType
+}
+
+// ReconcileBuildRun triggers the Builds that reference a BuildRun that completed
+type ReconcileBuildRun struct {
+	reconciler
+}
+
+// ReconcilePipelineRun triggers the Builds that reference a Tekton PipelineRun that completed
+type ReconcilePipelineRun struct {
+	reconciler
+}
+
+func NewBuildRunReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder) reconcile.Reconciler {
+	return &ReconcileBuildRun{reconciler{
+		config:      c,
+		client:      client.WithFieldOwner(mgr.GetClient(), "shipwright-buildrun-trigger-controller"),
+		recorder:    recorder,
+		triggerType: buildapi.BuildRunTrigger,
+	}}
+}
+
+func NewPipelineRunReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder) reconcile.Reconciler {
+	return &ReconcilePipelineRun{reconciler{
+		config:      c,
+		client:      client.WithFieldOwner(mgr.GetClient(), "shipwright-pipelinerun-trigger-controller"),
+		recorder:    recorder,
+		triggerType: buildapi.PipelineTrigger,
+	}}
+}
+
+// Reconcile creates a BuildRun for every Build with a BuildRun trigger that matches the completed BuildRun
+func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "Start reconciling BuildRun triggers", namespace, request.Namespace, name, request.Name)
+
+	br := &buildapi.BuildRun{}
+	if err := r.client.Get(ctx, request.NamespacedName, br); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "Finish reconciling BuildRun triggers. BuildRun was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !br.IsDone() {
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{}, r.triggerBuilds(ctx, request.Namespace, completedObject{
+		kind:    "BuildRun",
+		name:    br.Name,
+		uid:     br.UID,
+		refName: ptr.Deref(br.Spec.Build.Name, ""),
+		labels:  br.Labels,
+		reason:  br.Status.GetCondition(buildapi.Succeeded).Reason,
+	})
+}
+
+// Reconcile creates a BuildRun for every Build with a Pipeline trigger that matches the completed PipelineRun
+func (r *ReconcilePipelineRun) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "Start reconciling Pipeline triggers", namespace, request.Namespace, name, request.Name)
+
+	pr := &pipelineapi.PipelineRun{}
+	if err := r.client.Get(ctx, request.NamespacedName, pr); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "Finish reconciling Pipeline triggers. PipelineRun was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !pr.IsDone() {
+		return reconcile.Result{}, nil
+	}
+
+	var pipelineName string
+	if pr.Spec.PipelineRef != nil {
+		pipelineName = pr.Spec.PipelineRef.Name
+	}
+
+	return reconcile.Result{}, r.triggerBuilds(ctx, request.Namespace, completedObject{
+		kind:    "PipelineRun",
+		name:    pr.Name,
+		uid:     pr.UID,
+		refName: pipelineName,
+		labels:  pr.Labels,
+		reason:  pr.Status.GetCondition(apis.ConditionSucceeded).Reason,
+	})
+}
+
+// triggerBuilds creates a BuildRun for every Build of the namespace that has a trigger matching the completed object
+func (r *reconciler) triggerBuilds(ctx context.Context, namespace string, object completedObject) error {
+	buildList := &buildapi.BuildList{}
+	if err := r.client.List(ctx, buildList, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	for i := range buildList.Items {
+		b := &buildList.Items[i]
+		if b.Spec.Trigger == nil || b.Status.Registered == nil || *b.Status.Registered != corev1.ConditionTrue {
+			continue
+		}
+
+		// a Build is never triggered by its own BuildRuns
+		if object.kind == "BuildRun" && object.refName == b.Name {
+			continue
+		}
+
+		for _, when := range b.Spec.Trigger.When {
+			if when.Type != r.triggerType || when.ObjectRef == nil || !matches(when.ObjectRef, object) {
+				continue
+			}
+
+			// the name is derived from the completed object so that it never creates more than one BuildRun for the Build
+			buildRun, err := resources.CreateTriggeredBuildRun(ctx, r.client, b, when.Name, uidSuffix(object.uid), map[string]string{
+				buildapi.AnnotationBuildRunTriggerObject: fmt.Sprintf("%s/%s", object.kind, object.name),
+			}, nil)
+			if err != nil {
+				return err
+			}
+
+			r.recorder.Eventf(b, buildRun, corev1.EventTypeNormal, ObjectCompleted, "Trigger", "created BuildRun %s for trigger %s as %s %s completed with %s", buildRun.Name, when.Name, object.kind, object.name, object.reason)
+			break
+		}
+	}
+
+	return nil
+}
+
+// matches returns true if the completed object has one of the statuses of the object reference,
+// and matches its name or label selector
+func matches(objectRef *buildapi.WhenObjectRef, object completedObject) bool {
+	if !slices.Contains(objectRef.Status, object.reason) {
+		return false
+	}
+
+	if objectRef.Name != "" {
+		return objectRef.Name == object.refName
+	}
+
+	return len(objectRef.Selector) > 0 && labels.SelectorFromSet(objectRef.Selector).Matches(labels.Set(object.labels))
+}
+
+// uidSuffix returns a short hash of the UID of the completed object to be used in the BuildRun name
+func uidSuffix(uid types.UID) string {
+	hash := sha256.Sum256([]byte(uid))
+	return hex.EncodeToString(hash[:])[:10]
+}
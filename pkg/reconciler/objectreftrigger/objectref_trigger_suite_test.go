@@ -0,0 +1,17 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objectreftrigger_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestObjectRefTrigger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ObjectRefTrigger Suite")
+}
@@ -0,0 +1,249 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objectreftrigger_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/objectreftrigger"
+)
+
+var _ = Describe("Reconcile object reference triggers", func() {
+	var (
+		manager     *fakes.FakeManager
+		client      *fakes.FakeClient
+		recorder    *events.FakeRecorder
+		builds      []buildapi.Build
+		buildRun    *buildapi.BuildRun
+		pipelineRun *pipelineapi.PipelineRun
+	)
+
+	newBuild := func(name string, when buildapi.TriggerWhen) buildapi.Build {
+		return buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "build-examples"},
+			Spec: buildapi.BuildSpec{
+				Trigger: &buildapi.Trigger{When: []buildapi.TriggerWhen{when}},
+			},
+			Status: buildapi.BuildStatus{
+				Registered: ptr.To(corev1.ConditionTrue),
+			},
+		}
+	}
+
+	// createdBuildRuns returns the BuildRuns that the reconciler created
+	createdBuildRuns := func() []*buildapi.BuildRun {
+		var result []*buildapi.BuildRun
+		for i := range client.CreateCallCount() {
+			_, object, _ := client.CreateArgsForCall(i)
+			br, ok := object.(*buildapi.BuildRun)
+			Expect(ok).To(BeTrue())
+			result = append(result, br)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		builds = nil
+
+		buildRun = &buildapi.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "base-image-run-1",
+				Namespace: "build-examples",
+				UID:       "4c5f1a0e-5b0b-4b1e-9a39-0b8d6a1f7c11",
+				Labels:    map[string]string{"tier": "base"},
+			},
+			Spec: buildapi.BuildRunSpec{
+				Build: buildapi.ReferencedBuild{Name: ptr.To("base-image")},
+			},
+			Status: buildapi.BuildRunStatus{
+				Conditions: buildapi.Conditions{{
+					Type:   buildapi.Succeeded,
+					Status: corev1.ConditionTrue,
+					Reason: "Succeeded",
+				}},
+			},
+		}
+
+		pipelineRun = &pipelineapi.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "release-run-1",
+				Namespace: "build-examples",
+				UID:       "0d0b5e7e-2f6c-4d35-8f0e-6d7c2f1b9a44",
+				Labels:    map[string]string{"app": "release"},
+			},
+			Spec: pipelineapi.PipelineRunSpec{
+				PipelineRef: &pipelineapi.PipelineRef{Name: "release"},
+			},
+			Status: pipelineapi.PipelineRunStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+						Reason: "Failed",
+					}},
+				},
+			},
+		}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *buildapi.BuildRun:
+				buildRun.DeepCopyInto(object)
+				return nil
+			case *pipelineapi.PipelineRun:
+				pipelineRun.DeepCopyInto(object)
+				return nil
+			default:
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			}
+		})
+		client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+			switch list := list.(type) {
+			case *buildapi.BuildList:
+				list.Items = builds
+			}
+			return nil
+		})
+
+		manager = &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+
+		recorder = events.NewFakeRecorder(10)
+	})
+
+	Context("for a BuildRun", func() {
+		var request reconcile.Request
+
+		BeforeEach(func() {
+			request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildRun.Name, Namespace: buildRun.Namespace}}
+		})
+
+		reconcileBuildRun := func() {
+			_, err := objectreftrigger.NewBuildRunReconciler(config.NewDefaultConfig(), manager, recorder).Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("creates a BuildRun for a Build that references the Build of the BuildRun", func() {
+			builds = []buildapi.Build{newBuild("app", buildapi.TriggerWhen{
+				Name:      "after-base-image",
+				Type:      buildapi.BuildRunTrigger,
+				ObjectRef: &buildapi.WhenObjectRef{Name: "base-image", Status: []string{"Succeeded"}},
+			})}
+
+			reconcileBuildRun()
+
+			buildRuns := createdBuildRuns()
+			Expect(buildRuns).To(HaveLen(1))
+			Expect(buildRuns[0].Spec.Build.Name).To(Equal(ptr.To("app")))
+			Expect(buildRuns[0].Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTrigger, "after-base-image"))
+			Expect(buildRuns[0].Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTriggerObject, "BuildRun/base-image-run-1"))
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal ObjectCompleted")))
+		})
+
+		It("creates a BuildRun for a Build whose selector matches the BuildRun", func() {
+			builds = []buildapi.Build{newBuild("app", buildapi.TriggerWhen{
+				Name:      "after-base-images",
+				Type:      buildapi.BuildRunTrigger,
+				ObjectRef: &buildapi.WhenObjectRef{Selector: map[string]string{"tier": "base"}, Status: []string{"Succeeded"}},
+			})}
+
+			reconcileBuildRun()
+			Expect(createdBuildRuns()).To(HaveLen(1))
+		})
+
+		It("ignores Builds that wait for a different status, type or Build", func() {
+			builds = []buildapi.Build{
+				newBuild("on-failure", buildapi.TriggerWhen{
+					Name:      "after-base-image-failed",
+					Type:      buildapi.BuildRunTrigger,
+					ObjectRef: &buildapi.WhenObjectRef{Name: "base-image", Status: []string{"Failed"}},
+				}),
+				newBuild("on-pipeline", buildapi.TriggerWhen{
+					Name:      "after-pipeline",
+					Type:      buildapi.PipelineTrigger,
+					ObjectRef: &buildapi.WhenObjectRef{Name: "base-image", Status: []string{"Succeeded"}},
+				}),
+				newBuild("on-other-build", buildapi.TriggerWhen{
+					Name:      "after-other-build",
+					Type:      buildapi.BuildRunTrigger,
+					ObjectRef: &buildapi.WhenObjectRef{Name: "other-build", Status: []string{"Succeeded"}},
+				}),
+			}
+
+			reconcileBuildRun()
+			Expect(createdBuildRuns()).To(BeEmpty())
+		})
+
+		It("does not trigger a Build with its own BuildRuns", func() {
+			builds = []buildapi.Build{newBuild("base-image", buildapi.TriggerWhen{
+				Name:      "after-base-images",
+				Type:      buildapi.BuildRunTrigger,
+				ObjectRef: &buildapi.WhenObjectRef{Selector: map[string]string{"tier": "base"}, Status: []string{"Succeeded"}},
+			})}
+
+			reconcileBuildRun()
+			Expect(createdBuildRuns()).To(BeEmpty())
+		})
+
+		It("does nothing for a BuildRun that is still running", func() {
+			buildRun.Status.Conditions[0].Status = corev1.ConditionUnknown
+			builds = []buildapi.Build{newBuild("app", buildapi.TriggerWhen{
+				Name:      "after-base-image",
+				Type:      buildapi.BuildRunTrigger,
+				ObjectRef: &buildapi.WhenObjectRef{Name: "base-image", Status: []string{"Succeeded"}},
+			})}
+
+			reconcileBuildRun()
+			Expect(client.ListCallCount()).To(Equal(0))
+			Expect(createdBuildRuns()).To(BeEmpty())
+		})
+	})
+
+	Context("for a PipelineRun", func() {
+		It("creates a BuildRun for a Build that references the Pipeline of the PipelineRun", func() {
+			builds = []buildapi.Build{
+				newBuild("app", buildapi.TriggerWhen{
+					Name:      "after-release",
+					Type:      buildapi.PipelineTrigger,
+					ObjectRef: &buildapi.WhenObjectRef{Name: "release", Status: []string{"Failed"}},
+				}),
+				newBuild("unregistered", buildapi.TriggerWhen{
+					Name:      "after-release",
+					Type:      buildapi.PipelineTrigger,
+					ObjectRef: &buildapi.WhenObjectRef{Name: "release", Status: []string{"Failed"}},
+				}),
+			}
+			builds[1].Status.Registered = ptr.To(corev1.ConditionFalse)
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: pipelineRun.Name, Namespace: pipelineRun.Namespace}}
+			_, err := objectreftrigger.NewPipelineRunReconciler(config.NewDefaultConfig(), manager, recorder).Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+
+			buildRuns := createdBuildRuns()
+			Expect(buildRuns).To(HaveLen(1))
+			Expect(buildRuns[0].Spec.Build.Name).To(Equal(ptr.To("app")))
+			Expect(buildRuns[0].Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTriggerObject, "PipelineRun/release-run-1"))
+		})
+	})
+})
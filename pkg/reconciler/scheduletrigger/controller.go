@@ -0,0 +1,87 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates a new schedule trigger Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(_ context.Context, c *config.Config, mgr manager.Manager, recorder events.EventRecorder) error {
+	return add(mgr, NewReconciler(c, mgr, recorder), c.Controllers.Build.MaxConcurrentReconciles)
+}
+
+// hasScheduleTrigger returns true if the Build has at least one trigger of type Schedule
+func hasScheduleTrigger(b *buildapi.Build) bool {
+	if b.Spec.Trigger == nil {
+		return false
+	}
+
+	for _, when := range b.Spec.Trigger.When {
+		if when.Type == buildapi.ScheduleTrigger {
+			return true
+		}
+	}
+
+	return false
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	c, err := controller.New("schedule-trigger-controller", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	predBuild := predicate.TypedFuncs[*buildapi.Build]{
+		CreateFunc: func(e event.TypedCreateEvent[*buildapi.Build]) bool {
+			return hasScheduleTrigger(e.Object)
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*buildapi.Build]) bool {
+			if !hasScheduleTrigger(e.ObjectNew) {
+				return false
+			}
+
+			// the schedule changed, or the Build just passed its validation, updates of
+			// the trigger status are ignored as the reconciler requeues itself
+			return e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration() ||
+				!reflect.DeepEqual(e.ObjectNew.Status.Registered, e.ObjectOld.Status.Registered)
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*buildapi.Build]) bool {
+			// Never reconcile on deletion, there is nothing we have to do
+			return false
+		},
+	}
+
+	// Watch for changes to primary resource Build
+	return c.Watch(source.Kind(mgr.GetCache(), &buildapi.Build{}, &handler.TypedEnqueueRequestForObject[*buildapi.Build]{}, predBuild))
+}
@@ -0,0 +1,189 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+const (
+	// Scheduled is the reason of the event that is emitted when a BuildRun is created for a Schedule trigger
+	Scheduled = "Scheduled"
+
+	// MissedSchedule is the reason of the event that is emitted when scheduled BuildRuns were skipped
+	MissedSchedule = "MissedSchedule"
+)
+
+// ReconcileBuild reconciles the Schedule triggers of a Build object
+type ReconcileBuild struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config   *config.Config
+	client   client.Client
+	recorder events.EventRecorder
+}
+
+func NewReconciler(c *config.Config, mgr manager.Manager, recorder events.EventRecorder) reconcile.Reconciler {
+	return &ReconcileBuild{
+		config:   c,
+		client:   client.WithFieldOwner(mgr.GetClient(), "shipwright-schedule-trigger-controller"),
+		recorder: recorder,
+	}
+}
+
+// Reconcile creates a BuildRun for every Schedule trigger of a Build whose scheduled time has come,
+// and requeues the Build for the next scheduled time
+func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "Start reconciling schedule triggers", namespace, request.Namespace, name, request.Name)
+
+	b := &buildapi.Build{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, b); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "Finish reconciling schedule triggers. Build was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// only a valid Build is scheduled, the Build is reconciled again once it becomes valid
+	if b.Spec.Trigger == nil || b.Status.Registered == nil || *b.Status.Registered != corev1.ConditionTrue {
+		return reconcile.Result{}, nil
+	}
+
+	now := time.Now()
+	var requeueAfter time.Duration
+	statusChanged := false
+
+	for _, when := range b.Spec.Trigger.When {
+		if when.Type != buildapi.ScheduleTrigger || when.Schedule == nil {
+			continue
+		}
+
+		schedule, location, err := parseSchedule(when.Schedule)
+		if err != nil {
+			// the Build validation reports an invalid schedule
+			ctxlog.Info(ctx, "skipping invalid schedule", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "error", err.Error())
+			continue
+		}
+
+		// without a previous run, the schedule starts with the creation of the Build
+		earliest := b.CreationTimestamp.Time
+		if triggerStatus := b.Status.GetTriggerStatus(when.Name); triggerStatus != nil && triggerStatus.LastScheduleTime != nil {
+			earliest = triggerStatus.LastScheduleTime.Time
+		}
+
+		// runs that could not be started within the deadline are skipped
+		if when.Schedule.StartingDeadlineSeconds != nil {
+			if deadline := now.Add(-time.Duration(*when.Schedule.StartingDeadlineSeconds) * time.Second); earliest.Before(deadline) {
+				earliest = deadline
+			}
+		}
+
+		if scheduledTime := mostRecentScheduleTime(schedule, earliest.In(location), now.In(location)); !scheduledTime.IsZero() {
+			if next := schedule.Next(earliest.In(location)); next.Before(scheduledTime) {
+				r.recorder.Eventf(b, nil, corev1.EventTypeWarning, MissedSchedule, "Trigger", "skipped the BuildRuns of trigger %s scheduled between %s and %s, only the most recent one is started", when.Name, next.Format(time.RFC3339), scheduledTime.Format(time.RFC3339))
+			}
+
+			// the name is derived from the trigger and the scheduled time so that a scheduled time never creates more
+			// than one BuildRun, while triggers that are scheduled at the same time create one BuildRun each
+			buildRun, err := resources.CreateTriggeredBuildRun(ctx, r.client, b, when.Name, scheduleSuffix(when.Name, scheduledTime), map[string]string{
+				buildapi.AnnotationBuildRunScheduledTime: scheduledTime.UTC().Format(time.RFC3339),
+			}, nil)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
+			r.recorder.Eventf(b, buildRun, corev1.EventTypeNormal, Scheduled, "Trigger", "created BuildRun %s for trigger %s scheduled at %s", buildRun.Name, when.Name, scheduledTime.Format(time.RFC3339))
+
+			b.Status.SetTriggerStatus(buildapi.TriggerStatus{
+				Name:             when.Name,
+				LastScheduleTime: &metav1.Time{Time: scheduledTime},
+			})
+			statusChanged = true
+		}
+
+		if next := schedule.Next(now.In(location)); !next.IsZero() {
+			if wait := next.Sub(now); requeueAfter == 0 || wait < requeueAfter {
+				requeueAfter = wait
+			}
+		}
+	}
+
+	if statusChanged {
+		if err := r.client.Status().Update(ctx, b); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	ctxlog.Debug(ctx, "Finishing reconciling schedule triggers", namespace, request.Namespace, name, request.Name, "requeueAfter", requeueAfter)
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// parseSchedule parses the cron expression and time zone of a Schedule trigger
+func parseSchedule(whenSchedule *buildapi.WhenSchedule) (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(whenSchedule.Cron)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	location := time.UTC
+	if whenSchedule.TimeZone != nil && *whenSchedule.TimeZone != "" {
+		if location, err = time.LoadLocation(*whenSchedule.TimeZone); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return schedule, location, nil
+}
+
+// mostRecentScheduleTime returns the latest scheduled time after earliest that is not after now,
+// or the zero time if there is none
+func mostRecentScheduleTime(schedule cron.Schedule, earliest time.Time, now time.Time) time.Time {
+	// look back from now in growing steps, so that a long time without runs, for example
+	// for an old Build that got a new trigger, does not mean to iterate over every missed run
+	start := earliest
+	for lookBack := time.Minute; now.Add(-lookBack).After(earliest); lookBack *= 2 {
+		if next := schedule.Next(now.Add(-lookBack)); !next.IsZero() && !next.After(now) {
+			start = now.Add(-lookBack)
+			break
+		}
+	}
+
+	var mostRecent time.Time
+	for t := schedule.Next(start); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		mostRecent = t
+	}
+
+	return mostRecent
+}
+
+// scheduleSuffix returns a short hash of the trigger name and the minute of the scheduled time to be used in the BuildRun name
+func scheduleSuffix(triggerName string, scheduledTime time.Time) string {
+	hash := sha256.Sum256([]byte(triggerName + "\n" + strconv.FormatInt(scheduledTime.Unix()/60, 10)))
+	return hex.EncodeToString(hash[:])[:10]
+}
@@ -0,0 +1,191 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/scheduletrigger"
+)
+
+var _ = Describe("Reconcile schedule triggers", func() {
+	var (
+		manager      *fakes.FakeManager
+		client       *fakes.FakeClient
+		statusWriter *fakes.FakeStatusWriter
+		recorder     *events.FakeRecorder
+		reconciler   reconcile.Reconciler
+		request      reconcile.Request
+		build        *buildapi.Build
+	)
+
+	BeforeEach(func() {
+		build = &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "nightly",
+				Namespace:         "build-examples",
+				Generation:        2,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			},
+			Spec: buildapi.BuildSpec{
+				Trigger: &buildapi.Trigger{
+					When: []buildapi.TriggerWhen{{
+						Name:     "every-minute",
+						Type:     buildapi.ScheduleTrigger,
+						Schedule: &buildapi.WhenSchedule{Cron: "* * * * *"},
+					}},
+				},
+			},
+			Status: buildapi.BuildStatus{
+				Registered: ptr.To(corev1.ConditionTrue),
+			},
+		}
+
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: build.Name, Namespace: build.Namespace}}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *buildapi.Build:
+				build.DeepCopyInto(object)
+				return nil
+			default:
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			}
+		})
+		statusWriter = &fakes.FakeStatusWriter{}
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+
+		manager = &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+
+		recorder = events.NewFakeRecorder(10)
+	})
+
+	JustBeforeEach(func() {
+		reconciler = scheduletrigger.NewReconciler(config.NewDefaultConfig(), manager, recorder)
+	})
+
+	It("does nothing for a Build that is not registered", func() {
+		build.Status.Registered = ptr.To(corev1.ConditionFalse)
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("creates only the most recent missed BuildRun and records its scheduled time", func() {
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+
+		Expect(client.CreateCallCount()).To(Equal(1))
+		_, object, _ := client.CreateArgsForCall(0)
+		buildRun, ok := object.(*buildapi.BuildRun)
+		Expect(ok).To(BeTrue())
+
+		scheduledTime, err := time.Parse(time.RFC3339, buildRun.Annotations[buildapi.AnnotationBuildRunScheduledTime])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scheduledTime).To(BeTemporally("~", time.Now().Truncate(time.Minute), time.Minute))
+		Expect(buildRun.Name).To(MatchRegexp("^nightly-[0-9a-f]{10}$"))
+		Expect(buildRun.Namespace).To(Equal(build.Namespace))
+		Expect(buildRun.Spec.Build.Name).To(Equal(ptr.To("nightly")))
+		Expect(buildRun.Labels).To(HaveKeyWithValue(buildapi.LabelBuild, "nightly"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTrigger, "every-minute"))
+
+		Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+		_, object, _ = statusWriter.UpdateArgsForCall(0)
+		updatedBuild, ok := object.(*buildapi.Build)
+		Expect(ok).To(BeTrue())
+		triggerStatus := updatedBuild.Status.GetTriggerStatus("every-minute")
+		Expect(triggerStatus).ToNot(BeNil())
+		Expect(triggerStatus.LastScheduleTime.Time).To(BeTemporally("==", scheduledTime))
+
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning MissedSchedule")))
+		Expect(recorder.Events).To(Receive(HavePrefix("Normal Scheduled")))
+	})
+
+	It("does not create a BuildRun before the next scheduled time", func() {
+		build.Status.Triggers = []buildapi.TriggerStatus{{
+			Name:             "every-minute",
+			LastScheduleTime: &metav1.Time{Time: time.Now().Add(time.Minute).Truncate(time.Minute)},
+		}}
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(client.CreateCallCount()).To(Equal(0))
+		Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+	})
+
+	It("skips runs that are older than the starting deadline", func() {
+		build.Spec.Trigger.When[0].Schedule.Cron = "0 0 1 1 *"
+		build.Spec.Trigger.When[0].Schedule.StartingDeadlineSeconds = ptr.To[int64](60)
+		build.CreationTimestamp = metav1.NewTime(time.Now().AddDate(-2, 0, 0))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("creates one BuildRun per trigger that is scheduled at the same time", func() {
+		build.Spec.Trigger.When = append(build.Spec.Trigger.When, buildapi.TriggerWhen{
+			Name:     "also-every-minute",
+			Type:     buildapi.ScheduleTrigger,
+			Schedule: &buildapi.WhenSchedule{Cron: "* * * * *"},
+		})
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.CreateCallCount()).To(Equal(2))
+		_, first, _ := client.CreateArgsForCall(0)
+		_, second, _ := client.CreateArgsForCall(1)
+		Expect(first.(*buildapi.BuildRun).Annotations[buildapi.AnnotationBuildRunScheduledTime]).To(Equal(second.(*buildapi.BuildRun).Annotations[buildapi.AnnotationBuildRunScheduledTime]))
+		Expect(first.GetName()).ToNot(Equal(second.GetName()))
+	})
+
+	It("treats an existing BuildRun for the scheduled time as created", func() {
+		client.CreateReturns(errors.NewAlreadyExists(schema.GroupResource{}, "nightly"))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+	})
+
+	It("interprets the schedule in the configured time zone", func() {
+		build.Spec.Trigger.When[0].Schedule.Cron = "0 * * * *"
+		build.Spec.Trigger.When[0].Schedule.TimeZone = ptr.To("Asia/Kolkata")
+		build.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.CreateCallCount()).To(Equal(1))
+
+		// Asia/Kolkata is 5:30 hours ahead of UTC, a full hour there is at half past in UTC
+		_, object, _ := client.CreateArgsForCall(0)
+		scheduledTime, err := time.Parse(time.RFC3339, object.GetAnnotations()[buildapi.AnnotationBuildRunScheduledTime])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scheduledTime.Minute()).To(Equal(30))
+	})
+})
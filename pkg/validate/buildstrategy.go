@@ -0,0 +1,180 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+// paramReferenceRegexp matches parameter references in the dot, and the bracket notation,
+// the optional suffix is either [*] to reference a complete array, or an array index
+var paramReferenceRegexp = regexp.MustCompile(`\$?\$\(params(?:\.([^)\[\]'"]+)|\['([^']+)'\]|\["([^"]+)"\])(\[(?:\*|\d+)\])?\)`)
+
+// paramReference is a single reference to a parameter in a build strategy step
+type paramReference struct {
+	name string
+	// complete is true if the reference uses the [*] suffix
+	complete bool
+	// indexed is true if the reference uses an array index suffix
+	indexed bool
+	// isolated is true if the reference is the complete value
+	isolated bool
+}
+
+// BuildStrategySpec validates the steps, parameters and volumes of a build strategy. This applies to both
+// namespaced or cluster scoped strategies
+func BuildStrategySpec(strategy buildapi.BuilderStrategy) (bool, buildapi.BuildStrategyReason, string) {
+	for _, validation := range []func(buildapi.BuilderStrategy) (bool, buildapi.BuildStrategyReason, string){
+		validateStrategyStepNames,
+		validateStrategyParameterNames,
+		validateStrategyParameterReferences,
+		validateStrategyVolumeMounts,
+	} {
+		if valid, reason, message := validation(strategy); !valid {
+			return valid, reason, message
+		}
+	}
+
+	return true, buildapi.BuildStrategySucceeded, buildapi.AllStrategyValidationsSucceeded
+}
+
+func validateStrategyStepNames(strategy buildapi.BuilderStrategy) (bool, buildapi.BuildStrategyReason, string) {
+	stepNames := map[string]bool{}
+	duplicateStepNames := []string{}
+
+	for _, step := range strategy.GetBuildSteps() {
+		if stepNames[step.Name] {
+			duplicateStepNames = append(duplicateStepNames, step.Name)
+		}
+		stepNames[step.Name] = true
+	}
+
+	if len(duplicateStepNames) > 0 {
+		return false, buildapi.BuildStrategyDuplicateStepName, fmt.Sprintf("The following step names are used more than once: %s", strings.Join(duplicateStepNames, ", "))
+	}
+
+	return true, "", ""
+}
+
+func validateStrategyParameterNames(strategy buildapi.BuilderStrategy) (bool, buildapi.BuildStrategyReason, string) {
+	undesiredParams := []string{}
+
+	for _, parameter := range strategy.GetParameters() {
+		if resources.IsSystemReservedParameter(parameter.Name) {
+			undesiredParams = append(undesiredParams, parameter.Name)
+		}
+	}
+
+	if len(undesiredParams) > 0 {
+		return false, buildapi.BuildStrategyRestrictedParameter, fmt.Sprintf("The following parameters are restricted and cannot be defined: %s", strings.Join(undesiredParams, ", "))
+	}
+
+	return true, "", ""
+}
+
+func validateStrategyParameterReferences(strategy buildapi.BuilderStrategy) (bool, buildapi.BuildStrategyReason, string) {
+	undefinedParams := []string{}
+	arrayParamsInStringContext := []string{}
+
+	for _, step := range strategy.GetBuildSteps() {
+		for _, reference := range stepParamReferences(step) {
+			if resources.IsSystemParameter(reference.name) {
+				continue
+			}
+
+			parameter := resources.FindParameterByName(strategy.GetParameters(), reference.name)
+			if parameter == nil {
+				undefinedParams = appendIfMissing(undefinedParams, reference.name)
+				continue
+			}
+
+			// array parameters can only be used as an isolated value in the command or args
+			// of a step, or by referencing a single item using an index
+			if parameter.Type == buildapi.ParameterTypeArray && !reference.indexed && !(reference.complete && reference.isolated) {
+				arrayParamsInStringContext = appendIfMissing(arrayParamsInStringContext, fmt.Sprintf("%s (step %s)", reference.name, step.Name))
+			}
+		}
+	}
+
+	if len(undefinedParams) > 0 {
+		return false, buildapi.BuildStrategyUndefinedParameter, fmt.Sprintf("The following parameters are referenced in steps but not defined in the build strategy: %s", strings.Join(undefinedParams, ", "))
+	}
+
+	if len(arrayParamsInStringContext) > 0 {
+		return false, buildapi.BuildStrategyArrayParameterInStringContext, fmt.Sprintf("The following array parameters are used where a string is required, use $(params.name[*]) as a separate command or argument: %s", strings.Join(arrayParamsInStringContext, ", "))
+	}
+
+	return true, "", ""
+}
+
+func validateStrategyVolumeMounts(strategy buildapi.BuilderStrategy) (bool, buildapi.BuildStrategyReason, string) {
+	strategyVolumesMap := toVolumeMap(strategy.GetVolumes())
+	undefinedVolumes := []string{}
+
+	for _, step := range strategy.GetBuildSteps() {
+		for _, volumeMount := range step.VolumeMounts {
+			if _, ok := strategyVolumesMap[volumeMount.Name]; !ok {
+				undefinedVolumes = appendIfMissing(undefinedVolumes, volumeMount.Name)
+			}
+		}
+	}
+
+	if len(undefinedVolumes) > 0 {
+		return false, buildapi.BuildStrategyUndefinedVolume, fmt.Sprintf("The following volumes are mounted in steps but not defined in the build strategy: %s", strings.Join(undefinedVolumes, ", "))
+	}
+
+	return true, "", ""
+}
+
+// stepParamReferences returns all parameter references of a step, references in the
+// command and args can be isolated, all others are always used in a string context
+func stepParamReferences(step buildapi.Step) []paramReference {
+	var references []paramReference
+
+	for _, value := range append(append([]string{}, step.Command...), step.Args...) {
+		references = append(references, paramReferences(value, true)...)
+	}
+
+	references = append(references, paramReferences(step.Image, false)...)
+	references = append(references, paramReferences(step.WorkingDir, false)...)
+	for _, env := range step.Env {
+		references = append(references, paramReferences(env.Value, false)...)
+	}
+
+	return references
+}
+
+func paramReferences(value string, canBeIsolated bool) []paramReference {
+	var references []paramReference
+
+	for _, match := range paramReferenceRegexp.FindAllStringSubmatch(value, -1) {
+		// escaped references are not substituted
+		if strings.HasPrefix(match[0], "$$") {
+			continue
+		}
+
+		references = append(references, paramReference{
+			name:     match[1] + match[2] + match[3],
+			complete: match[4] == "[*]",
+			indexed:  match[4] != "" && match[4] != "[*]",
+			isolated: canBeIsolated && match[0] == value,
+		})
+	}
+
+	return references
+}
+
+func appendIfMissing(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
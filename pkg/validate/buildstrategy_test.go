@@ -0,0 +1,150 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("BuildStrategySpec", func() {
+	var strategy *buildapi.ClusterBuildStrategy
+
+	BeforeEach(func() {
+		strategy = &buildapi.ClusterBuildStrategy{
+			Spec: buildapi.BuildStrategySpec{
+				Parameters: []buildapi.Parameter{
+					{Name: "args", Type: buildapi.ParameterTypeArray},
+					{Name: "mode", Type: buildapi.ParameterTypeString},
+				},
+				Steps: []buildapi.Step{
+					{
+						Name:       "build",
+						WorkingDir: "$(params.shp-source-root)",
+						Command:    []string{"build"},
+						Args:       []string{"--mode=$(params.mode)", "$(params.args[*])", "--first=$(params.args[0])", "$(params.shp-output-image)"},
+						Env:        []corev1.EnvVar{{Name: "MODE", Value: "$(params['mode'])"}},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "cache", MountPath: "/cache"},
+						},
+					},
+				},
+				Volumes: []buildapi.BuildStrategyVolume{
+					{Name: "cache", Overridable: ptr.To(false)},
+				},
+			},
+		}
+	})
+
+	It("succeeds for a valid strategy", func() {
+		valid, reason, message := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeTrue())
+		Expect(reason).To(Equal(buildapi.BuildStrategySucceeded))
+		Expect(message).To(Equal(buildapi.AllStrategyValidationsSucceeded))
+	})
+
+	It("ignores escaped parameter references", func() {
+		strategy.Spec.Steps[0].Args = append(strategy.Spec.Steps[0].Args, "$$(params.undefined)")
+
+		valid, _, _ := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeTrue())
+	})
+
+	It("fails for duplicate step names", func() {
+		strategy.Spec.Steps = append(strategy.Spec.Steps, buildapi.Step{Name: "build"})
+
+		valid, reason, message := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyDuplicateStepName))
+		Expect(message).To(ContainSubstring("build"))
+	})
+
+	It("fails for a parameter with a reserved name", func() {
+		strategy.Spec.Parameters = append(strategy.Spec.Parameters, buildapi.Parameter{Name: "shp-source-root"})
+
+		valid, reason, message := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyRestrictedParameter))
+		Expect(message).To(ContainSubstring("shp-source-root"))
+	})
+
+	It("fails for a reference to an undefined parameter", func() {
+		strategy.Spec.Steps[0].Env = append(strategy.Spec.Steps[0].Env, corev1.EnvVar{Name: "TARGET", Value: "$(params.target)"})
+
+		valid, reason, message := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyUndefinedParameter))
+		Expect(message).To(ContainSubstring("target"))
+	})
+
+	It("fails for a reference to an undefined system parameter", func() {
+		strategy.Spec.Steps[0].Args = append(strategy.Spec.Steps[0].Args, "$(params.shp-unknown)")
+
+		valid, reason, _ := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyUndefinedParameter))
+	})
+
+	It("fails for an array parameter that is referenced without [*]", func() {
+		strategy.Spec.Steps[0].Args = append(strategy.Spec.Steps[0].Args, "$(params.args)")
+
+		valid, reason, message := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyArrayParameterInStringContext))
+		Expect(message).To(ContainSubstring("args (step build)"))
+	})
+
+	It("fails for an array parameter that is referenced as part of a string", func() {
+		strategy.Spec.Steps[0].Args = append(strategy.Spec.Steps[0].Args, "--args=$(params.args[*])")
+
+		valid, reason, _ := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyArrayParameterInStringContext))
+	})
+
+	It("fails for an array parameter that is referenced in an environment variable", func() {
+		strategy.Spec.Steps[0].Env = append(strategy.Spec.Steps[0].Env, corev1.EnvVar{Name: "ARGS", Value: "$(params.args[*])"})
+
+		valid, reason, _ := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyArrayParameterInStringContext))
+	})
+
+	It("fails for a volume mount without a volume", func() {
+		strategy.Spec.Steps[0].VolumeMounts = append(strategy.Spec.Steps[0].VolumeMounts, corev1.VolumeMount{Name: "storage", MountPath: "/storage"})
+
+		valid, reason, message := validate.BuildStrategySpec(strategy)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildapi.BuildStrategyUndefinedVolume))
+		Expect(message).To(ContainSubstring("storage"))
+	})
+
+	It("succeeds for all sample build strategies", func() {
+		files, err := filepath.Glob(filepath.Join("..", "..", "samples", "v1beta1", "buildstrategy", "*", "*.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).ToNot(BeEmpty())
+
+		for _, file := range files {
+			// #nosec G304 ok in tests
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+
+			sample := &buildapi.ClusterBuildStrategy{}
+			Expect(yaml.Unmarshal(data, sample)).To(Succeed(), file)
+
+			valid, reason, message := validate.BuildStrategySpec(sample)
+			Expect(valid).To(BeTrue(), "%s: %s %s", file, reason, message)
+		}
+	})
+})
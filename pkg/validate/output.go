@@ -10,11 +10,13 @@ import (
 	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/image"
 )
 
 // BuildSpecOutputValidator implements validation interface to add validations for `buildapi.spec.output`.
@@ -45,9 +47,9 @@ func (b *BuildSpecOutputValidator) ValidatePath(_ context.Context) error {
 
 		default:
 			// check that value is parsable integer
-			if _, err := strconv.ParseInt(*b.Build.Spec.Output.Timestamp, 10, 64); err != nil {
-				b.Build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.OutputTimestampNotValid)
-				b.Build.Status.Message = ptr.To("output timestamp value is invalid, must be Zero, SourceTimestamp, BuildTimestamp, or number")
+			if valid, reason, msg := ValidateOutputTimestamp(*b.Build.Spec.Output.Timestamp); !valid {
+				b.Build.Status.Reason = ptr.To(buildapi.BuildReason(reason))
+				b.Build.Status.Message = ptr.To(msg)
 			}
 		}
 	}
@@ -56,6 +58,14 @@ func (b *BuildSpecOutputValidator) ValidatePath(_ context.Context) error {
 		b.checkOutputPlatformsFields()
 	}
 
+	if valid, reason, msg := ValidateOutputTags(b.Build.Spec.Output); !valid {
+		b.Build.Status.Reason = ptr.To(buildapi.BuildReason(reason))
+		b.Build.Status.Message = ptr.To(msg)
+	} else if usesGitTagPlaceholders(b.Build.Spec.Output) && (b.Build.Spec.Source == nil || b.Build.Spec.Source.Type != buildapi.GitType) {
+		b.Build.Status.Reason = ptr.To(buildapi.OutputTagNotValid)
+		b.Build.Status.Message = ptr.To(fmt.Sprintf("the placeholders %s and %s of the output image require a Git source", buildapi.OutputImageTagGitCommitSha, buildapi.OutputImageTagGitDescribe))
+	}
+
 	return nil
 }
 
@@ -74,7 +84,88 @@ func (b *BuildSpecOutputValidator) checkOutputPlatformsFields() {
 
 func (b *BuildSpecOutputValidator) isEmptySource() bool {
 	return b.Build.Spec.Source == nil ||
-		b.Build.Spec.Source.Git == nil && b.Build.Spec.Source.OCIArtifact == nil && b.Build.Spec.Source.Local == nil
+		b.Build.Spec.Source.Git == nil && b.Build.Spec.Source.OCIArtifact == nil && b.Build.Spec.Source.HTTPArchive == nil && b.Build.Spec.Source.Local == nil
+}
+
+// ValidateOutputTimestamp validates the value of spec.output.timestamp, without checking whether
+// the value can be used in combination with the build source.
+func ValidateOutputTimestamp(timestamp string) (bool, string, string) {
+	switch timestamp {
+	case "", buildapi.OutputImageZeroTimestamp, buildapi.OutputImageSourceTimestamp, buildapi.OutputImageBuildTimestamp:
+		return true, "", ""
+	}
+
+	if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+		return false, string(buildapi.OutputTimestampNotValid), "output timestamp value is invalid, must be Zero, SourceTimestamp, BuildTimestamp, or number"
+	}
+
+	return true, "", ""
+}
+
+// outputTagPlaceholders are the placeholders that can be used in the tags of the output image
+var outputTagPlaceholders = []string{
+	buildapi.OutputImageTagGitCommitSha,
+	buildapi.OutputImageTagGitDescribe,
+	buildapi.OutputImageTagBuildRunName,
+	buildapi.OutputImageTagDate,
+}
+
+// ValidateOutputTags validates the placeholders in the tag of the output image and its
+// additional tags, without checking whether the placeholders can be used with the build source.
+func ValidateOutputTags(output buildapi.Image) (bool, string, string) {
+	if placeholders := image.TagPlaceholders(output.Image); len(placeholders) > 0 {
+		// the placeholders can only be used in the tag, which follows the last slash
+		_, tag, found := strings.Cut(output.Image[strings.LastIndex(output.Image, "/")+1:], ":")
+		if !found || strings.Contains(tag, "@") || len(image.TagPlaceholders(tag)) != len(placeholders) {
+			return false, string(buildapi.OutputTagNotValid), fmt.Sprintf("output image %q can only contain placeholders in its tag", output.Image)
+		}
+
+		if valid, msg := validateOutputTag(tag); !valid {
+			return false, string(buildapi.OutputTagNotValid), msg
+		}
+	}
+
+	for _, tag := range output.AdditionalTags {
+		if valid, msg := validateOutputTag(tag); !valid {
+			return false, string(buildapi.OutputTagNotValid), msg
+		}
+	}
+
+	return true, "", ""
+}
+
+// validateOutputTag validates a tag of the output image that can contain placeholders
+func validateOutputTag(tag string) (bool, string) {
+	for _, placeholder := range image.TagPlaceholders(tag) {
+		if !slices.Contains(outputTagPlaceholders, placeholder) {
+			return false, fmt.Sprintf("output tag %q contains the unknown placeholder %s, supported are %s", tag, placeholder, strings.Join(outputTagPlaceholders, ", "))
+		}
+	}
+
+	// the placeholders are resolved to valid characters of a tag
+	if resolved, _ := image.ResolveTagPlaceholders(tag, map[string]string{
+		buildapi.OutputImageTagGitCommitSha: "x",
+		buildapi.OutputImageTagGitDescribe:  "x",
+		buildapi.OutputImageTagBuildRunName: "x",
+		buildapi.OutputImageTagDate:         "x",
+	}); !image.IsValidTag(resolved) {
+		return false, fmt.Sprintf("output tag %q is not a valid tag", tag)
+	}
+
+	return true, ""
+}
+
+// usesGitTagPlaceholders returns whether the tags of the output image use the results of the Git source
+func usesGitTagPlaceholders(output buildapi.Image) bool {
+	for _, value := range append([]string{output.Image}, output.AdditionalTags...) {
+		for _, placeholder := range image.TagPlaceholders(value) {
+			if placeholder == buildapi.OutputImageTagGitCommitSha || placeholder == buildapi.OutputImageTagGitDescribe {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 // os/arch must be the same strings as Node labels kubernetes.io/os and kubernetes.io/arch
@@ -134,17 +225,53 @@ func ValidatePipelineRunExecutor(executor string) (bool, string, string) {
 	return true, "", ""
 }
 
-// ValidateMultiArchPreflight runs platform, nodeSelector, and executor checks before
-// the reconciler lists Nodes. If this returns (true, "", ""), the caller may List Nodes
+// ValidateMultiPlatformStorageClass checks that the controller is configured with a storage class
+// that provides ReadWriteMany volumes, the per-platform PipelineTasks run on nodes of different
+// architectures and share the source volume.
+func ValidateMultiPlatformStorageClass(storageClass string) (bool, string, string) {
+	if storageClass == "" {
+		return false, string(buildapi.MultiPlatformStorageClassMissing),
+			"multi-arch builds require a storage class that provides ReadWriteMany volumes, which is not configured in the controller"
+	}
+	return true, "", ""
+}
+
+// ValidateAffinityAssistant checks that the feature flags of Tekton disable the affinity assistant.
+// It schedules all pods of a PipelineRun that use the same volume on one node, so that the tasks of
+// the platforms with a different architecture than that node can never be scheduled.
+func ValidateAffinityAssistant(featureFlags map[string]string) (bool, string, string) {
+	coschedule := featureFlags["coschedule"]
+	switch {
+	case coschedule == "disabled":
+		return true, "", ""
+
+	// older Tekton versions disable the affinity assistant with a separate flag
+	case (coschedule == "" || coschedule == "workspaces") && featureFlags["disable-affinity-assistant"] == "true":
+		return true, "", ""
+	}
+
+	if coschedule == "" {
+		coschedule = "workspaces"
+	}
+
+	return false, string(buildapi.MultiPlatformAffinityAssistantEnabled), fmt.Sprintf(
+		"multi-arch builds require the coschedule feature flag of Tekton to be disabled, it is %s", coschedule)
+}
+
+// ValidateMultiArchPreflight runs platform, nodeSelector, executor, and storage class checks
+// before the reconciler lists Nodes. If this returns (true, "", ""), the caller may List Nodes
 // and then call ValidateNodeAvailability.
-func ValidateMultiArchPreflight(platforms []buildapi.ImagePlatform, nodeSelector map[string]string, executor string) (bool, string, string) {
+func ValidateMultiArchPreflight(platforms []buildapi.ImagePlatform, nodeSelector map[string]string, executor string, storageClass string) (bool, string, string) {
 	if valid, reason, msg := ValidatePlatforms(platforms); !valid {
 		return valid, reason, msg
 	}
 	if valid, reason, msg := ValidateOutputNodeSelector(nodeSelector); !valid {
 		return valid, reason, msg
 	}
-	return ValidatePipelineRunExecutor(executor)
+	if valid, reason, msg := ValidatePipelineRunExecutor(executor); !valid {
+		return valid, reason, msg
+	}
+	return ValidateMultiPlatformStorageClass(storageClass)
 }
 
 // ValidateNodeAvailability checks that, for each requested platform, the cluster has
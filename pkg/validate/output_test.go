@@ -82,6 +82,20 @@ var _ = Describe("BuildSpecOutputValidator", func() {
 			Expect(build.Status.Message).To(BeNil())
 		})
 
+		It("should pass with string SourceTimestamp for an HTTP archive source", func() {
+			build := sampleBuild(buildapi.OutputImageSourceTimestamp)
+			build.Spec.Source = &buildapi.Source{
+				Type: buildapi.HTTPType,
+				HTTPArchive: &buildapi.HTTPArchive{
+					URL: "https://example.com/sources/app-1.0.tar.gz",
+				},
+			}
+
+			validate(build)
+			Expect(build.Status.Reason).To(BeNil())
+			Expect(build.Status.Message).To(BeNil())
+		})
+
 		It("should fail with string SourceTimestamp in case there are no sources", func() {
 			build := sampleBuild(buildapi.OutputImageSourceTimestamp)
 			build.Spec.Source = nil
@@ -225,6 +239,64 @@ var _ = Describe("BuildSpecOutputValidator", func() {
 			Expect(build.Status.Message).To(BeNil())
 		})
 	})
+
+	Context("output tags are specified", func() {
+		var sampleBuild = func(source *buildapi.Source, image string, additionalTags ...string) *buildapi.Build {
+			return &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source:   source,
+					Strategy: buildapi.Strategy{Name: "magic"},
+					Output: buildapi.Image{
+						Image:          image,
+						AdditionalTags: additionalTags,
+					},
+				},
+			}
+		}
+
+		gitSource := &buildapi.Source{
+			Type: buildapi.GitType,
+			Git:  &buildapi.Git{URL: "https://github.com/shipwright-io/sample-go"},
+		}
+
+		It("should pass with placeholders in the tag and the additional tags", func() {
+			build := sampleBuild(gitSource, "registry.example.com:5000/app:$(source.git.commitSha)", "$(source.git.describe)", "$(buildrun.name)-$(date)", "latest")
+			validate(build)
+			Expect(build.Status.Reason).To(BeNil())
+			Expect(build.Status.Message).To(BeNil())
+		})
+
+		It("should fail with placeholders outside of the tag", func() {
+			build := sampleBuild(gitSource, "registry.example.com/$(buildrun.name):latest")
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(buildapi.OutputTagNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("can only contain placeholders in its tag"))
+		})
+
+		It("should fail with an unknown placeholder", func() {
+			build := sampleBuild(gitSource, "registry.example.com/app", "$(source.git.branchName)")
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(buildapi.OutputTagNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("unknown placeholder $(source.git.branchName)"))
+		})
+
+		It("should fail with an invalid additional tag", func() {
+			build := sampleBuild(gitSource, "registry.example.com/app", "release/$(date)")
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(buildapi.OutputTagNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("is not a valid tag"))
+		})
+
+		It("should fail with Git placeholders for a source that is not Git", func() {
+			build := sampleBuild(&buildapi.Source{
+				Type:        buildapi.OCIArtifactType,
+				OCIArtifact: &buildapi.OCIArtifact{Image: "ghcr.io/shipwright-io/sample-go/source-bundle:latest"},
+			}, "registry.example.com/app:$(source.git.commitSha)")
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(buildapi.OutputTagNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("require a Git source"))
+		})
+	})
 })
 
 var _ = Describe("ValidateNodeAvailability", func() {
@@ -313,7 +385,7 @@ var _ = Describe("ValidateMultiArchPreflight", func() {
 			{OS: "linux", Arch: "amd64"},
 			{OS: "linux", Arch: "arm64"},
 		}
-		valid, reason, msg := validate.ValidateMultiArchPreflight(platforms, map[string]string{"disktype": "ssd"}, "PipelineRun")
+		valid, reason, msg := validate.ValidateMultiArchPreflight(platforms, map[string]string{"disktype": "ssd"}, "PipelineRun", "nfs-client")
 		Expect(valid).To(BeTrue())
 		Expect(reason).To(BeEmpty())
 		Expect(msg).To(BeEmpty())
@@ -321,10 +393,61 @@ var _ = Describe("ValidateMultiArchPreflight", func() {
 
 	It("returns ExecutorNotPipelineRun when executor is TaskRun", func() {
 		platforms := []buildapi.ImagePlatform{{OS: "linux", Arch: "amd64"}}
-		valid, reason, msg := validate.ValidateMultiArchPreflight(platforms, nil, "TaskRun")
+		valid, reason, msg := validate.ValidateMultiArchPreflight(platforms, nil, "TaskRun", "nfs-client")
 		Expect(valid).To(BeFalse())
 		Expect(reason).To(Equal(string(buildapi.ExecutorNotPipelineRun)))
 		Expect(msg).To(ContainSubstring("PipelineRun executor mode"))
 		Expect(msg).To(ContainSubstring("TaskRun"))
 	})
+
+	It("returns MultiPlatformStorageClassMissing when no storage class is configured", func() {
+		platforms := []buildapi.ImagePlatform{{OS: "linux", Arch: "amd64"}}
+		valid, reason, msg := validate.ValidateMultiArchPreflight(platforms, nil, "PipelineRun", "")
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(string(buildapi.MultiPlatformStorageClassMissing)))
+		Expect(msg).To(ContainSubstring("ReadWriteMany"))
+	})
+})
+
+var _ = Describe("ValidateAffinityAssistant", func() {
+	It("succeeds when the coschedule feature flag is disabled", func() {
+		valid, reason, msg := validate.ValidateAffinityAssistant(map[string]string{"coschedule": "disabled"})
+		Expect(valid).To(BeTrue())
+		Expect(reason).To(BeEmpty())
+		Expect(msg).To(BeEmpty())
+	})
+
+	It("succeeds when the affinity assistant is disabled with the flag of older Tekton versions", func() {
+		valid, _, _ := validate.ValidateAffinityAssistant(map[string]string{"disable-affinity-assistant": "true"})
+		Expect(valid).To(BeTrue())
+	})
+
+	It("returns MultiPlatformAffinityAssistantEnabled for the default coschedule feature flag", func() {
+		valid, reason, msg := validate.ValidateAffinityAssistant(map[string]string{})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(string(buildapi.MultiPlatformAffinityAssistantEnabled)))
+		Expect(msg).To(ContainSubstring("workspaces"))
+	})
+
+	It("returns MultiPlatformAffinityAssistantEnabled when all pods of a PipelineRun are coscheduled", func() {
+		valid, reason, msg := validate.ValidateAffinityAssistant(map[string]string{"coschedule": "pipelineruns", "disable-affinity-assistant": "true"})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(string(buildapi.MultiPlatformAffinityAssistantEnabled)))
+		Expect(msg).To(ContainSubstring("pipelineruns"))
+	})
+})
+
+var _ = Describe("ValidateOutputTimestamp", func() {
+	It("succeeds for the known values and numbers", func() {
+		for _, timestamp := range []string{"", buildapi.OutputImageZeroTimestamp, buildapi.OutputImageSourceTimestamp, buildapi.OutputImageBuildTimestamp, "1691650396"} {
+			valid, _, _ := validate.ValidateOutputTimestamp(timestamp)
+			Expect(valid).To(BeTrue(), timestamp)
+		}
+	})
+
+	It("returns OutputTimestampNotValid for other values", func() {
+		valid, reason, _ := validate.ValidateOutputTimestamp("yesterday")
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(string(buildapi.OutputTimestampNotValid)))
+	})
 })
@@ -6,6 +6,7 @@ package validate
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
@@ -24,6 +25,76 @@ func BuildRunParameters(parameterDefinitions []buildapi.Parameter, buildParamVal
 	return validateParameters(parameterDefinitions, paramValues, false)
 }
 
+// ParamValues validates the parameter values of a Build or BuildRun without the parameter definitions of the
+// BuildStrategy. It only detects issues that are independent of the strategy, like restricted parameter names
+// or values that set none or more than one of 'configMapValue', 'secretValue', and 'value'.
+func ParamValues(paramValues []buildapi.ParamValue) (bool, string, string) {
+	// list of params that collide with reserved system strategy parameters
+	undesiredParams := []string{}
+
+	// list of params that have multiple values set
+	multiValueParams := []string{}
+
+	// list of parameters where at least one array item is empty
+	arrayItemEmptyParameters := []string{}
+
+	// list of params that have incomplete ConfigMap values
+	incompleteConfigMapValueParameters := []string{}
+
+	// list of params that have incomplete Secret values
+	incompleteSecretValueParameters := []string{}
+
+	for _, paramValue := range paramValues {
+		if resources.IsSystemReservedParameter(paramValue.Name) {
+			undesiredParams = append(undesiredParams, paramValue.Name)
+			continue
+		}
+
+		singleValues := paramValue.Values
+		if paramValue.SingleValue != nil {
+			singleValues = append([]buildapi.SingleValue{*paramValue.SingleValue}, singleValues...)
+		}
+
+		if slices.ContainsFunc(paramValue.Values, hasNoValue) {
+			arrayItemEmptyParameters = append(arrayItemEmptyParameters, paramValue.Name)
+		}
+
+		if slices.ContainsFunc(singleValues, hasMoreThanOneValue) {
+			multiValueParams = append(multiValueParams, paramValue.Name)
+		}
+
+		if slices.ContainsFunc(singleValues, hasIncompleteConfigMapValue) {
+			incompleteConfigMapValueParameters = append(incompleteConfigMapValueParameters, paramValue.Name)
+		}
+
+		if slices.ContainsFunc(singleValues, hasIncompleteSecretValue) {
+			incompleteSecretValueParameters = append(incompleteSecretValueParameters, paramValue.Name)
+		}
+	}
+
+	if len(undesiredParams) > 0 {
+		return false, resources.ConditionRestrictedParametersInUse, fmt.Sprintf("The following parameters are restricted and cannot be set: %s", strings.Join(undesiredParams, ", "))
+	}
+
+	if len(multiValueParams) > 0 {
+		return false, resources.ConditionInconsistentParameterValues, fmt.Sprintf("The following parameters have more than one of 'configMapValue', 'secretValue', and 'value' set: %s", strings.Join(multiValueParams, ", "))
+	}
+
+	if len(arrayItemEmptyParameters) > 0 {
+		return false, resources.ConditionEmptyArrayItemParameterValues, fmt.Sprintf("The values for the following array parameters are containing at least one item where none of 'configMapValue', 'secretValue', and 'value' are set: %s", strings.Join(arrayItemEmptyParameters, ", "))
+	}
+
+	if len(incompleteConfigMapValueParameters) > 0 {
+		return false, resources.ConditionIncompleteConfigMapValueParameterValues, fmt.Sprintf("The values for the following parameters are containing a 'configMapValue' with an empty 'name' or 'key': %s", strings.Join(incompleteConfigMapValueParameters, ", "))
+	}
+
+	if len(incompleteSecretValueParameters) > 0 {
+		return false, resources.ConditionIncompleteSecretValueParameterValues, fmt.Sprintf("The values for the following parameters are containing a 'secretValue' with an empty 'name' or 'key': %s", strings.Join(incompleteSecretValueParameters, ", "))
+	}
+
+	return true, "", ""
+}
+
 func validateParameters(parameterDefinitions []buildapi.Parameter, paramValues []buildapi.ParamValue, ignoreMissingParameters bool) (bool, string, string) {
 	// list of params that collide with reserved system strategy parameters
 	undesiredParams := []string{}
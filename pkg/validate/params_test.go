@@ -412,3 +412,63 @@ var _ = Describe("ValidateBuildRunParameters", func() {
 		})
 	})
 })
+
+var _ = Describe("ValidateParamValues", func() {
+	It("validates parameter values that are independent of the build strategy", func() {
+		valid, _, _ := validate.ParamValues([]buildapi.ParamValue{
+			{
+				Name: "string-param",
+				SingleValue: &buildapi.SingleValue{
+					Value: ptr.To("a value"),
+				},
+			},
+			{
+				Name: "array-param",
+				Values: []buildapi.SingleValue{
+					{Value: ptr.To("an item")},
+					{ConfigMapValue: &buildapi.ObjectKeyRef{Name: "a-configmap", Key: "a-key"}},
+				},
+			},
+		})
+		Expect(valid).To(BeTrue())
+	})
+
+	It("fails for a system parameter", func() {
+		valid, reason, message := validate.ParamValues([]buildapi.ParamValue{
+			{
+				Name: "shp-source-root",
+				SingleValue: &buildapi.SingleValue{
+					Value: ptr.To("/workspace/source"),
+				},
+			},
+		})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal("RestrictedParametersInUse"))
+		Expect(message).To(Equal("The following parameters are restricted and cannot be set: shp-source-root"))
+	})
+
+	It("fails for an array item without a value", func() {
+		valid, reason, _ := validate.ParamValues([]buildapi.ParamValue{
+			{
+				Name:   "array-param",
+				Values: []buildapi.SingleValue{{}},
+			},
+		})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal("EmptyArrayItemParameterValues"))
+	})
+
+	It("fails for a value with an incomplete secret reference", func() {
+		valid, reason, message := validate.ParamValues([]buildapi.ParamValue{
+			{
+				Name: "string-param",
+				SingleValue: &buildapi.SingleValue{
+					SecretValue: &buildapi.ObjectKeyRef{Name: "a-secret"},
+				},
+			},
+		})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal("IncompleteSecretValueParameterValues"))
+		Expect(message).To(ContainSubstring("string-param"))
+	})
+})
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/git"
 )
 
 // Credentials contains all required fields
@@ -34,17 +35,23 @@ func NewCredentials(client client.Client, build *buildapi.Build) *Credentials {
 // that all referenced secrets under spec exists
 func (s Credentials) ValidatePath(ctx context.Context) error {
 	var missingSecrets []string
-	secret := &corev1.Secret{}
 
 	secretNames := s.buildCredentialReferences()
 
 	for refSecret, secretType := range secretNames {
+		secret := &corev1.Secret{}
 		if err := s.Client.Get(ctx, types.NamespacedName{Name: refSecret, Namespace: s.Build.Namespace}, secret); err != nil && !apierrors.IsNotFound(err) {
 			return err
 		} else if apierrors.IsNotFound(err) {
 			s.Build.Status.Reason = ptr.To[buildapi.BuildReason](secretType)
 			s.Build.Status.Message = ptr.To(fmt.Sprintf("referenced secret %s not found", refSecret))
 			missingSecrets = append(missingSecrets, refSecret)
+		} else if secretType == buildapi.SpecSourceSecretRefNotFound && git.IsGitHubAppSecret(secret.Data) {
+			// a source secret with the credentials of a GitHub App must contain all of them
+			if err := git.ValidateGitHubAppSecret(secret.Data); err != nil {
+				s.Build.Status.Reason = ptr.To(buildapi.SpecSourceSecretNotValid)
+				s.Build.Status.Message = ptr.To(fmt.Sprintf("referenced secret %s is not a valid GitHub App secret: %s", refSecret, err.Error()))
+			}
 		}
 	}
 
@@ -68,5 +75,25 @@ func (s Credentials) buildCredentialReferences() map[string]buildapi.BuildReason
 	if s.Build.GetSourceCredentials() != nil {
 		secretRefMap[*s.Build.GetSourceCredentials()] = buildapi.SpecSourceSecretRefNotFound
 	}
+
+	for _, secret := range s.Build.GetAdditionalSourceCredentials() {
+		secretRefMap[secret] = buildapi.SpecSourceSecretRefNotFound
+	}
+
+	for _, secret := range s.Build.GetSignatureVerificationSecrets() {
+		secretRefMap[secret] = buildapi.SpecSourceSecretRefNotFound
+	}
+
+	if s.Build.Spec.Trigger != nil {
+		if s.Build.Spec.Trigger.TriggerSecret != nil {
+			secretRefMap[*s.Build.Spec.Trigger.TriggerSecret] = buildapi.SpecTriggerSecretRefNotFound
+		}
+
+		for _, when := range s.Build.Spec.Trigger.When {
+			if when.Image != nil && when.Image.PullSecret != nil {
+				secretRefMap[*when.Image.PullSecret] = buildapi.SpecTriggerSecretRefNotFound
+			}
+		}
+	}
 	return secretRefMap
 }
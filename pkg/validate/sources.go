@@ -7,10 +7,17 @@ package validate
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 )
 
+// defaultSourceName is the name of the source of the Build, which additional sources must not use
+const defaultSourceName = "default"
+
 // SourcesRef implements RuntimeRef interface to add validations for `buildapi.spec.source`.
 type SourceRef struct {
 	Build *buildapi.Build // build instance for analysis
@@ -19,10 +26,12 @@ type SourceRef struct {
 // ValidatePath executes the validation routine, inspecting the `buildapi.spec.source` path
 func (s *SourceRef) ValidatePath(_ context.Context) error {
 	if s.Build.Spec.Source != nil {
-		return s.validateSourceEntry(s.Build.Spec.Source)
+		if err := s.validateSourceEntry(s.Build.Spec.Source); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return s.validateAdditionalSources(s.Build.Spec.AdditionalSources)
 }
 
 // validateSourceEntry inspect informed entry, probes all required attributes.
@@ -30,21 +39,42 @@ func (s *SourceRef) validateSourceEntry(source *buildapi.Source) error {
 
 	// dont bail out if the Source object is empty, we preserve the old behaviour as in v1alpha1
 	if source.Type == "" && source.Git == nil &&
-		source.OCIArtifact == nil && source.Local == nil {
+		source.OCIArtifact == nil && source.HTTPArchive == nil && source.Local == nil {
 		return nil
 	}
 
 	switch source.Type {
 	case buildapi.GitType:
-		if source.Git == nil || source.OCIArtifact != nil || source.Local != nil {
+		if source.Git == nil || source.OCIArtifact != nil || source.HTTPArchive != nil || source.Local != nil {
 			return fmt.Errorf("type does not match the source")
 		}
+
+		if err := validateSparseCheckout(source.Git.SparseCheckout); err != nil {
+			return err
+		}
+
+		if err := validatePullRequest(source.Git.PullRequest); err != nil {
+			return err
+		}
+
+		if err := validateLFS(source.Git.LFS); err != nil {
+			return err
+		}
 	case buildapi.OCIArtifactType:
-		if source.OCIArtifact == nil || source.Git != nil || source.Local != nil {
+		if source.OCIArtifact == nil || source.Git != nil || source.HTTPArchive != nil || source.Local != nil {
+			return fmt.Errorf("type does not match the source")
+		}
+	case buildapi.HTTPType:
+		if source.HTTPArchive == nil || source.Git != nil || source.OCIArtifact != nil || source.Local != nil {
 			return fmt.Errorf("type does not match the source")
 		}
+
+		archiveURL, err := url.Parse(source.HTTPArchive.URL)
+		if err != nil || (archiveURL.Scheme != "http" && archiveURL.Scheme != "https") || archiveURL.Host == "" {
+			return fmt.Errorf("the URL %q of the HTTP archive is not a valid HTTP or HTTPS URL", source.HTTPArchive.URL)
+		}
 	case buildapi.LocalType:
-		if source.Local == nil || source.OCIArtifact != nil || source.Git != nil {
+		if source.Local == nil || source.OCIArtifact != nil || source.HTTPArchive != nil || source.Git != nil {
 			return fmt.Errorf("type does not match the source")
 		}
 	case "":
@@ -53,7 +83,122 @@ func (s *SourceRef) validateSourceEntry(source *buildapi.Source) error {
 	return nil
 }
 
+// validateAdditionalSources inspects the additional sources, their names and target directories
+// must be unique, and the target directories must be relative paths inside of the source root.
+func (s *SourceRef) validateAdditionalSources(additionalSources []buildapi.AdditionalSource) error {
+	names := map[string]struct{}{}
+	targetDirs := map[string]struct{}{}
+
+	for _, source := range additionalSources {
+		if source.Name == "" {
+			return fmt.Errorf("name of additional source is missing")
+		}
+
+		if source.Name == defaultSourceName {
+			return fmt.Errorf("additional source name %q is reserved for the source of the Build", defaultSourceName)
+		}
+
+		if _, found := names[source.Name]; found {
+			return fmt.Errorf("additional source name %q is used more than once", source.Name)
+		}
+		names[source.Name] = struct{}{}
+
+		switch source.Type {
+		case buildapi.GitType:
+			if source.Git == nil || source.OCIArtifact != nil {
+				return fmt.Errorf("type does not match the additional source %q", source.Name)
+			}
+
+			if err := validateSparseCheckout(source.Git.SparseCheckout); err != nil {
+				return err
+			}
+
+			if err := validatePullRequest(source.Git.PullRequest); err != nil {
+				return err
+			}
+
+			if err := validateLFS(source.Git.LFS); err != nil {
+				return err
+			}
+		case buildapi.OCIArtifactType:
+			if source.OCIArtifact == nil || source.Git != nil {
+				return fmt.Errorf("type does not match the additional source %q", source.Name)
+			}
+		case "":
+			return fmt.Errorf("type definition of additional source %q is missing", source.Name)
+		default:
+			return fmt.Errorf("type %q of additional source %q is not supported, only Git and OCI are supported", source.Type, source.Name)
+		}
+
+		targetDir := path.Clean(source.TargetDir)
+		if source.TargetDir == "" || path.IsAbs(targetDir) || targetDir == "." || targetDir == ".." || strings.HasPrefix(targetDir, "../") {
+			return fmt.Errorf("target directory %q of additional source %q must be a relative path inside of the source root", source.TargetDir, source.Name)
+		}
+
+		if _, found := targetDirs[targetDir]; found {
+			return fmt.Errorf("target directory %q of additional source %q is used more than once", source.TargetDir, source.Name)
+		}
+		targetDirs[targetDir] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateSparseCheckout inspects the paths of a sparse checkout, they must be relative paths
+// inside of the repository
+func validateSparseCheckout(sparseCheckout *buildapi.SparseCheckout) error {
+	if sparseCheckout == nil {
+		return nil
+	}
+
+	for _, sparseCheckoutPath := range sparseCheckout.Paths {
+		cleanPath := path.Clean(sparseCheckoutPath)
+		if sparseCheckoutPath == "" || path.IsAbs(cleanPath) || cleanPath == "." || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+			return fmt.Errorf("sparse checkout path %q must be a relative path inside of the repository", sparseCheckoutPath)
+		}
+	}
+
+	return nil
+}
+
 // NewSourcesRef instantiate a new SourcesRef passing the build object pointer along.
 func NewSourceRef(b *buildapi.Build) *SourceRef {
 	return &SourceRef{Build: b}
 }
+
+// validatePullRequest inspects the pull request, its number must be positive and its
+// ref must be a ref below refs/
+func validatePullRequest(pullRequest *buildapi.PullRequest) error {
+	if pullRequest == nil {
+		return nil
+	}
+
+	if pullRequest.Number < 1 {
+		return fmt.Errorf("pull request number %d must be a positive number", pullRequest.Number)
+	}
+
+	if pullRequest.Ref != nil {
+		ref := *pullRequest.Ref
+		if !strings.HasPrefix(ref, "refs/") || strings.Contains(ref, "..") || strings.ContainsAny(ref, " \t\n~^:?*[\\") {
+			return fmt.Errorf("pull request ref %q must be a valid ref below refs/", ref)
+		}
+	}
+
+	return nil
+}
+
+// validateLFS inspects the patterns of the LFS files, they are passed to Git as a comma
+// separated list and therefore must not be empty or contain a comma
+func validateLFS(lfs *buildapi.LFS) error {
+	if lfs == nil {
+		return nil
+	}
+
+	for _, pattern := range append(slices.Clone(lfs.Include), lfs.Exclude...) {
+		if strings.TrimSpace(pattern) == "" || strings.Contains(pattern, ",") {
+			return fmt.Errorf("LFS pattern %q must not be empty or contain a comma", pattern)
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/validate"
@@ -59,5 +60,186 @@ var _ = Describe("SourcesRef", func() {
 
 			Expect(srcRef.ValidatePath(context.TODO())).To(HaveOccurred())
 		})
+
+		It("should successfully validate a build with an HTTP archive source", func() {
+			srcRef := validate.NewSourceRef(&buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type:        buildapi.HTTPType,
+						HTTPArchive: &buildapi.HTTPArchive{URL: "https://example.com/sources/app-1.0.tar.gz"},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(BeNil())
+		})
+
+		It("should fail to validate an HTTP archive source without an HTTP URL", func() {
+			srcRef := validate.NewSourceRef(&buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type:        buildapi.HTTPType,
+						HTTPArchive: &buildapi.HTTPArchive{URL: "ftp://example.com/sources/app-1.0.tar.gz"},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(MatchError(ContainSubstring("is not a valid HTTP or HTTPS URL")))
+		})
+
+		It("should successfully validate a Git source with a sparse checkout", func() {
+			srcRef := validate.NewSourceRef(&buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type: buildapi.GitType,
+						Git: &buildapi.Git{
+							URL:            "https://github.com/shipwright-io/sample-go",
+							SparseCheckout: &buildapi.SparseCheckout{Paths: []string{"docker-build", "source-build/"}},
+						},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should fail to validate a sparse checkout path outside of the repository", func() {
+			srcRef := validate.NewSourceRef(&buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type: buildapi.GitType,
+						Git: &buildapi.Git{
+							URL:            "https://github.com/shipwright-io/sample-go",
+							SparseCheckout: &buildapi.SparseCheckout{Paths: []string{"docker-build", "../other"}},
+						},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(MatchError(`sparse checkout path "../other" must be a relative path inside of the repository`))
+		})
+
+		It("should successfully validate a Git source with a pull request", func() {
+			srcRef := validate.NewSourceRef(&buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type: buildapi.GitType,
+						Git: &buildapi.Git{
+							URL:         "https://gitlab.com/shipwright-io/sample-go",
+							PullRequest: &buildapi.PullRequest{Number: 42, Ref: ptr.To("refs/merge-requests/42/head"), Merge: true},
+						},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should fail to validate a pull request with an invalid ref", func() {
+			srcRef := validate.NewSourceRef(&buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type: buildapi.GitType,
+						Git: &buildapi.Git{
+							URL:         "https://github.com/shipwright-io/sample-go",
+							PullRequest: &buildapi.PullRequest{Number: 42, Ref: ptr.To("pull/42/head")},
+						},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(MatchError(`pull request ref "pull/42/head" must be a valid ref below refs/`))
+		})
+
+		It("should fail to validate an LFS pattern with a comma", func() {
+			srcRef := validate.NewSourceRef(&buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Source: &buildapi.Source{
+						Type: buildapi.GitType,
+						Git: &buildapi.Git{
+							URL: "https://github.com/shipwright-io/sample-lfs",
+							LFS: &buildapi.LFS{Include: []string{"assets/*"}, Exclude: []string{"*.png,*.jpg"}},
+						},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(MatchError(`LFS pattern "*.png,*.jpg" must not be empty or contain a comma`))
+		})
+
+		Context("additional sources", func() {
+			withAdditionalSources := func(additionalSources ...buildapi.AdditionalSource) *validate.SourceRef {
+				return validate.NewSourceRef(&buildapi.Build{
+					Spec: buildapi.BuildSpec{
+						Source: &buildapi.Source{
+							Type: buildapi.GitType,
+							Git:  &buildapi.Git{URL: "https://github.com/shipwright-io/sample-go"},
+						},
+						AdditionalSources: additionalSources,
+					},
+				})
+			}
+
+			scripts := buildapi.AdditionalSource{
+				Name:      "scripts",
+				Type:      buildapi.GitType,
+				TargetDir: "build/scripts",
+				Git:       &buildapi.Git{URL: "https://github.com/shipwright-io/build-scripts"},
+			}
+
+			It("should successfully validate Git and OCI additional sources", func() {
+				Expect(withAdditionalSources(scripts, buildapi.AdditionalSource{
+					Name:        "protos",
+					Type:        buildapi.OCIArtifactType,
+					TargetDir:   "protos",
+					OCIArtifact: &buildapi.OCIArtifact{Image: "ghcr.io/shipwright-io/protos:latest"},
+				}).ValidatePath(context.TODO())).To(Succeed())
+			})
+
+			It("should fail to validate if a name is used more than once", func() {
+				other := *scripts.DeepCopy()
+				other.TargetDir = "other"
+
+				Expect(withAdditionalSources(scripts, other).ValidatePath(context.TODO())).To(MatchError(`additional source name "scripts" is used more than once`))
+			})
+
+			It("should fail to validate if the name of the source of the Build is used", func() {
+				other := *scripts.DeepCopy()
+				other.Name = "default"
+
+				Expect(withAdditionalSources(other).ValidatePath(context.TODO())).To(MatchError(ContainSubstring("is reserved for the source of the Build")))
+			})
+
+			It("should fail to validate if the type does not match the source", func() {
+				other := *scripts.DeepCopy()
+				other.Type = buildapi.OCIArtifactType
+
+				Expect(withAdditionalSources(other).ValidatePath(context.TODO())).To(MatchError(`type does not match the additional source "scripts"`))
+			})
+
+			It("should fail to validate an unsupported type", func() {
+				other := *scripts.DeepCopy()
+				other.Type = buildapi.LocalType
+
+				Expect(withAdditionalSources(other).ValidatePath(context.TODO())).To(MatchError(ContainSubstring("only Git and OCI are supported")))
+			})
+
+			It("should fail to validate a target directory outside of the source root", func() {
+				for _, targetDir := range []string{"", ".", "/scripts", "../scripts", "scripts/../.."} {
+					other := *scripts.DeepCopy()
+					other.TargetDir = targetDir
+
+					Expect(withAdditionalSources(other).ValidatePath(context.TODO())).To(MatchError(ContainSubstring("must be a relative path inside of the source root")), targetDir)
+				}
+			})
+
+			It("should fail to validate if a target directory is used more than once", func() {
+				other := *scripts.DeepCopy()
+				other.Name = "other"
+				other.TargetDir = "build/scripts/"
+
+				Expect(withAdditionalSources(scripts, other).ValidatePath(context.TODO())).To(MatchError(ContainSubstring("is used more than once")))
+			})
+		})
 	})
 })
@@ -6,7 +6,12 @@ package validate
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	imagename "github.com/google/go-containerregistry/pkg/name"
+	"github.com/robfig/cron/v3"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/ptr"
 
@@ -19,6 +24,68 @@ type Trigger struct {
 	build *buildapi.Build // build instance
 }
 
+// webHookTrigger describes a Git webhook trigger type, which is configured in the attribute that is
+// named like the provider
+type webHookTrigger struct {
+	// attribute is the name of the attribute of the trigger type
+	attribute string
+
+	// events returns the events of the attribute, and whether the attribute is set
+	events func(when buildapi.TriggerWhen) ([]string, bool)
+
+	// validEvents are the events that the provider supports
+	validEvents []string
+
+	// reason is used for the status of an invalid trigger
+	reason buildapi.BuildReason
+}
+
+// webHookTriggers are the Git webhook trigger types that are validated by validateWebHook
+var webHookTriggers = map[buildapi.TriggerType]webHookTrigger{
+	buildapi.GitLabWebHookTrigger: {
+		attribute: "gitlab",
+		events: func(when buildapi.TriggerWhen) ([]string, bool) {
+			if when.GitLab == nil {
+				return nil, false
+			}
+			return eventNames(when.GitLab.Events), true
+		},
+		validEvents: eventNames([]buildapi.GitLabEventName{buildapi.GitLabPushEvent, buildapi.GitLabMergeRequestEvent, buildapi.GitLabTagEvent}),
+		reason:      buildapi.TriggerInvalidGitLabWebHook,
+	},
+	buildapi.GiteaWebHookTrigger: {
+		attribute: "gitea",
+		events: func(when buildapi.TriggerWhen) ([]string, bool) {
+			if when.Gitea == nil {
+				return nil, false
+			}
+			return eventNames(when.Gitea.Events), true
+		},
+		validEvents: eventNames([]buildapi.GiteaEventName{buildapi.GiteaPushEvent, buildapi.GiteaPullRequestEvent, buildapi.GiteaTagEvent}),
+		reason:      buildapi.TriggerInvalidGiteaWebHook,
+	},
+	buildapi.BitbucketWebHookTrigger: {
+		attribute: "bitbucket",
+		events: func(when buildapi.TriggerWhen) ([]string, bool) {
+			if when.Bitbucket == nil {
+				return nil, false
+			}
+			return eventNames(when.Bitbucket.Events), true
+		},
+		validEvents: eventNames([]buildapi.BitbucketEventName{buildapi.BitbucketPushEvent, buildapi.BitbucketPullRequestEvent, buildapi.BitbucketTagEvent}),
+		reason:      buildapi.TriggerInvalidBitbucketWebHook,
+	},
+}
+
+// eventNames returns the events of a trigger as strings
+func eventNames[T ~string](events []T) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+	return names
+}
+
 // validate goes through the trigger "when" conditions to validate each entry.
 func (t *Trigger) validate(triggerWhen []buildapi.TriggerWhen) []error {
 	var allErrs []error
@@ -29,6 +96,18 @@ func (t *Trigger) validate(triggerWhen []buildapi.TriggerWhen) []error {
 			allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 		}
 
+		// the webhook requests are verified with the token of the trigger secret, without it the trigger never fires
+		switch when.Type {
+		case buildapi.GitHubWebHookTrigger, buildapi.GitLabWebHookTrigger, buildapi.GiteaWebHookTrigger, buildapi.BitbucketWebHookTrigger:
+			if ptr.Deref(t.build.Spec.Trigger.TriggerSecret, "") == "" {
+				t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerSecretMissing)
+				t.build.Status.Message = ptr.To(fmt.Sprintf(
+					"%q requires the attribute `.spec.trigger.triggerSecret`", when.Name,
+				))
+				allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+			}
+		}
+
 		switch when.Type {
 		case buildapi.GitHubWebHookTrigger:
 			if when.GitHub == nil {
@@ -46,6 +125,8 @@ func (t *Trigger) validate(triggerWhen []buildapi.TriggerWhen) []error {
 					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 				}
 			}
+		case buildapi.GitLabWebHookTrigger, buildapi.GiteaWebHookTrigger, buildapi.BitbucketWebHookTrigger:
+			allErrs = append(allErrs, t.validateWebHook(when, webHookTriggers[when.Type])...)
 		case buildapi.ImageTrigger:
 			if when.Image == nil {
 				t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidImage)
@@ -61,35 +142,48 @@ func (t *Trigger) validate(triggerWhen []buildapi.TriggerWhen) []error {
 					))
 					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 				}
+				for _, imageName := range when.Image.Names {
+					if _, err := imagename.ParseReference(imageName); err != nil {
+						t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidImage)
+						t.build.Status.Message = ptr.To(fmt.Sprintf(
+							"%q contains an invalid image name %q: %v", when.Name, imageName, err,
+						))
+						allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+					}
+				}
 			}
 		case buildapi.PipelineTrigger:
-			if when.ObjectRef == nil {
-				t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidPipeline)
+			allErrs = append(allErrs, t.validateObjectRef(when, buildapi.TriggerInvalidPipeline)...)
+		case buildapi.BuildRunTrigger:
+			allErrs = append(allErrs, t.validateObjectRef(when, buildapi.TriggerInvalidBuildRun)...)
+		case buildapi.ScheduleTrigger:
+			if when.Schedule == nil {
+				t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidSchedule)
 				t.build.Status.Message = ptr.To(fmt.Sprintf(
-					"%q is missing required attribute `.objectRef`", when.Name,
+					"%q is missing required attribute `.schedule`", when.Name,
 				))
 				allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 			} else {
-				if len(when.ObjectRef.Status) == 0 {
-					t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidPipeline)
+				if _, err := cron.ParseStandard(when.Schedule.Cron); err != nil {
+					t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidSchedule)
 					t.build.Status.Message = ptr.To(fmt.Sprintf(
-						"%q is missing required attribute `.objectRef.status`", when.Name,
+						"%q contains an invalid cron schedule %q: %v", when.Name, when.Schedule.Cron, err,
 					))
 					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 				}
-				if when.ObjectRef.Name == "" && len(when.ObjectRef.Selector) == 0 {
-					t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidPipeline)
-					t.build.Status.Message = ptr.To(fmt.Sprintf(
-						"%q is missing required attributes `.objectRef.name` or `.objectRef.selector`",
-						when.Name,
-					))
-					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+				if when.Schedule.TimeZone != nil {
+					if _, err := time.LoadLocation(*when.Schedule.TimeZone); err != nil || *when.Schedule.TimeZone == "" {
+						t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidSchedule)
+						t.build.Status.Message = ptr.To(fmt.Sprintf(
+							"%q contains an unknown time zone %q", when.Name, *when.Schedule.TimeZone,
+						))
+						allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+					}
 				}
-				if when.ObjectRef.Name != "" && len(when.ObjectRef.Selector) > 0 {
-					t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidPipeline)
+				if when.Schedule.StartingDeadlineSeconds != nil && *when.Schedule.StartingDeadlineSeconds < 0 {
+					t.build.Status.Reason = ptr.To[buildapi.BuildReason](buildapi.TriggerInvalidSchedule)
 					t.build.Status.Message = ptr.To(fmt.Sprintf(
-						"%q contains `.objectRef.name` and `.objectRef.selector`, must be only one",
-						when.Name,
+						"%q contains a negative `.schedule.startingDeadlineSeconds`", when.Name,
 					))
 					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 				}
@@ -104,6 +198,85 @@ func (t *Trigger) validate(triggerWhen []buildapi.TriggerWhen) []error {
 	return allErrs
 }
 
+// validateWebHook validates the attribute of the Git webhook trigger types that is named like the
+// provider, it must be set and list at least one of the valid events of the provider.
+func (t *Trigger) validateWebHook(when buildapi.TriggerWhen, webHook webHookTrigger) []error {
+	var allErrs []error
+	events, present := webHook.events(when)
+	if !present {
+		t.build.Status.Reason = ptr.To(webHook.reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(
+			"%q is missing required attribute `.%s`", when.Name, webHook.attribute,
+		))
+		return append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+	}
+
+	if len(events) == 0 {
+		t.build.Status.Reason = ptr.To(webHook.reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(
+			"%q is missing required attribute `.%s.events`", when.Name, webHook.attribute,
+		))
+		allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+	}
+	for _, event := range events {
+		if !slices.Contains(webHook.validEvents, event) {
+			t.build.Status.Reason = ptr.To(webHook.reason)
+			t.build.Status.Message = ptr.To(fmt.Sprintf(
+				"%q contains an invalid event %q, valid events are %s", when.Name, event, strings.Join(webHook.validEvents, ", "),
+			))
+			allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+		}
+	}
+
+	return allErrs
+}
+
+// validateObjectRef validates the `.objectRef` attribute of the trigger types that match objects
+// in the cluster, the reason is used for the status of an invalid trigger.
+func (t *Trigger) validateObjectRef(when buildapi.TriggerWhen, reason buildapi.BuildReason) []error {
+	var allErrs []error
+	if when.ObjectRef == nil {
+		t.build.Status.Reason = ptr.To(reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(
+			"%q is missing required attribute `.objectRef`", when.Name,
+		))
+		return append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+	}
+
+	if len(when.ObjectRef.Status) == 0 {
+		t.build.Status.Reason = ptr.To(reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(
+			"%q is missing required attribute `.objectRef.status`", when.Name,
+		))
+		allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+	}
+	if when.ObjectRef.Name == "" && len(when.ObjectRef.Selector) == 0 {
+		t.build.Status.Reason = ptr.To(reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(
+			"%q is missing required attributes `.objectRef.name` or `.objectRef.selector`",
+			when.Name,
+		))
+		allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+	}
+	if when.ObjectRef.Name != "" && len(when.ObjectRef.Selector) > 0 {
+		t.build.Status.Reason = ptr.To(reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(
+			"%q contains `.objectRef.name` and `.objectRef.selector`, must be only one",
+			when.Name,
+		))
+		allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+	}
+	if when.Type == buildapi.BuildRunTrigger && when.ObjectRef.Name != "" && when.ObjectRef.Name == t.build.Name {
+		t.build.Status.Reason = ptr.To(reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(
+			"%q references the BuildRuns of its own Build", when.Name,
+		))
+		allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+	}
+
+	return allErrs
+}
+
 // ValidatePath validates the `.spec.trigger` path.
 func (t *Trigger) ValidatePath(_ context.Context) error {
 	if t.build.Spec.Trigger == nil || len(t.build.Spec.Trigger.When) == 0 {
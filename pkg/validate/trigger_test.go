@@ -8,6 +8,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/validate"
@@ -67,10 +69,33 @@ var _ = Describe("ValidateBuildTriggers", func() {
 			Expect(err.Error()).To(ContainSubstring("missing required attribute `.github.events`"))
 		})
 
+		It("should error when the trigger secret is not set", func() {
+			b := &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name: "github",
+							Type: buildapi.GitHubWebHookTrigger,
+							GitHub: &buildapi.WhenGitHub{
+								Events: []buildapi.GitHubEventName{
+									buildapi.GitHubPushEvent,
+								},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("requires the attribute `.spec.trigger.triggerSecret`"))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerSecretMissing))
+		})
+
 		It("should pass when github type is complete", func() {
 			b := &buildapi.Build{
 				Spec: buildapi.BuildSpec{
 					Trigger: &buildapi.Trigger{
+						TriggerSecret: ptr.To("github-webhook"),
 						When: []buildapi.TriggerWhen{{
 							Name: "github",
 							Type: buildapi.GitHubWebHookTrigger,
@@ -125,6 +150,26 @@ var _ = Describe("ValidateBuildTriggers", func() {
 			Expect(err.Error()).To(ContainSubstring("missing required attribute `.image.names`"))
 		})
 
+		It("should error when an image name is invalid", func() {
+			b := &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name: "image",
+							Type: buildapi.ImageTrigger,
+							Image: &buildapi.WhenImage{
+								Names: []string{"ghcr.io/Invalid Image"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("contains an invalid image name"))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerInvalidImage))
+		})
+
 		It("should pass when github type is complete", func() {
 			b := &buildapi.Build{
 				Spec: buildapi.BuildSpec{
@@ -250,6 +295,262 @@ var _ = Describe("ValidateBuildTriggers", func() {
 		})
 	})
 
+	Context("trigger type buildrun", func() {
+		It("should error when objectRef attribute is not set", func() {
+			b := &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name: "buildrun",
+							Type: buildapi.BuildRunTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.objectRef`"))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerInvalidBuildRun))
+		})
+
+		It("should error when the trigger references its own Build", func() {
+			b := &buildapi.Build{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name: "buildrun",
+							Type: buildapi.BuildRunTrigger,
+							ObjectRef: &buildapi.WhenObjectRef{
+								Status: []string{"Succeeded"},
+								Name:   "app",
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("references the BuildRuns of its own Build"))
+		})
+
+		It("should pass when objectRef type is complete", func() {
+			b := &buildapi.Build{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name: "buildrun",
+							Type: buildapi.BuildRunTrigger,
+							ObjectRef: &buildapi.WhenObjectRef{
+								Status: []string{"Succeeded"},
+								Name:   "base-image",
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type schedule", func() {
+		newScheduleBuild := func(schedule *buildapi.WhenSchedule) *buildapi.Build {
+			return &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name:     "nightly",
+							Type:     buildapi.ScheduleTrigger,
+							Schedule: schedule,
+						}},
+					},
+				},
+			}
+		}
+
+		It("should error when schedule attribute is not set", func() {
+			b := newScheduleBuild(nil)
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.schedule`"))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerInvalidSchedule))
+		})
+
+		It("should error when the cron schedule is invalid", func() {
+			b := newScheduleBuild(&buildapi.WhenSchedule{Cron: "0 25 * * *"})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("contains an invalid cron schedule"))
+		})
+
+		It("should error when the time zone is unknown", func() {
+			b := newScheduleBuild(&buildapi.WhenSchedule{Cron: "0 2 * * *", TimeZone: ptr.To("Mars/Olympus_Mons")})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("contains an unknown time zone"))
+		})
+
+		It("should error when the starting deadline is negative", func() {
+			b := newScheduleBuild(&buildapi.WhenSchedule{Cron: "0 2 * * *", StartingDeadlineSeconds: ptr.To[int64](-1)})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("negative `.schedule.startingDeadlineSeconds`"))
+		})
+
+		It("should pass when schedule type is complete", func() {
+			b := newScheduleBuild(&buildapi.WhenSchedule{
+				Cron:                    "@daily",
+				TimeZone:                ptr.To("Europe/Berlin"),
+				StartingDeadlineSeconds: ptr.To[int64](3600),
+			})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type gitlab", func() {
+		newGitLabBuild := func(gitLab *buildapi.WhenGitLab) *buildapi.Build {
+			return &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						TriggerSecret: ptr.To("gitlab-webhook"),
+						When: []buildapi.TriggerWhen{{
+							Name:   "gitlab",
+							Type:   buildapi.GitLabWebHookTrigger,
+							GitLab: gitLab,
+						}},
+					},
+				},
+			}
+		}
+
+		It("should error when gitlab attribute is not set", func() {
+			b := newGitLabBuild(nil)
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitlab`"))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerInvalidGitLabWebHook))
+		})
+
+		It("should error when gitlab events attribute is empty", func() {
+			b := newGitLabBuild(&buildapi.WhenGitLab{})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitlab.events`"))
+		})
+
+		It("should error when a gitlab event is invalid", func() {
+			b := newGitLabBuild(&buildapi.WhenGitLab{Events: []buildapi.GitLabEventName{"PullRequest"}})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring(`contains an invalid event "PullRequest"`))
+		})
+
+		It("should error when the trigger secret is not set", func() {
+			b := newGitLabBuild(&buildapi.WhenGitLab{Events: []buildapi.GitLabEventName{buildapi.GitLabPushEvent}})
+			b.Spec.Trigger.TriggerSecret = nil
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("requires the attribute `.spec.trigger.triggerSecret`"))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerSecretMissing))
+		})
+
+		It("should pass when gitlab type is complete", func() {
+			b := newGitLabBuild(&buildapi.WhenGitLab{
+				Events:   []buildapi.GitLabEventName{buildapi.GitLabPushEvent, buildapi.GitLabMergeRequestEvent, buildapi.GitLabTagEvent},
+				Branches: []string{"main"},
+			})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type gitea", func() {
+		It("should error when gitea attribute is not set", func() {
+			b := &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name: "gitea",
+							Type: buildapi.GiteaWebHookTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitea`"))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerInvalidGiteaWebHook))
+		})
+
+		It("should pass when gitea type is complete", func() {
+			b := &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						TriggerSecret: ptr.To("gitea-webhook"),
+						When: []buildapi.TriggerWhen{{
+							Name: "gitea",
+							Type: buildapi.GiteaWebHookTrigger,
+							Gitea: &buildapi.WhenGitea{
+								Events: []buildapi.GiteaEventName{buildapi.GiteaPushEvent, buildapi.GiteaPullRequestEvent},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type bitbucket", func() {
+		It("should error when a bitbucket event is invalid", func() {
+			b := &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						When: []buildapi.TriggerWhen{{
+							Name: "bitbucket",
+							Type: buildapi.BitbucketWebHookTrigger,
+							Bitbucket: &buildapi.WhenBitbucket{
+								Events: []buildapi.BitbucketEventName{"MergeRequest"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring(`contains an invalid event "MergeRequest"`))
+			Expect(*b.Status.Reason).To(Equal(buildapi.TriggerInvalidBitbucketWebHook))
+		})
+
+		It("should pass when bitbucket type is complete", func() {
+			b := &buildapi.Build{
+				Spec: buildapi.BuildSpec{
+					Trigger: &buildapi.Trigger{
+						TriggerSecret: ptr.To("bitbucket-webhook"),
+						When: []buildapi.TriggerWhen{{
+							Name: "bitbucket",
+							Type: buildapi.BitbucketWebHookTrigger,
+							Bitbucket: &buildapi.WhenBitbucket{
+								Events: []buildapi.BitbucketEventName{buildapi.BitbucketPushEvent, buildapi.BitbucketTagEvent},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
 	Context("invalid trigger type", func() {
 		It("should error when declaring a invalid trigger type", func() {
 			b := &buildapi.Build{
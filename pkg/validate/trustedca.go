@@ -0,0 +1,52 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// TrustedCARef contains all required fields
+// to validate the trusted certificate authorities of a Build
+type TrustedCARef struct {
+	Build  *buildapi.Build
+	Client client.Client
+}
+
+// ValidatePath implements BuildPath interface and validates that the
+// ConfigMap with the trusted certificate authorities exists and contains the key
+func (t *TrustedCARef) ValidatePath(ctx context.Context) error {
+	trustedCA := t.Build.Spec.TrustedCA
+	if trustedCA == nil {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: trustedCA.ConfigMap, Namespace: t.Build.Namespace}, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		t.Build.Status.Reason = ptr.To(buildapi.SpecTrustedCAConfigMapNotFound)
+		t.Build.Status.Message = ptr.To(fmt.Sprintf("referenced ConfigMap %s with the trusted certificate authorities not found", trustedCA.ConfigMap))
+		return nil
+	}
+
+	if _, found := configMap.Data[trustedCA.GetKey()]; !found {
+		t.Build.Status.Reason = ptr.To(buildapi.SpecTrustedCAConfigMapNotFound)
+		t.Build.Status.Message = ptr.To(fmt.Sprintf("referenced ConfigMap %s does not contain the key %s with the trusted certificate authorities", trustedCA.ConfigMap, trustedCA.GetKey()))
+	}
+
+	return nil
+}
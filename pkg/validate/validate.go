@@ -44,6 +44,8 @@ const (
 	SchedulerName = "schedulername"
 	// RuntimeClassName for validating `spec.runtimeClassName` entry
 	RuntimeClassName = "runtimeclassname"
+	// TrustedCA for validating the `spec.trustedCA` ConfigMap reference
+	TrustedCA = "trustedca"
 )
 
 const (
@@ -92,6 +94,8 @@ func NewValidation(
 		return &SchedulerNameRef{Build: build}, nil
 	case RuntimeClassName:
 		return &RuntimeClassNameRef{Build: build}, nil
+	case TrustedCA:
+		return &TrustedCARef{Build: build, Client: client}, nil
 	default:
 		return nil, fmt.Errorf("unknown validation type")
 	}
@@ -142,6 +146,11 @@ func BuildRunFields(buildRun *buildapi.BuildRun) (string, string) {
 				"cannot use 'timeout' override and 'buildSpec' simultaneously"
 		}
 
+		if buildRun.Spec.Timeouts != nil {
+			return resources.BuildRunBuildFieldOverrideForbidden,
+				"cannot use 'timeouts' override and 'buildSpec' simultaneously"
+		}
+
 		if buildRun.Spec.Build.Spec.Trigger != nil {
 			return resources.BuildRunBuildFieldOverrideForbidden,
 				"cannot use 'triggers' override in the 'BuildRun', only allowed in the 'Build'"
@@ -171,6 +180,22 @@ func BuildRunFields(buildRun *buildapi.BuildRun) (string, string) {
 			return resources.BuildRunBuildFieldOverrideForbidden,
 				"cannot use 'stepResources' override and 'buildSpec' simultaneously"
 		}
+
+		if buildRun.Spec.Source != nil && buildRun.Spec.Source.Git != nil {
+			return resources.BuildRunBuildFieldOverrideForbidden,
+				"cannot use 'source.git' override and 'buildSpec' simultaneously"
+		}
+
+		if buildRun.Spec.Retry != nil {
+			return resources.BuildRunBuildFieldOverrideForbidden,
+				"cannot use 'retry' override and 'buildSpec' simultaneously"
+		}
+	}
+
+	if buildRun.Spec.Source != nil && buildRun.Spec.Source.Git != nil {
+		if err := validatePullRequest(buildRun.Spec.Source.Git.PullRequest); err != nil {
+			return resources.BuildRunPullRequestInvalid, err.Error()
+		}
 	}
 
 	for _, envVar := range buildRun.Spec.Env {
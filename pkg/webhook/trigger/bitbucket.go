@@ -0,0 +1,145 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const (
+	bitbucketEventHeader     = "X-Event-Key"
+	bitbucketDeliveryHeader  = "X-Request-UUID"
+	bitbucketSignatureHeader = "X-Hub-Signature"
+)
+
+// bitbucketRepository is the repository of a Bitbucket Cloud webhook payload
+type bitbucketRepository struct {
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// bitbucketPushPayload is the payload of a Bitbucket Cloud repo:push event, one push can
+// change several branches and tags
+type bitbucketPushPayload struct {
+	Push struct {
+		Changes []struct {
+			New *struct {
+				Type   string `json:"type"`
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+	Repository bitbucketRepository `json:"repository"`
+}
+
+// bitbucketPullRequestPayload is the payload of a Bitbucket Cloud pullrequest event
+type bitbucketPullRequestPayload struct {
+	PullRequest struct {
+		Source struct {
+			Commit struct {
+				Hash string `json:"hash"`
+			} `json:"commit"`
+		} `json:"source"`
+		Destination struct {
+			Branch struct {
+				Name string `json:"name"`
+			} `json:"branch"`
+		} `json:"destination"`
+	} `json:"pullrequest"`
+	Repository bitbucketRepository `json:"repository"`
+}
+
+// bitbucket is the provider for Bitbucket Cloud webhook requests
+type bitbucket struct{}
+
+// BitbucketHandler is a handle func for the Bitbucket webhook endpoint, it creates BuildRuns for the
+// Builds with a Bitbucket trigger that matches the push or pull request event of the request
+func BitbucketHandler(ctx context.Context, c client.Client) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serve(ctx, c, w, r, bitbucket{})
+	}
+}
+
+func (bitbucket) triggerType() buildapi.TriggerType {
+	return buildapi.BitbucketWebHookTrigger
+}
+
+func (bitbucket) parse(header http.Header, payload []byte) ([]event, error) {
+	switch header.Get(bitbucketEventHeader) {
+	case "repo:push":
+		var push bitbucketPushPayload
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, err
+		}
+
+		var events []event
+		for _, change := range push.Push.Changes {
+			// deleted branches and tags are not built
+			if change.New == nil {
+				continue
+			}
+
+			e := event{
+				repositoryURLs: []string{push.Repository.Links.HTML.Href},
+				sha:            change.New.Target.Hash,
+				delivery:       header.Get(bitbucketDeliveryHeader),
+			}
+
+			switch change.New.Type {
+			case "branch":
+				e.kind, e.branch = string(buildapi.BitbucketPushEvent), change.New.Name
+			case "tag", "annotated_tag":
+				e.kind, e.tag = string(buildapi.BitbucketTagEvent), change.New.Name
+			default:
+				continue
+			}
+
+			events = append(events, e)
+		}
+
+		return events, nil
+
+	case "pullrequest:created", "pullrequest:updated":
+		var pullRequest bitbucketPullRequestPayload
+		if err := json.Unmarshal(payload, &pullRequest); err != nil {
+			return nil, err
+		}
+
+		return []event{{
+			kind:           string(buildapi.BitbucketPullRequestEvent),
+			repositoryURLs: []string{pullRequest.Repository.Links.HTML.Href},
+			branch:         pullRequest.PullRequest.Destination.Branch.Name,
+			sha:            pullRequest.PullRequest.Source.Commit.Hash,
+			delivery:       header.Get(bitbucketDeliveryHeader),
+		}}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// verify checks the HMAC hex digest of the payload that Bitbucket sends in the X-Hub-Signature header
+func (bitbucket) verify(header http.Header, payload []byte, token []byte) bool {
+	signature, found := strings.CutPrefix(header.Get(bitbucketSignatureHeader), "sha256=")
+	return found && validHMAC(payload, token, signature)
+}
+
+func (bitbucket) matches(when buildapi.TriggerWhen, e *event) bool {
+	return when.Bitbucket != nil && slices.Contains(when.Bitbucket.Events, buildapi.BitbucketEventName(e.kind))
+}
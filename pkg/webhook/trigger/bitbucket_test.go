@@ -0,0 +1,84 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/webhook/trigger"
+)
+
+var _ = Describe("Bitbucket webhook", func() {
+	var (
+		client *fakes.FakeClient
+		server *httptest.Server
+		build  *buildapi.Build
+	)
+
+	// postBitbucket sends a recorded payload to the webhook, signed with the token
+	postBitbucket := func(event string, payloadFile string, token string) *http.Response {
+		payload := readPayload("bitbucket", payloadFile)
+		return post(server, map[string]string{
+			"X-Event-Key":     event,
+			"X-Request-UUID":  "3c4d5e6f-7a8b-4c9d-a0e1-f2a3b4c5d6e7",
+			"X-Hub-Signature": "sha256=" + signature(payload, token),
+		}, payload)
+	}
+
+	BeforeEach(func() {
+		build = newBuild("https://bitbucket.org/shipwright/sample-go.git", buildapi.TriggerWhen{
+			Name: "bitbucket",
+			Type: buildapi.BitbucketWebHookTrigger,
+			Bitbucket: &buildapi.WhenBitbucket{
+				Events:   []buildapi.BitbucketEventName{buildapi.BitbucketPushEvent, buildapi.BitbucketPullRequestEvent},
+				Branches: []string{"main"},
+			},
+		})
+
+		client = newFakeClient(build)
+		server = httptest.NewServer(http.HandlerFunc(trigger.BitbucketHandler(context.TODO(), client)))
+		DeferCleanup(server.Close)
+	})
+
+	It("creates a BuildRun for the pushed branch, but not for the tag and the deleted branch", func() {
+		response := postBitbucket("repo:push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To("5e3a4b2c1d0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d")))
+	})
+
+	It("creates a BuildRun for a pushed tag", func() {
+		build.Spec.Trigger.When[0].Bitbucket.Events = []buildapi.BitbucketEventName{buildapi.BitbucketTagEvent}
+
+		response := postBitbucket("repo:push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(createdBuildRuns(client)).To(HaveLen(1))
+	})
+
+	It("creates a BuildRun for the source commit of a pull request", func() {
+		response := postBitbucket("pullrequest:updated", "pull_request.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To("c4d5e6f7a8b9")))
+	})
+
+	It("rejects a request with an invalid signature", func() {
+		response := postBitbucket("repo:push", "push.json", "another-token")
+		Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+})
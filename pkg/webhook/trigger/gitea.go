@@ -0,0 +1,102 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const (
+	giteaEventHeader     = "X-Gitea-Event"
+	giteaDeliveryHeader  = "X-Gitea-Delivery"
+	giteaSignatureHeader = "X-Gitea-Signature"
+)
+
+// giteaPullRequestActions are the actions of pull_request events that change the code of a pull request
+var giteaPullRequestActions = []string{"opened", "reopened", "synchronized"}
+
+// gitea is the provider for Gitea webhook requests, Gitea sends its payloads in the format of GitHub
+type gitea struct{}
+
+// GiteaHandler is a handle func for the Gitea webhook endpoint, it creates BuildRuns for the
+// Builds with a Gitea trigger that matches the push, tag push or pull request event of the request
+func GiteaHandler(ctx context.Context, c client.Client) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serve(ctx, c, w, r, gitea{})
+	}
+}
+
+func (gitea) triggerType() buildapi.TriggerType {
+	return buildapi.GiteaWebHookTrigger
+}
+
+func (gitea) parse(header http.Header, payload []byte) ([]event, error) {
+	switch header.Get(giteaEventHeader) {
+	case "push":
+		var push gitHubPushPayload
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, err
+		}
+
+		// deleted branches and tags are not built
+		if isZeroSHA(push.After) {
+			return nil, nil
+		}
+
+		e := event{
+			repositoryURLs: push.Repository.urls(),
+			sha:            push.After,
+			delivery:       header.Get(giteaDeliveryHeader),
+		}
+
+		if tag, isTag := strings.CutPrefix(push.Ref, "refs/tags/"); isTag {
+			e.kind, e.tag = string(buildapi.GiteaTagEvent), tag
+		} else if branch, isBranch := strings.CutPrefix(push.Ref, "refs/heads/"); isBranch {
+			e.kind, e.branch = string(buildapi.GiteaPushEvent), branch
+		} else {
+			return nil, nil
+		}
+
+		return []event{e}, nil
+
+	case "pull_request":
+		var pullRequest gitHubPullRequestPayload
+		if err := json.Unmarshal(payload, &pullRequest); err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(giteaPullRequestActions, pullRequest.Action) {
+			return nil, nil
+		}
+
+		return []event{{
+			kind:           string(buildapi.GiteaPullRequestEvent),
+			repositoryURLs: pullRequest.Repository.urls(),
+			branch:         pullRequest.PullRequest.Base.Ref,
+			sha:            pullRequest.PullRequest.Head.SHA,
+			delivery:       header.Get(giteaDeliveryHeader),
+		}}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// verify checks the HMAC hex digest of the payload that Gitea sends in the X-Gitea-Signature header
+func (gitea) verify(header http.Header, payload []byte, token []byte) bool {
+	return validHMAC(payload, token, header.Get(giteaSignatureHeader))
+}
+
+func (gitea) matches(when buildapi.TriggerWhen, e *event) bool {
+	return when.Gitea != nil && slices.Contains(when.Gitea.Events, buildapi.GiteaEventName(e.kind))
+}
@@ -0,0 +1,75 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/webhook/trigger"
+)
+
+var _ = Describe("Gitea webhook", func() {
+	var (
+		client *fakes.FakeClient
+		server *httptest.Server
+		build  *buildapi.Build
+	)
+
+	// postGitea sends a recorded payload to the webhook, signed with the token
+	postGitea := func(event string, payloadFile string, token string) *http.Response {
+		payload := readPayload("gitea", payloadFile)
+		return post(server, map[string]string{
+			"X-Gitea-Event":     event,
+			"X-Gitea-Delivery":  "0f4e5d6c-7b8a-4c9d-8e1f-2a3b4c5d6e7f",
+			"X-Gitea-Signature": signature(payload, token),
+		}, payload)
+	}
+
+	BeforeEach(func() {
+		build = newBuild("git@gitea.example.com:shipwright/sample-go.git", buildapi.TriggerWhen{
+			Name: "gitea",
+			Type: buildapi.GiteaWebHookTrigger,
+			Gitea: &buildapi.WhenGitea{
+				Events: []buildapi.GiteaEventName{buildapi.GiteaPushEvent, buildapi.GiteaPullRequestEvent},
+			},
+		})
+
+		client = newFakeClient(build)
+		server = httptest.NewServer(http.HandlerFunc(trigger.GiteaHandler(context.TODO(), client)))
+		DeferCleanup(server.Close)
+	})
+
+	It("creates a BuildRun pinned to the commit of a push", func() {
+		response := postGitea("push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To("4c2f7a8e9d0b1c3e5f6a7b8c9d0e1f2a3b4c5d6e")))
+	})
+
+	It("creates a BuildRun for the head commit of a pull request", func() {
+		response := postGitea("pull_request", "pull_request.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To("8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c")))
+	})
+
+	It("rejects a request with an invalid signature", func() {
+		response := postGitea("push", "push.json", "another-token")
+		Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+})
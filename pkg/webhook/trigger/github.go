@@ -0,0 +1,130 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const (
+	gitHubEventHeader     = "X-GitHub-Event"
+	gitHubDeliveryHeader  = "X-GitHub-Delivery"
+	gitHubSignatureHeader = "X-Hub-Signature-256"
+)
+
+// gitHubRepository is the repository of a GitHub webhook payload
+type gitHubRepository struct {
+	HTMLURL  string `json:"html_url"`
+	CloneURL string `json:"clone_url"`
+	SSHURL   string `json:"ssh_url"`
+}
+
+// gitHubPushPayload is the payload of a GitHub push event
+type gitHubPushPayload struct {
+	Ref        string           `json:"ref"`
+	After      string           `json:"after"`
+	Deleted    bool             `json:"deleted"`
+	Repository gitHubRepository `json:"repository"`
+}
+
+// gitHubPullRequestPayload is the payload of a GitHub pull_request event
+type gitHubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository gitHubRepository `json:"repository"`
+}
+
+// gitHubPullRequestActions are the actions of pull_request events that change the code of a pull request
+var gitHubPullRequestActions = []string{"opened", "reopened", "synchronize"}
+
+// gitHub is the provider for GitHub webhook requests
+type gitHub struct{}
+
+// GitHubHandler is a handle func for the GitHub webhook endpoint, it creates BuildRuns for the
+// Builds with a GitHub trigger that matches the push or pull request event of the request
+func GitHubHandler(ctx context.Context, c client.Client) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serve(ctx, c, w, r, gitHub{})
+	}
+}
+
+func (gitHub) triggerType() buildapi.TriggerType {
+	return buildapi.GitHubWebHookTrigger
+}
+
+func (gitHub) parse(header http.Header, payload []byte) ([]event, error) {
+	switch header.Get(gitHubEventHeader) {
+	case "push":
+		var push gitHubPushPayload
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, err
+		}
+
+		// tags and deleted branches are not built
+		branch, isBranch := strings.CutPrefix(push.Ref, "refs/heads/")
+		if !isBranch || push.Deleted {
+			return nil, nil
+		}
+
+		return []event{{
+			kind:           string(buildapi.GitHubPushEvent),
+			repositoryURLs: push.Repository.urls(),
+			branch:         branch,
+			sha:            push.After,
+			delivery:       header.Get(gitHubDeliveryHeader),
+		}}, nil
+
+	case "pull_request":
+		var pullRequest gitHubPullRequestPayload
+		if err := json.Unmarshal(payload, &pullRequest); err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(gitHubPullRequestActions, pullRequest.Action) {
+			return nil, nil
+		}
+
+		return []event{{
+			kind:           string(buildapi.GitHubPullRequestEvent),
+			repositoryURLs: pullRequest.Repository.urls(),
+			branch:         pullRequest.PullRequest.Base.Ref,
+			sha:            pullRequest.PullRequest.Head.SHA,
+			delivery:       header.Get(gitHubDeliveryHeader),
+		}}, nil
+
+	default:
+		// other events, for example the ping event of a new webhook, do not trigger Builds
+		return nil, nil
+	}
+}
+
+// verify checks the HMAC hex digest of the payload that GitHub sends in the X-Hub-Signature-256 header
+func (gitHub) verify(header http.Header, payload []byte, token []byte) bool {
+	signature, found := strings.CutPrefix(header.Get(gitHubSignatureHeader), "sha256=")
+	return found && validHMAC(payload, token, signature)
+}
+
+func (gitHub) matches(when buildapi.TriggerWhen, e *event) bool {
+	return when.GitHub != nil && slices.Contains(when.GitHub.Events, buildapi.GitHubEventName(e.kind))
+}
+
+func (r gitHubRepository) urls() []string {
+	return []string{r.HTMLURL, r.CloneURL, r.SSHURL}
+}
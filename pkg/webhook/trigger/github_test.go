@@ -0,0 +1,178 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/webhook/trigger"
+)
+
+var _ = Describe("GitHub webhook", func() {
+	const (
+		pushSHA        = "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"
+		pullRequestSHA = "9f8e7d6c5b4a39281706f5e4d3c2b1a098765432"
+	)
+
+	var (
+		client *fakes.FakeClient
+		server *httptest.Server
+		build  *buildapi.Build
+	)
+
+	// postGitHub sends a recorded payload to the webhook, signed with the token
+	postGitHub := func(event string, payloadFile string, token string) *http.Response {
+		payload := readPayload("github", payloadFile)
+		return post(server, map[string]string{
+			"X-GitHub-Event":      event,
+			"X-GitHub-Delivery":   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+			"X-Hub-Signature-256": "sha256=" + signature(payload, token),
+		}, payload)
+	}
+
+	BeforeEach(func() {
+		build = newBuild("https://github.com/shipwright-io/sample-go", buildapi.TriggerWhen{
+			Name: "push and pull-request on the main branch",
+			Type: buildapi.GitHubWebHookTrigger,
+			GitHub: &buildapi.WhenGitHub{
+				Events:   []buildapi.GitHubEventName{buildapi.GitHubPushEvent, buildapi.GitHubPullRequestEvent},
+				Branches: []string{"main"},
+			},
+		})
+
+		client = newFakeClient(build)
+		server = httptest.NewServer(http.HandlerFunc(trigger.GitHubHandler(context.TODO(), client)))
+		DeferCleanup(server.Close)
+	})
+
+	It("creates a BuildRun pinned to the commit of a push", func() {
+		response := postGitHub("push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		buildRun := buildRuns[0]
+		Expect(buildRun.Name).To(MatchRegexp("^sample-go-[0-9a-f]{10}$"))
+		Expect(buildRun.Namespace).To(Equal("build-examples"))
+		Expect(buildRun.Spec.Build.Name).To(Equal(ptr.To("sample-go")))
+		Expect(buildRun.Spec.Source).ToNot(BeNil())
+		Expect(buildRun.Spec.Source.Type).To(Equal(buildapi.GitType))
+		Expect(buildRun.Spec.Source.Git.Revision).To(Equal(ptr.To(pushSHA)))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTrigger, "push and pull-request on the main branch"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTriggerDelivery, "72d3162e-cc78-11e3-81ab-4c9367dc0958"))
+
+		var body struct {
+			BuildRuns []string `json:"buildRuns"`
+		}
+		Expect(json.NewDecoder(response.Body).Decode(&body)).To(Succeed())
+		Expect(body.BuildRuns).To(Equal([]string{"build-examples/" + buildRun.Name}))
+	})
+
+	It("creates a BuildRun for the head commit of a pull request", func() {
+		response := postGitHub("pull_request", "pull_request.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To(pullRequestSHA)))
+	})
+
+	It("creates a BuildRun for each event of the same commit", func() {
+		Expect(postGitHub("push", "push.json", webhookToken).StatusCode).To(Equal(http.StatusOK))
+
+		payload := bytes.ReplaceAll(readPayload("github", "pull_request.json"), []byte(pullRequestSHA), []byte(pushSHA))
+		response := post(server, map[string]string{
+			"X-GitHub-Event":      "pull_request",
+			"X-GitHub-Delivery":   "8a4e7f2c-cc78-11e3-81ab-4c9367dc0958",
+			"X-Hub-Signature-256": "sha256=" + signature(payload, webhookToken),
+		}, payload)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(2))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(buildRuns[1].Spec.Source.Git.Revision))
+		Expect(buildRuns[0].Name).ToNot(Equal(buildRuns[1].Name))
+	})
+
+	It("matches the Git URL in a different notation", func() {
+		build.Spec.Source.Git.URL = "git@github.com:shipwright-io/Sample-Go.git"
+
+		response := postGitHub("push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(client.CreateCallCount()).To(Equal(1))
+	})
+
+	It("rejects a request with an invalid signature", func() {
+		response := postGitHub("push", "push.json", "another-token")
+		Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("rejects a request for a Build without a trigger secret", func() {
+		build.Spec.Trigger.TriggerSecret = nil
+
+		response := postGitHub("push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("ignores events for other branches", func() {
+		build.Spec.Trigger.When[0].GitHub.Branches = []string{"release-v1"}
+
+		response := postGitHub("push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("matches the revision of the Build when the trigger has no branches", func() {
+		build.Spec.Trigger.When[0].GitHub.Branches = nil
+		build.Spec.Source.Git.Revision = ptr.To("main")
+
+		response := postGitHub("push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(client.CreateCallCount()).To(Equal(1))
+	})
+
+	It("ignores event types that the trigger does not list", func() {
+		build.Spec.Trigger.When[0].GitHub.Events = []buildapi.GitHubEventName{buildapi.GitHubPushEvent}
+
+		response := postGitHub("pull_request", "pull_request.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("ignores Builds of other repositories", func() {
+		build.Spec.Source.Git.URL = "https://github.com/shipwright-io/sample-nodejs"
+
+		response := postGitHub("push", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("accepts the ping event of a new webhook", func() {
+		response := postGitHub("ping", "ping.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(client.ListCallCount()).To(Equal(0))
+	})
+
+	It("only accepts POST requests", func() {
+		response, err := server.Client().Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		defer response.Body.Close()
+		_, _ = io.Copy(io.Discard, response.Body)
+		Expect(response.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
@@ -0,0 +1,140 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const (
+	gitLabEventHeader    = "X-Gitlab-Event"
+	gitLabDeliveryHeader = "X-Gitlab-Event-UUID"
+	gitLabTokenHeader    = "X-Gitlab-Token"
+)
+
+// gitLabProject is the project of a GitLab webhook payload
+type gitLabProject struct {
+	WebURL     string `json:"web_url"`
+	GitHTTPURL string `json:"git_http_url"`
+	GitSSHURL  string `json:"git_ssh_url"`
+}
+
+// gitLabPushPayload is the payload of a GitLab push or tag push event
+type gitLabPushPayload struct {
+	Ref         string        `json:"ref"`
+	After       string        `json:"after"`
+	CheckoutSHA string        `json:"checkout_sha"`
+	Project     gitLabProject `json:"project"`
+}
+
+// gitLabMergeRequestPayload is the payload of a GitLab merge request event
+type gitLabMergeRequestPayload struct {
+	ObjectAttributes struct {
+		Action       string `json:"action"`
+		OldRev       string `json:"oldrev"`
+		TargetBranch string `json:"target_branch"`
+		LastCommit   struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+	Project gitLabProject `json:"project"`
+}
+
+// gitLab is the provider for GitLab webhook requests
+type gitLab struct{}
+
+// GitLabHandler is a handle func for the GitLab webhook endpoint, it creates BuildRuns for the
+// Builds with a GitLab trigger that matches the push, tag push or merge request event of the request
+func GitLabHandler(ctx context.Context, c client.Client) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serve(ctx, c, w, r, gitLab{})
+	}
+}
+
+func (gitLab) triggerType() buildapi.TriggerType {
+	return buildapi.GitLabWebHookTrigger
+}
+
+func (gitLab) parse(header http.Header, payload []byte) ([]event, error) {
+	switch header.Get(gitLabEventHeader) {
+	case "Push Hook", "Tag Push Hook":
+		var push gitLabPushPayload
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, err
+		}
+
+		// deleted branches and tags are not built
+		if isZeroSHA(push.After) {
+			return nil, nil
+		}
+
+		// the checkout SHA is the commit of an annotated tag, while after is the tag object
+		sha := push.CheckoutSHA
+		if sha == "" {
+			sha = push.After
+		}
+
+		e := event{
+			repositoryURLs: push.Project.urls(),
+			sha:            sha,
+			delivery:       header.Get(gitLabDeliveryHeader),
+		}
+
+		if tag, isTag := strings.CutPrefix(push.Ref, "refs/tags/"); isTag {
+			e.kind, e.tag = string(buildapi.GitLabTagEvent), tag
+		} else if branch, isBranch := strings.CutPrefix(push.Ref, "refs/heads/"); isBranch {
+			e.kind, e.branch = string(buildapi.GitLabPushEvent), branch
+		} else {
+			return nil, nil
+		}
+
+		return []event{e}, nil
+
+	case "Merge Request Hook":
+		var mergeRequest gitLabMergeRequestPayload
+		if err := json.Unmarshal(payload, &mergeRequest); err != nil {
+			return nil, err
+		}
+
+		// only merge requests that are opened, or updated with new commits, are built
+		attributes := mergeRequest.ObjectAttributes
+		if attributes.Action != "open" && attributes.Action != "reopen" && (attributes.Action != "update" || attributes.OldRev == "") {
+			return nil, nil
+		}
+
+		return []event{{
+			kind:           string(buildapi.GitLabMergeRequestEvent),
+			repositoryURLs: mergeRequest.Project.urls(),
+			branch:         attributes.TargetBranch,
+			sha:            attributes.LastCommit.ID,
+			delivery:       header.Get(gitLabDeliveryHeader),
+		}}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// verify compares the secret token that GitLab sends in the X-Gitlab-Token header
+func (gitLab) verify(header http.Header, _ []byte, token []byte) bool {
+	return subtle.ConstantTimeCompare([]byte(header.Get(gitLabTokenHeader)), token) == 1
+}
+
+func (gitLab) matches(when buildapi.TriggerWhen, e *event) bool {
+	return when.GitLab != nil && slices.Contains(when.GitLab.Events, buildapi.GitLabEventName(e.kind))
+}
+
+func (p gitLabProject) urls() []string {
+	return []string{p.WebURL, p.GitHTTPURL, p.GitSSHURL}
+}
@@ -0,0 +1,103 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/webhook/trigger"
+)
+
+var _ = Describe("GitLab webhook", func() {
+	var (
+		client *fakes.FakeClient
+		server *httptest.Server
+		build  *buildapi.Build
+	)
+
+	// postGitLab sends a recorded payload to the webhook with the token
+	postGitLab := func(event string, payloadFile string, token string) *http.Response {
+		return post(server, map[string]string{
+			"X-Gitlab-Event":      event,
+			"X-Gitlab-Event-UUID": "6a9b4f2e-1b2c-4d5e-8f90-a1b2c3d4e5f6",
+			"X-Gitlab-Token":      token,
+		}, readPayload("gitlab", payloadFile))
+	}
+
+	BeforeEach(func() {
+		build = newBuild("https://gitlab.example.com/shipwright/sample-go.git", buildapi.TriggerWhen{
+			Name: "gitlab",
+			Type: buildapi.GitLabWebHookTrigger,
+			GitLab: &buildapi.WhenGitLab{
+				Events:   []buildapi.GitLabEventName{buildapi.GitLabPushEvent, buildapi.GitLabMergeRequestEvent, buildapi.GitLabTagEvent},
+				Branches: []string{"main"},
+			},
+		})
+
+		client = newFakeClient(build)
+		server = httptest.NewServer(http.HandlerFunc(trigger.GitLabHandler(context.TODO(), client)))
+		DeferCleanup(server.Close)
+	})
+
+	It("creates a BuildRun pinned to the commit of a push", func() {
+		response := postGitLab("Push Hook", "push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To("da1560886d4f094c3e6c9ef40349f7d38b5d27d7")))
+		Expect(buildRuns[0].Annotations).To(HaveKeyWithValue(buildapi.AnnotationBuildRunTriggerDelivery, "6a9b4f2e-1b2c-4d5e-8f90-a1b2c3d4e5f6"))
+	})
+
+	It("creates a BuildRun for the last commit of a merge request", func() {
+		response := postGitLab("Merge Request Hook", "merge_request.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To("7e6f1f4b1d5c38a1e6d3f2c0b9a8e7d6c5b4a392")))
+	})
+
+	It("creates a BuildRun for the tagged commit regardless of the branches", func() {
+		response := postGitLab("Tag Push Hook", "tag_push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(ptr.To("da1560886d4f094c3e6c9ef40349f7d38b5d27d7")))
+	})
+
+	It("creates a BuildRun for the tag of a commit whose push was built", func() {
+		Expect(postGitLab("Push Hook", "push.json", webhookToken).StatusCode).To(Equal(http.StatusOK))
+		Expect(postGitLab("Tag Push Hook", "tag_push.json", webhookToken).StatusCode).To(Equal(http.StatusOK))
+
+		buildRuns := createdBuildRuns(client)
+		Expect(buildRuns).To(HaveLen(2))
+		Expect(buildRuns[0].Spec.Source.Git.Revision).To(Equal(buildRuns[1].Spec.Source.Git.Revision))
+		Expect(buildRuns[0].Name).ToNot(Equal(buildRuns[1].Name))
+	})
+
+	It("ignores tags when the trigger does not list the tag event", func() {
+		build.Spec.Trigger.When[0].GitLab.Events = []buildapi.GitLabEventName{buildapi.GitLabPushEvent}
+
+		response := postGitLab("Tag Push Hook", "tag_push.json", webhookToken)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+
+	It("rejects a request with an invalid token", func() {
+		response := postGitLab("Push Hook", "push.json", "another-token")
+		Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(client.CreateCallCount()).To(Equal(0))
+	})
+})
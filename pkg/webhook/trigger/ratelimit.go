@@ -0,0 +1,33 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimited wraps the handle func of a webhook endpoint, so that requests over the rate of the
+// limiter are rejected before they are parsed. Every request that is handled looks up the Builds
+// of the cluster and their trigger secrets, the endpoints are reachable without authentication.
+func RateLimited(limiter *rate.Limiter, handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.Allow() {
+			retryAfter := 1
+			if limit := float64(limiter.Limit()); limit > 0 && limit < 1 {
+				retryAfter = int(math.Ceil(1 / limit))
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, "too many webhook requests", http.StatusTooManyRequests)
+			return
+		}
+
+		handler(w, r)
+	}
+}
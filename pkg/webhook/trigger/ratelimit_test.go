@@ -0,0 +1,38 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/time/rate"
+
+	"github.com/shipwright-io/build/pkg/webhook/trigger"
+)
+
+var _ = Describe("Rate limit", func() {
+	It("rejects requests over the rate of the limiter", func() {
+		handled := 0
+		handler := trigger.RateLimited(rate.NewLimiter(rate.Limit(0.5), 2), func(w http.ResponseWriter, _ *http.Request) {
+			handled++
+			w.WriteHeader(http.StatusOK)
+		})
+
+		var statusCodes []int
+		var recorder *httptest.ResponseRecorder
+		for range 3 {
+			recorder = httptest.NewRecorder()
+			handler(recorder, httptest.NewRequest(http.MethodPost, "/triggers/github", http.NoBody))
+			statusCodes = append(statusCodes, recorder.Code)
+		}
+
+		Expect(statusCodes).To(Equal([]int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("2"))
+		Expect(handled).To(Equal(2))
+	})
+})
@@ -0,0 +1,314 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+const (
+	// SecretTokenKey is the key in the trigger secret of a Build that holds the secret token
+	// that webhook requests are verified with
+	SecretTokenKey = "token"
+
+	// defaultBranch is the branch that is matched for triggers without branches, when the Build
+	// does not define a Git revision
+	defaultBranch = "main"
+
+	// maxPayloadSize is the maximum size of a webhook payload, GitHub caps payloads at 25 MB
+	maxPayloadSize = 25 << 20
+
+	namespace = "namespace"
+	name      = "name"
+)
+
+// event is a Git event of a webhook request, reduced to the details that are needed to trigger Builds
+type event struct {
+	// kind is the name of the event in terms of the trigger, for example Push
+	kind string
+
+	// repositoryURLs are the URLs under which the repository of the event is known
+	repositoryURLs []string
+
+	// branch is the branch that was pushed to, or the target branch of a pull or merge request
+	branch string
+
+	// tag is the tag that was pushed, the branches of a trigger do not apply to tag events
+	tag string
+
+	// sha is the commit to build
+	sha string
+
+	// delivery is the identifier of the webhook delivery
+	delivery string
+}
+
+// provider parses and verifies the webhook requests of a Git hosting service
+type provider interface {
+	// triggerType returns the type of the triggers that react on the events of the provider
+	triggerType() buildapi.TriggerType
+
+	// parse returns the events of a webhook request, which are none if the request does not
+	// contain an event that triggers Builds
+	parse(header http.Header, payload []byte) ([]event, error)
+
+	// verify returns true if the webhook request was sent with the secret token
+	verify(header http.Header, payload []byte, token []byte) bool
+
+	// matches returns true if a trigger of the provider type reacts on the kind of the event
+	matches(when buildapi.TriggerWhen, e *event) bool
+}
+
+// response is the body of the response to a webhook request
+type response struct {
+	Message   string   `json:"message"`
+	BuildRuns []string `json:"buildRuns,omitempty"`
+}
+
+// serve handles a webhook request of a Git hosting service. It creates a BuildRun for every Build
+// whose Git repository and trigger match the event of the request, and whose trigger secret
+// verifies the request.
+func serve(ctx context.Context, c client.Client, w http.ResponseWriter, r *http.Request, p provider) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not supported", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read the payload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	events, err := p.parse(r.Header, payload)
+	if err != nil {
+		ctxlog.Error(ctx, err, "failed to parse webhook request", "type", p.triggerType())
+		http.Error(w, fmt.Sprintf("failed to parse the payload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if len(events) == 0 {
+		writeResponse(ctx, w, http.StatusOK, response{Message: "the event does not trigger Builds"})
+		return
+	}
+
+	var buildRuns []string
+	rejected := false
+	for i := range events {
+		eventBuildRuns, eventRejected, err := triggerBuilds(ctx, c, r.Header, payload, p, &events[i])
+		if err != nil {
+			ctxlog.Error(ctx, err, "failed to trigger Builds", "type", p.triggerType(), "delivery", events[i].delivery)
+			http.Error(w, fmt.Sprintf("failed to trigger Builds: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		buildRuns = append(buildRuns, eventBuildRuns...)
+		rejected = rejected || eventRejected
+	}
+
+	if len(buildRuns) == 0 && rejected {
+		http.Error(w, "the request could not be verified with the trigger secret of any matching Build", http.StatusForbidden)
+		return
+	}
+
+	writeResponse(ctx, w, http.StatusOK, response{
+		Message:   fmt.Sprintf("created %d BuildRun(s)", len(buildRuns)),
+		BuildRuns: buildRuns,
+	})
+}
+
+// triggerBuilds creates a BuildRun pinned to the commit of the event for every Build that matches the
+// event. It returns the created BuildRuns in the format namespace/name, and whether a matching Build
+// was skipped because its trigger secret did not verify the request.
+func triggerBuilds(ctx context.Context, c client.Client, header http.Header, payload []byte, p provider, e *event) ([]string, bool, error) {
+	buildList := &buildapi.BuildList{}
+	if err := c.List(ctx, buildList); err != nil {
+		return nil, false, err
+	}
+
+	var buildRuns []string
+	rejected := false
+	for i := range buildList.Items {
+		b := &buildList.Items[i]
+		if b.Spec.Trigger == nil || b.Status.Registered == nil || *b.Status.Registered != corev1.ConditionTrue {
+			continue
+		}
+
+		if b.Spec.Source == nil || b.Spec.Source.Type != buildapi.GitType || b.Spec.Source.Git == nil || !repositoryMatches(b.Spec.Source.Git.URL, e.repositoryURLs) {
+			continue
+		}
+
+		when := matchingTrigger(b, p, e)
+		if when == nil {
+			continue
+		}
+
+		// a webhook request must never trigger a Build without being verified
+		token, err := triggerSecretToken(ctx, c, b)
+		if err != nil {
+			ctxlog.Info(ctx, "skipping Build without a usable trigger secret", namespace, b.Namespace, name, b.Name, "error", err.Error())
+			rejected = true
+			continue
+		}
+
+		if !p.verify(header, payload, token) {
+			ctxlog.Info(ctx, "skipping Build as its trigger secret does not verify the request", namespace, b.Namespace, name, b.Name, "delivery", e.delivery)
+			rejected = true
+			continue
+		}
+
+		// the name is derived from the trigger and the event so that a redelivered event never creates more than
+		// one BuildRun, while different events for the same commit create one BuildRun each
+		buildRun, err := resources.CreateTriggeredBuildRun(ctx, c, b, when.Name, eventSuffix(when.Name, e), map[string]string{
+			buildapi.AnnotationBuildRunTriggerDelivery: e.delivery,
+		}, &buildapi.BuildRunSource{
+			Type: buildapi.GitType,
+			Git: &buildapi.BuildRunGitSource{
+				Revision: ptr.To(e.sha),
+			},
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		buildRuns = append(buildRuns, fmt.Sprintf("%s/%s", buildRun.Namespace, buildRun.Name))
+	}
+
+	return buildRuns, rejected, nil
+}
+
+// matchingTrigger returns the first trigger of the Build that reacts on the kind and branch of the event
+func matchingTrigger(b *buildapi.Build, p provider, e *event) *buildapi.TriggerWhen {
+	for i := range b.Spec.Trigger.When {
+		when := &b.Spec.Trigger.When[i]
+		if when.Type != p.triggerType() || !p.matches(*when, e) {
+			continue
+		}
+
+		if e.tag != "" {
+			return when
+		}
+
+		// without branches, the trigger matches the revision of the Build
+		branches := when.GetBranches(p.triggerType())
+		if len(branches) == 0 {
+			branches = []string{ptr.Deref(b.Spec.Source.Git.Revision, defaultBranch)}
+		}
+
+		if slices.Contains(branches, e.branch) {
+			return when
+		}
+	}
+
+	return nil
+}
+
+// triggerSecretToken returns the secret token of the trigger secret of a Build
+func triggerSecretToken(ctx context.Context, c client.Client, b *buildapi.Build) ([]byte, error) {
+	if b.Spec.Trigger.TriggerSecret == nil || *b.Spec.Trigger.TriggerSecret == "" {
+		return nil, fmt.Errorf("the Build does not define a trigger secret")
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: *b.Spec.Trigger.TriggerSecret, Namespace: b.Namespace}, secret); err != nil {
+		return nil, err
+	}
+
+	token, found := secret.Data[SecretTokenKey]
+	if !found || len(token) == 0 {
+		return nil, fmt.Errorf("the secret %s does not contain the key %s", secret.Name, SecretTokenKey)
+	}
+
+	return token, nil
+}
+
+// repositoryMatches returns true if the Git URL of a Build is one of the URLs of a repository
+func repositoryMatches(gitURL string, repositoryURLs []string) bool {
+	normalizedURL := normalizeRepositoryURL(gitURL)
+	for _, repositoryURL := range repositoryURLs {
+		if repositoryURL != "" && normalizeRepositoryURL(repositoryURL) == normalizedURL {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeRepositoryURL reduces a Git URL to host and path, so that the HTTPS and SSH URLs of a
+// repository compare equal, for example https://github.com/org/repo.git and git@github.com:org/repo
+func normalizeRepositoryURL(gitURL string) string {
+	normalizedURL := strings.ToLower(strings.TrimSpace(gitURL))
+
+	_, rest, found := strings.Cut(normalizedURL, "://")
+	if found {
+		normalizedURL = rest
+	}
+
+	// remove the user, for example git@
+	if at := strings.Index(normalizedURL, "@"); at >= 0 && at < strings.IndexAny(normalizedURL+"/", ":/") {
+		normalizedURL = normalizedURL[at+1:]
+	}
+
+	// the scp-like syntax separates the host and the path with a colon
+	if !found {
+		if host, path, hasColon := strings.Cut(normalizedURL, ":"); hasColon && !strings.Contains(host, "/") {
+			normalizedURL = host + "/" + path
+		}
+	}
+
+	normalizedURL = strings.TrimSuffix(normalizedURL, "/")
+	return strings.TrimSuffix(normalizedURL, ".git")
+}
+
+// validHMAC returns true if the signature is the hex encoded HMAC SHA-256 digest of the payload
+// with the secret token as key
+func validHMAC(payload []byte, token []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, token)
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// isZeroSHA returns true for the commit SHA that Git services send for a deleted branch or tag
+func isZeroSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
+
+// eventSuffix returns a short hash of the trigger name, and the kind, the branch or tag, and the commit of an
+// event to be used in the BuildRun name
+func eventSuffix(triggerName string, e *event) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{triggerName, e.kind, e.branch, e.tag, e.sha}, "\n")))
+	return hex.EncodeToString(hash[:])[:10]
+}
+
+func writeResponse(ctx context.Context, w http.ResponseWriter, status int, body response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		ctxlog.Error(ctx, err, "encoding the webhook response failed")
+	}
+}
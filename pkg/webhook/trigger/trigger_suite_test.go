@@ -0,0 +1,126 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/webhook/trigger"
+)
+
+func TestTrigger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Trigger Suite")
+}
+
+// webhookToken is the secret token in the trigger secret of the test Builds
+const webhookToken = "webhook-token"
+
+// newBuild returns a registered Build of the Git repository with a trigger secret and the trigger
+func newBuild(gitURL string, when buildapi.TriggerWhen) *buildapi.Build {
+	return &buildapi.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "sample-go",
+			Namespace:  "build-examples",
+			Generation: 1,
+		},
+		Spec: buildapi.BuildSpec{
+			Source: &buildapi.Source{
+				Type: buildapi.GitType,
+				Git: &buildapi.Git{
+					URL: gitURL,
+				},
+			},
+			Trigger: &buildapi.Trigger{
+				When:          []buildapi.TriggerWhen{when},
+				TriggerSecret: ptr.To("webhook-secret"),
+			},
+		},
+		Status: buildapi.BuildStatus{
+			Registered: ptr.To(corev1.ConditionTrue),
+		},
+	}
+}
+
+// newFakeClient returns a client that lists the Build, and gets its trigger secret with the webhook token
+func newFakeClient(build *buildapi.Build) *fakes.FakeClient {
+	client := &fakes.FakeClient{}
+	client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+		if buildList, ok := list.(*buildapi.BuildList); ok {
+			buildList.Items = []buildapi.Build{*build}
+		}
+		return nil
+	})
+	client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+		if secret, ok := object.(*corev1.Secret); ok && nn.Name == "webhook-secret" && nn.Namespace == build.Namespace {
+			secret.Data = map[string][]byte{trigger.SecretTokenKey: []byte(webhookToken)}
+			return nil
+		}
+		return errors.NewNotFound(schema.GroupResource{}, nn.Name)
+	})
+	return client
+}
+
+// readPayload reads a recorded webhook payload
+func readPayload(provider string, file string) []byte {
+	payload, err := os.ReadFile(filepath.Join("testdata", provider, file))
+	Expect(err).ToNot(HaveOccurred())
+	return payload
+}
+
+// signature returns the hex encoded HMAC SHA-256 digest of the payload
+func signature(payload []byte, token string) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// post sends a webhook request with the headers and payload to the server
+func post(server *httptest.Server, header map[string]string, payload []byte) *http.Response {
+	request, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(payload))
+	Expect(err).ToNot(HaveOccurred())
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range header {
+		request.Header.Set(key, value)
+	}
+
+	response, err := server.Client().Do(request)
+	Expect(err).ToNot(HaveOccurred())
+	DeferCleanup(response.Body.Close)
+	return response
+}
+
+// createdBuildRuns returns the BuildRuns that were created with the client
+func createdBuildRuns(client *fakes.FakeClient) []*buildapi.BuildRun {
+	var buildRuns []*buildapi.BuildRun
+	for i := range client.CreateCallCount() {
+		_, object, _ := client.CreateArgsForCall(i)
+		buildRun, ok := object.(*buildapi.BuildRun)
+		Expect(ok).To(BeTrue())
+		buildRuns = append(buildRuns, buildRun)
+	}
+	return buildRuns
+}
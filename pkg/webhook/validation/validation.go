@@ -0,0 +1,149 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+// validateFunc validates an admission request and returns the reason and message of
+// the first failed validation, or empty strings when the object is valid
+type validateFunc func(ctx context.Context, request *admissionv1.AdmissionRequest) (string, string, error)
+
+// AdmissionHandler is a handle func for the /validate endpoint
+func AdmissionHandler(ctx context.Context) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Admission(ctx, w, r)
+	}
+}
+
+// Admission serves the /validate endpoint by passing an additional argument(ctx)
+func Admission(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	serve(ctx, w, r, validateSHPCR)
+}
+
+// serve handles an AdmissionReview object type, it will process the AdmissionRequest
+// and respond with an AdmissionResponse that allows or denies the request.
+func serve(ctx context.Context, w http.ResponseWriter, r *http.Request, validate validateFunc) {
+	var body []byte
+	if r.Body != nil {
+		if data, err := io.ReadAll(r.Body); err == nil {
+			body = data
+		}
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+		msg := fmt.Sprintf("invalid Content-Type header `%s`", contentType)
+		ctxlog.Error(ctx, errors.New(msg), "invalid header")
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	var admissionReview admissionv1.AdmissionReview
+	if err := json.Unmarshal(body, &admissionReview); err != nil || admissionReview.Request == nil {
+		msg := fmt.Sprintf("failed to deserialize body (%v) with error %v", string(body), err)
+		ctxlog.Error(ctx, errors.New(msg), "failed to deserialize")
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	ctxlog.Debug(ctx, "admission request", "uid", admissionReview.Request.UID, "kind", admissionReview.Request.Kind.Kind, "operation", admissionReview.Request.Operation)
+	admissionReview.Response = doValidation(ctx, admissionReview.Request, validate)
+	admissionReview.Response.UID = admissionReview.Request.UID
+	ctxlog.Info(ctx, "admission response", "allowed", admissionReview.Response.Allowed, "uid", admissionReview.Response.UID)
+
+	admissionReview.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(admissionReview); err != nil {
+		ctxlog.Error(ctx, err, "encoding the admission review failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// doValidation validates the object of an AdmissionRequest using the validate function
+// and returns an AdmissionResponse that allows or denies the request
+func doValidation(ctx context.Context, request *admissionv1.AdmissionRequest, validate validateFunc) *admissionv1.AdmissionResponse {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	// Objects that were stored before the webhook was registered might be invalid. Updates that
+	// do not touch the spec, for example removing a finalizer, must still be possible for them.
+	if request.Operation == admissionv1.Update {
+		unchanged, err := specUnchanged(request)
+		if err != nil {
+			return statusError(http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error())
+		}
+		if unchanged {
+			return &admissionv1.AdmissionResponse{Allowed: true}
+		}
+	}
+
+	reason, message, err := validate(ctx, request)
+	if err != nil {
+		ctxlog.Error(ctx, err, "failed to validate object", "kind", request.Kind.Kind, namespace, request.Namespace, name, request.Name)
+		return statusError(http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error())
+	}
+
+	if reason != "" {
+		return statusError(http.StatusUnprocessableEntity, metav1.StatusReason(reason), fmt.Sprintf("%s: %s", reason, message))
+	}
+
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// specUnchanged returns true if the spec of the updated object equals the one of the old object
+func specUnchanged(request *admissionv1.AdmissionRequest) (bool, error) {
+	var oldObject, newObject struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+
+	if err := json.Unmarshal(request.OldObject.Raw, &oldObject); err != nil {
+		return false, fmt.Errorf("failed to unmarshal old object: %w", err)
+	}
+	if err := json.Unmarshal(request.Object.Raw, &newObject); err != nil {
+		return false, fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+
+	var oldSpec, newSpec any
+	if len(oldObject.Spec) > 0 {
+		if err := json.Unmarshal(oldObject.Spec, &oldSpec); err != nil {
+			return false, fmt.Errorf("failed to unmarshal old object: %w", err)
+		}
+	}
+	if len(newObject.Spec) > 0 {
+		if err := json.Unmarshal(newObject.Spec, &newSpec); err != nil {
+			return false, fmt.Errorf("failed to unmarshal object: %w", err)
+		}
+	}
+
+	return equality.Semantic.DeepEqual(oldSpec, newSpec), nil
+}
+
+func statusError(code int32, reason metav1.StatusReason, message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    code,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
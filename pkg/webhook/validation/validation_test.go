@@ -0,0 +1,222 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+package validation_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/webhook/validation"
+)
+
+func admissionRequest(operation admissionv1.Operation, kind string, object runtime.Object, oldObject runtime.Object) *admissionv1.AdmissionRequest {
+	request := &admissionv1.AdmissionRequest{
+		UID:       types.UID("0000-0000-0000-0000"),
+		Kind:      metav1.GroupVersionKind{Group: "shipwright.io", Version: "v1beta1", Kind: kind},
+		Operation: operation,
+		Object:    runtime.RawExtension{Object: object},
+	}
+
+	if oldObject != nil {
+		request.OldObject = runtime.RawExtension{Object: oldObject}
+	}
+
+	return request
+}
+
+func getAdmissionResponse(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	body, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  request,
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	response := httptest.NewRecorder()
+	httpRequest, err := http.NewRequest("POST", "/validate", strings.NewReader(string(body)))
+	Expect(err).ToNot(HaveOccurred())
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	validation.Admission(context.TODO(), response, httpRequest)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var admissionReview admissionv1.AdmissionReview
+	Expect(json.Unmarshal(response.Body.Bytes(), &admissionReview)).To(Succeed())
+	Expect(admissionReview.Kind).To(Equal("AdmissionReview"))
+	Expect(admissionReview.Response).ToNot(BeNil())
+	Expect(admissionReview.Response.UID).To(Equal(request.UID))
+
+	return admissionReview.Response
+}
+
+var _ = Describe("Admission", func() {
+
+	var build *buildapi.Build
+
+	BeforeEach(func() {
+		build = &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "buildah-build", Namespace: "default"},
+			Spec: buildapi.BuildSpec{
+				Source: &buildapi.Source{
+					Type: buildapi.GitType,
+					Git:  &buildapi.Git{URL: "https://github.com/shipwright-io/sample-go"},
+				},
+				Strategy: buildapi.Strategy{Name: "buildah"},
+				Output:   buildapi.Image{Image: "registry.example.com/sample-go"},
+			},
+		}
+	})
+
+	It("rejects requests with an invalid Content-Type", func() {
+		response := httptest.NewRecorder()
+		httpRequest, err := http.NewRequest("POST", "/validate", strings.NewReader("{}"))
+		Expect(err).ToNot(HaveOccurred())
+		httpRequest.Header.Add("Content-Type", "application/yaml")
+
+		validation.Admission(context.TODO(), response, httpRequest)
+		Expect(response.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	Context("for a Build", func() {
+		It("allows a valid Build", func() {
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "Build", build, nil))
+			Expect(response.Allowed).To(BeTrue())
+		})
+
+		It("denies a Build with an environment variable without a name", func() {
+			build.Spec.Env = []corev1.EnvVar{{Value: "some-value"}}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "Build", build, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(Equal(int32(http.StatusUnprocessableEntity)))
+			Expect(string(response.Result.Reason)).To(Equal(string(buildapi.SpecEnvNameCanNotBeBlank)))
+			Expect(response.Result.Message).To(Equal("SpecEnvNameCanNotBeBlank: name for environment variable must not be blank"))
+		})
+
+		It("denies a Build with an invalid output timestamp", func() {
+			build.Spec.Output.Timestamp = ptr.To("yesterday")
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "Build", build, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(string(buildapi.OutputTimestampNotValid)))
+		})
+
+		It("denies a Build with a system parameter", func() {
+			build.Spec.ParamValues = []buildapi.ParamValue{{Name: "shp-output-image", SingleValue: &buildapi.SingleValue{Value: ptr.To("image")}}}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "Build", build, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal("RestrictedParametersInUse"))
+		})
+
+		It("denies an update that makes the Build invalid", func() {
+			invalidBuild := build.DeepCopy()
+			invalidBuild.Spec.SchedulerName = ptr.To(strings.Repeat("s", 64))
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Update, "Build", invalidBuild, build))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(string(buildapi.SchedulerNameNotValid)))
+		})
+
+		It("allows an update of an invalid Build that does not change the spec", func() {
+			build.Spec.Env = []corev1.EnvVar{{Value: "some-value"}}
+			updatedBuild := build.DeepCopy()
+			updatedBuild.Finalizers = nil
+			updatedBuild.Labels = map[string]string{"some": "label"}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Update, "Build", updatedBuild, build))
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	Context("for a BuildRun", func() {
+		var buildRun *buildapi.BuildRun
+
+		BeforeEach(func() {
+			buildRun = &buildapi.BuildRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "buildah-buildrun", Namespace: "default"},
+				Spec: buildapi.BuildRunSpec{
+					Build: buildapi.ReferencedBuild{Name: ptr.To("buildah-build")},
+				},
+			}
+		})
+
+		It("allows a valid BuildRun", func() {
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "BuildRun", buildRun, nil))
+			Expect(response.Allowed).To(BeTrue())
+		})
+
+		It("denies a BuildRun that references and embeds a Build", func() {
+			buildRun.Spec.Build.Spec = &build.Spec
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "BuildRun", buildRun, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal("BuildRunAmbiguousBuild"))
+		})
+
+		It("denies a BuildRun with an invalid embedded Build", func() {
+			build.Spec.Tolerations = []corev1.Toleration{{Key: "some-key", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute}}
+			buildRun.Spec.Build = buildapi.ReferencedBuild{Spec: &build.Spec}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "BuildRun", buildRun, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(string(buildapi.TolerationNotValid)))
+		})
+
+		It("denies a BuildRun with an invalid node selector", func() {
+			buildRun.Spec.NodeSelector = map[string]string{"invalid/label/key": "value"}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "BuildRun", buildRun, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(string(buildapi.NodeSelectorNotValid)))
+		})
+
+		It("denies a BuildRun with an invalid platform", func() {
+			buildRun.Spec.Output = &buildapi.Image{Platforms: []buildapi.ImagePlatform{{OS: "Linux", Arch: "amd64"}}}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "BuildRun", buildRun, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(string(buildapi.InvalidPlatform)))
+		})
+	})
+
+	Context("for a build strategy", func() {
+		It("allows a valid ClusterBuildStrategy", func() {
+			strategy := &buildapi.ClusterBuildStrategy{
+				ObjectMeta: metav1.ObjectMeta{Name: "noop"},
+				Spec: buildapi.BuildStrategySpec{
+					Steps: []buildapi.Step{{Name: "step", Image: "alpine", Command: []string{"echo", "$(params.shp-output-image)"}}},
+				},
+			}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "ClusterBuildStrategy", strategy, nil))
+			Expect(response.Allowed).To(BeTrue())
+		})
+
+		It("denies a BuildStrategy that references an undefined parameter", func() {
+			strategy := &buildapi.BuildStrategy{
+				ObjectMeta: metav1.ObjectMeta{Name: "noop", Namespace: "default"},
+				Spec: buildapi.BuildStrategySpec{
+					Steps: []buildapi.Step{{Name: "step", Image: "alpine", Command: []string{"echo", "$(params.undefined)"}}},
+				},
+			}
+
+			response := getAdmissionResponse(admissionRequest(admissionv1.Create, "BuildStrategy", strategy, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(string(buildapi.BuildStrategyUndefinedParameter)))
+		})
+	})
+})
@@ -0,0 +1,185 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+const (
+	namespace = "namespace"
+	name      = "name"
+)
+
+// buildValidationTypes are the Build validations that only inspect the Build itself. Validations
+// that need to look up other objects, like secrets or strategies, remain with the Build reconciler.
+var buildValidationTypes = [...]string{
+	validate.Source,
+	validate.Output,
+	validate.BuildName,
+	validate.Envs,
+	validate.Triggers,
+	validate.NodeSelector,
+	validate.Tolerations,
+	validate.SchedulerName,
+	validate.RuntimeClassName,
+}
+
+// validateSHPCR decodes the object of an admission request into the Shipwright type of the
+// requested kind and runs all validations that do not depend on other objects
+func validateSHPCR(ctx context.Context, request *admissionv1.AdmissionRequest) (string, string, error) {
+	if request.Kind.Group != buildapi.SchemeGroupVersion.Group || request.Kind.Version != buildapi.SchemeGroupVersion.Version {
+		ctxlog.Info(ctx, "nothing to validate", "kind", request.Kind.String())
+		return "", "", nil
+	}
+
+	switch request.Kind.Kind {
+	case "Build":
+		var build buildapi.Build
+		if err := json.Unmarshal(request.Object.Raw, &build); err != nil {
+			return "", "", err
+		}
+		return validateBuild(ctx, &build)
+
+	case "BuildRun":
+		var buildRun buildapi.BuildRun
+		if err := json.Unmarshal(request.Object.Raw, &buildRun); err != nil {
+			return "", "", err
+		}
+		return validateBuildRun(ctx, &buildRun)
+
+	case "BuildStrategy":
+		var buildStrategy buildapi.BuildStrategy
+		if err := json.Unmarshal(request.Object.Raw, &buildStrategy); err != nil {
+			return "", "", err
+		}
+		return validateStrategy(&buildStrategy)
+
+	case "ClusterBuildStrategy":
+		var clusterBuildStrategy buildapi.ClusterBuildStrategy
+		if err := json.Unmarshal(request.Object.Raw, &clusterBuildStrategy); err != nil {
+			return "", "", err
+		}
+		return validateStrategy(&clusterBuildStrategy)
+
+	default:
+		ctxlog.Info(ctx, "nothing to validate", "kind", request.Kind.String())
+		return "", "", nil
+	}
+}
+
+// validateBuild runs the Build validations of the reconciler that only inspect the Build itself
+func validateBuild(ctx context.Context, build *buildapi.Build) (string, string, error) {
+	// the validations report their result in the status, start from an empty one
+	build.Status = buildapi.BuildStatus{}
+
+	for _, validationType := range buildValidationTypes {
+		v, err := validate.NewValidation(validationType, build, nil, nil)
+		if err != nil {
+			return "", "", err
+		}
+
+		// the returned errors are not relevant, a failed validation sets the status reason
+		_ = v.ValidatePath(ctx)
+
+		if build.Status.Reason != nil && *build.Status.Reason != buildapi.SucceedStatus {
+			return string(*build.Status.Reason), ptr.Deref(build.Status.Message, ""), nil
+		}
+	}
+
+	if valid, reason, message := validate.ParamValues(build.Spec.ParamValues); !valid {
+		return reason, message, nil
+	}
+
+	return "", "", nil
+}
+
+// validateBuildRun runs the BuildRun validations of the reconciler that only inspect the BuildRun itself
+func validateBuildRun(ctx context.Context, buildRun *buildapi.BuildRun) (string, string, error) {
+	if errs := validation.IsValidLabelValue(buildRun.Name); len(errs) > 0 {
+		return resources.BuildRunNameInvalid, strings.Join(errs, ", "), nil
+	}
+
+	if reason, message := validate.BuildRunFields(buildRun); reason != "" {
+		return reason, message, nil
+	}
+
+	// an embedded build specification is validated like a Build
+	if buildRun.Spec.Build.Spec != nil {
+		if reason, message, err := validateBuild(ctx, &buildapi.Build{ObjectMeta: buildRun.ObjectMeta, Spec: *buildRun.Spec.Build.Spec}); err != nil || reason != "" {
+			return reason, message, err
+		}
+	}
+
+	// the environment variables of a BuildRun are validated like the ones of a Build
+	envBuild := &buildapi.Build{Spec: buildapi.BuildSpec{Env: buildRun.Spec.Env}}
+	_ = validate.NewEnv(envBuild).ValidatePath(ctx)
+	if envBuild.Status.Reason != nil {
+		return string(*envBuild.Status.Reason), ptr.Deref(envBuild.Status.Message, ""), nil
+	}
+
+	if valid, reason, message := validate.ParamValues(buildRun.Spec.ParamValues); !valid {
+		return reason, message, nil
+	}
+
+	if valid, reason, message := validate.BuildRunNodeSelector(buildRun.Spec.NodeSelector); !valid {
+		return reason, message, nil
+	}
+
+	if valid, reason, message := validate.BuildRunTolerations(buildRun.Spec.Tolerations); !valid {
+		return reason, message, nil
+	}
+
+	if valid, reason, message := validate.BuildRunSchedulerName(buildRun.Spec.SchedulerName); !valid {
+		return reason, message, nil
+	}
+
+	if valid, reason, message := validate.BuildRunRuntimeClassName(buildRun.Spec.RuntimeClassName); !valid {
+		return reason, message, nil
+	}
+
+	if buildRun.Spec.Output != nil {
+		if buildRun.Spec.Output.Timestamp != nil {
+			if valid, reason, message := validate.ValidateOutputTimestamp(*buildRun.Spec.Output.Timestamp); !valid {
+				return reason, message, nil
+			}
+		}
+
+		if valid, reason, message := validate.ValidateOutputTags(*buildRun.Spec.Output); !valid {
+			return reason, message, nil
+		}
+
+		if len(buildRun.Spec.Output.Platforms) > 0 {
+			if valid, reason, message := validate.ValidatePlatforms(buildRun.Spec.Output.Platforms); !valid {
+				return reason, message, nil
+			}
+
+			if valid, reason, message := validate.ValidateOutputNodeSelector(buildRun.Spec.NodeSelector); !valid {
+				return reason, message, nil
+			}
+		}
+	}
+
+	return "", "", nil
+}
+
+// validateStrategy runs the same validations as the BuildStrategy and ClusterBuildStrategy reconcilers
+func validateStrategy(strategy buildapi.BuilderStrategy) (string, string, error) {
+	if valid, reason, message := validate.BuildStrategySpec(strategy); !valid {
+		return string(reason), message, nil
+	}
+
+	return "", "", nil
+}
@@ -195,6 +195,8 @@ var _ = Describe("Integration tests Build and TaskRun", func() {
 					"$(results.shp-image-size.path)",
 					"--result-file-image-vulnerabilities",
 					"$(results.shp-image-vulnerabilities.path)",
+					"--result-file-image-tags",
+					"$(results.shp-image-tags.path)",
 				}))
 			})
 
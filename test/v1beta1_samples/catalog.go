@@ -1007,6 +1007,29 @@ func (c *Catalog) BuildRunWithStepResourcesOverride(buildRunName string, buildNa
 	}
 }
 
+// BuildRunWithGitRevisionOverride returns a customized BuildRun object
+// that overrides the revision of the Git source
+func (c *Catalog) BuildRunWithGitRevisionOverride(buildRunName string, buildName string, revision string) *buildapi.BuildRun {
+	return &buildapi.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: buildRunName,
+		},
+		Spec: buildapi.BuildRunSpec{
+			Build: buildapi.ReferencedBuild{
+				Name: &buildName,
+				Spec: &buildapi.BuildSpec{Strategy: buildapi.Strategy{Name: "foobar"}},
+			},
+			Source: &buildapi.BuildRunSource{
+				Type: buildapi.GitType,
+				Git: &buildapi.BuildRunGitSource{
+					Revision: &revision,
+				},
+			},
+		},
+		Status: buildapi.BuildRunStatus{},
+	}
+}
+
 // BuildRunWithSucceededCondition returns a BuildRun with a single condition
 // of the type Succeeded
 func (c *Catalog) BuildRunWithSucceededCondition() *buildapi.BuildRun {